@@ -2,6 +2,7 @@ package weave
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/errors"
@@ -45,18 +46,25 @@ type DeliverResult struct {
 	RequiredFee coin.Coin
 	// Diff, if present, will apply to the Validator set in tendermint next block
 	Diff []abci.ValidatorUpdate
+	// ConsensusParams, if present, will replace the given sections
+	// of the consensus params in tendermint next block
+	ConsensusParams *abci.ConsensusParams
 	// Tags, if present, will be used by tendermint to index and search the transaction history
 	Tags []common.KVPair
-	// GasUsed is currently unused field until effects in tendermint are clear
+	// GasAllocated is the maximum units of work this tx was allowed to perform
+	GasAllocated int64
+	// GasUsed is the units of work this tx performed (see utils.GasLimiter)
 	GasUsed int64
 }
 
 // ToABCI converts our internal type into an abci response
 func (d DeliverResult) ToABCI() abci.ResponseDeliverTx {
 	return abci.ResponseDeliverTx{
-		Data: d.Data,
-		Log:  d.Log,
-		Tags: d.Tags,
+		Data:      d.Data,
+		Log:       d.Log,
+		Tags:      d.Tags,
+		GasWanted: d.GasAllocated,
+		GasUsed:   d.GasUsed,
 	}
 }
 
@@ -72,9 +80,15 @@ type CheckResult struct {
 	RequiredFee coin.Coin
 	// GasAllocated is the maximum units of work we allow this tx to perform
 	GasAllocated int64
+	// GasUsed is the units of work this tx performed while checking
+	GasUsed int64
 	// GasPayment is the total fees for this tx (or other source of payment)
 	//TODO: Implement when tendermint implements this properly
 	GasPayment int64
+	// Priority tells an ordering-aware mempool to prefer this tx over
+	// others with a lower one (see cash.PriorityConfig). It is passed
+	// on as the PriorityTag, as abci has no field for it yet
+	Priority int64
 }
 
 // NewCheck sets the gas used and the response data but no more info
@@ -88,18 +102,42 @@ func NewCheck(gasAllocated int64, log string) CheckResult {
 
 // ToABCI converts our internal type into an abci response
 func (c CheckResult) ToABCI() abci.ResponseCheckTx {
-	return abci.ResponseCheckTx{
+	res := abci.ResponseCheckTx{
 		Data:      c.Data,
 		Log:       c.Log,
 		GasWanted: c.GasAllocated,
+		GasUsed:   c.GasUsed,
 	}
+	if c.Priority != 0 {
+		res.Tags = []common.KVPair{{
+			Key:   []byte(PriorityTag),
+			Value: []byte(strconv.FormatInt(c.Priority, 10)),
+		}}
+	}
+	return res
 }
 
+// PriorityTag is the tag key holding the decimal priority
+// of a transaction in the CheckTx response
+const PriorityTag = "priority"
+
 // TickResult allows the Ticker to modify the validator set
 type TickResult struct {
 	Diff []abci.ValidatorUpdate
 }
 
+// EndBlockResult allows the EndBlocker to modify the validator set
+// and consensus params, and to tag the block
+type EndBlockResult struct {
+	// Diff, if present, will apply to the Validator set in tendermint next block
+	Diff []abci.ValidatorUpdate
+	// ConsensusParams, if present, will replace the given sections
+	// of the consensus params in tendermint next block
+	ConsensusParams *abci.ConsensusParams
+	// Tags, if present, will be used by tendermint to index and search the block
+	Tags []common.KVPair
+}
+
 //---------- type safe error converters --------
 
 // DeliverTxError converts any error into a abci.ResponseDeliverTx,
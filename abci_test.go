@@ -8,6 +8,7 @@ import (
 	"github.com/iov-one/weave/errors"
 	pkerr "github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/libs/common"
 )
 
 func TestCreateErrorResult(t *testing.T) {
@@ -71,4 +72,9 @@ func TestCreateResults(t *testing.T) {
 	assert.Equal(t, c, ac.Log)
 	assert.Equal(t, gas, ac.GasWanted)
 	assert.Empty(t, ac.Data)
+	assert.Empty(t, ac.Tags)
+
+	cres.Priority = 4321
+	ac = cres.ToABCI()
+	assert.Equal(t, []common.KVPair{{Key: []byte(weave.PriorityTag), Value: []byte("4321")}}, ac.Tags)
 }
@@ -1,42 +1,62 @@
 package app
 
 import (
+	"strconv"
+	"sync/atomic"
+
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/common"
 
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/errors"
 )
 
-// BaseApp adds DeliverTx, CheckTx, and BeginBlock
+// BaseApp adds DeliverTx, CheckTx, BeginBlock and EndBlock
 // handlers to the storage and query functionality of StoreApp
 type BaseApp struct {
 	*StoreApp
-	decoder weave.TxDecoder
-	handler weave.Handler
-	ticker  weave.Ticker
-	debug   bool
+	decoder    weave.TxDecoder
+	handler    weave.Handler
+	ticker     weave.Ticker
+	endBlocker weave.EndBlocker
+	debug      *debugFlag
 }
 
 var _ abci.Application = BaseApp{}
 
-// NewBaseApp constructs a basic abci application
+// NewBaseApp constructs a basic abci application,
+// ticker and endBlocker are optional
 func NewBaseApp(store *StoreApp, decoder weave.TxDecoder,
-	handler weave.Handler, ticker weave.Ticker, debug bool) BaseApp {
+	handler weave.Handler, ticker weave.Ticker,
+	endBlocker weave.EndBlocker, debug bool) BaseApp {
 
 	return BaseApp{
-		StoreApp: store,
-		decoder:  decoder,
-		handler:  handler,
-		ticker:   ticker,
-		debug:    debug,
+		StoreApp:   store,
+		decoder:    decoder,
+		handler:    handler,
+		ticker:     ticker,
+		endBlocker: endBlocker,
+		debug:      newDebugFlag(debug),
 	}
 }
 
+// RegisterOptions adds the "app.debug" option, which switches
+// returning the call stack of errors on and off at runtime
+func (b BaseApp) RegisterOptions(r weave.OptionRouter) {
+	r.Register("app.debug", b.debug.Set)
+}
+
 // DeliverTx - ABCI - dispatches to the handler
 func (b BaseApp) DeliverTx(txBytes []byte) abci.ResponseDeliverTx {
+	b.store.RLock()
+	defer b.store.RUnlock()
+
+	// every transaction has an index, even if it cannot be decoded
+	b.recordStep(StepDeliverTx)
+
 	tx, err := b.loadTx(txBytes)
 	if err != nil {
-		return weave.DeliverTxError(err, b.debug)
+		return weave.DeliverTxError(err, b.debug.IsOn())
 	}
 
 	// ignore error here, allow it to be logged
@@ -44,18 +64,46 @@ func (b BaseApp) DeliverTx(txBytes []byte) abci.ResponseDeliverTx {
 		"call", "deliver_tx",
 		"path", weave.GetPath(tx))
 
-	res, err := b.handler.Deliver(ctx, b.DeliverStore(), tx)
+	// collect events, they are only tagged if the transaction succeeded
+	events := weave.NewEventManager()
+	ctx = weave.WithEventManager(ctx, events)
+
+	// the validator and consensus params changes are only known once
+	// the handler is done, its writes are dropped if they are invalid
+	cache := b.DeliverStore().CacheWrap()
+	res, err := b.handler.Deliver(ctx, cache, tx)
 	if err == nil {
-		b.AddValChange(res.Diff)
+		err = b.addChanges(res.Diff, res.ConsensusParams)
+		if err != nil {
+			cache.Discard()
+			return weave.DeliverTxError(errors.Wrap(err, "invalid consensus change"), b.debug.IsOn())
+		}
+		res.Tags = append(res.Tags, events.Tags()...)
 	}
-	return weave.DeliverOrError(res, err, b.debug)
+	cache.Write()
+	return weave.DeliverOrError(res, err, b.debug.IsOn())
+}
+
+// addChanges adds both the validator and the consensus params changes,
+// or none of them if either is invalid
+func (b BaseApp) addChanges(diff []abci.ValidatorUpdate, params *abci.ConsensusParams) error {
+	if err := weave.ValidateConsensusParams(params); err != nil {
+		return err
+	}
+	if err := b.AddValChange(diff); err != nil {
+		return err
+	}
+	return b.AddParamsChange(params)
 }
 
 // CheckTx - ABCI - dispatches to the handler
 func (b BaseApp) CheckTx(txBytes []byte) abci.ResponseCheckTx {
+	b.store.RLock()
+	defer b.store.RUnlock()
+
 	tx, err := b.loadTx(txBytes)
 	if err != nil {
-		return weave.CheckTxError(err, b.debug)
+		return weave.CheckTxError(err, b.debug.IsOn())
 	}
 
 	ctx := weave.WithLogInfo(b.BlockContext(),
@@ -63,13 +111,16 @@ func (b BaseApp) CheckTx(txBytes []byte) abci.ResponseCheckTx {
 		"path", weave.GetPath(tx))
 
 	res, err := b.handler.Check(ctx, b.CheckStore(), tx)
-	return weave.CheckOrError(res, err, b.debug)
+	return weave.CheckOrError(res, err, b.debug.IsOn())
 }
 
 // BeginBlock - ABCI
 func (b BaseApp) BeginBlock(req abci.RequestBeginBlock) (
 	res abci.ResponseBeginBlock) {
 
+	b.store.RLock()
+	defer b.store.RUnlock()
+
 	// default: set the context properly
 	b.StoreApp.BeginBlock(req)
 
@@ -78,19 +129,86 @@ func (b BaseApp) BeginBlock(req abci.RequestBeginBlock) (
 		// start := time.Now()
 		// Add info to the logger
 		ctx := weave.WithLogInfo(b.BlockContext(), "call", "begin_block")
-		res, err := b.ticker.Tick(ctx, b.DeliverStore())
+		events := weave.NewEventManager()
+		ctx = weave.WithEventManager(ctx, events)
+		tres, err := b.ticker.Tick(ctx, b.DeliverStore())
 		// logDuration(ctx, start, "Ticker", err, false)
 		if err != nil {
 			panic(err)
 		}
-		b.StoreApp.AddValChange(res.Diff)
+		if err := b.StoreApp.AddValChange(tres.Diff); err != nil {
+			panic(err)
+		}
+		res.Tags = events.Tags()
 	}
 	return
 }
 
+// EndBlock - ABCI
+func (b BaseApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
+	b.store.RLock()
+	defer b.store.RUnlock()
+
+	// call the end blocker, if set, before the pending
+	// validator changes are flushed
+	if b.endBlocker == nil {
+		return b.StoreApp.EndBlock(req)
+	}
+	var tags []common.KVPair
+	res := b.StoreApp.endBlock(func() {
+		ctx := weave.WithLogInfo(b.BlockContext(), "call", "end_block")
+		events := weave.NewEventManager()
+		ctx = weave.WithEventManager(ctx, events)
+		res, err := b.endBlocker.EndBlock(ctx, b.DeliverStore())
+		if err != nil {
+			panic(err)
+		}
+		if err := b.addChanges(res.Diff, res.ConsensusParams); err != nil {
+			panic(err)
+		}
+		tags = append(res.Tags, events.Tags()...)
+	})
+	res.Tags = append(res.Tags, tags...)
+	return res
+}
+
 // loadTx calls the decoder, and capture any panics
 func (b BaseApp) loadTx(txBytes []byte) (tx weave.Tx, err error) {
 	defer errors.Recover(&err)
 	tx, err = b.decoder(txBytes)
 	return
 }
+
+// debugFlag can be switched while transactions are processed
+type debugFlag struct {
+	on int32
+}
+
+func newDebugFlag(on bool) *debugFlag {
+	d := &debugFlag{}
+	d.set(on)
+	return d
+}
+
+// IsOn returns true if debug output is enabled
+func (d *debugFlag) IsOn() bool {
+	return atomic.LoadInt32(&d.on) == 1
+}
+
+// Set parses the value as a bool and applies it
+func (d *debugFlag) Set(value string) error {
+	on, err := strconv.ParseBool(value)
+	if err != nil {
+		return errors.ErrInvalidInput.Newf("not a bool: %q", value)
+	}
+	d.set(on)
+	return nil
+}
+
+func (d *debugFlag) set(on bool) {
+	var v int32
+	if on {
+		v = 1
+	}
+	atomic.StoreInt32(&d.on, v)
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/common"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store/iavl"
+	"github.com/iov-one/weave/weavetest"
+)
+
+func TestBaseAppEvents(t *testing.T) {
+	storeApp := NewStoreApp("events", iavl.MockCommitStore(), weave.NewQueryRouter(), context.Background())
+	decoder := func(raw []byte) (weave.Tx, error) {
+		return &weavetest.Tx{Msg: &weavetest.Msg{RoutePath: string(raw)}}, nil
+	}
+	ticker := &emitTicker{event: weave.NewEvent("tick")}
+	base := NewBaseApp(storeApp, decoder, &emitHandler{}, ticker, nil, false)
+
+	bres := base.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	assert.Equal(t, ticker.event.Tags(), bres.Tags)
+
+	// events are turned into tags after the handler tags
+	res := base.DeliverTx([]byte("ok"))
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	want := append([]common.KVPair{{Key: []byte("handler"), Value: []byte("ok")}},
+		weave.NewEvent("test").With("path", "ok").Tags()...)
+	assert.Equal(t, want, res.Tags)
+
+	// events of a failed transaction are dropped
+	res = base.DeliverTx([]byte("fail"))
+	assert.NotEqual(t, uint32(0), res.Code)
+	assert.Empty(t, res.Tags)
+}
+
+func TestBaseAppDebugOption(t *testing.T) {
+	storeApp := NewStoreApp("debug", iavl.MockCommitStore(), weave.NewQueryRouter(), context.Background())
+	decoder := func(raw []byte) (weave.Tx, error) {
+		return &weavetest.Tx{Msg: &weavetest.Msg{RoutePath: string(raw)}}, nil
+	}
+	base := NewBaseApp(storeApp, decoder, &emitHandler{}, nil, nil, false)
+	options := weave.NewOptionRouter()
+	base.RegisterOptions(options)
+	base.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	quiet := base.DeliverTx([]byte("fail"))
+	assert.NoError(t, options.Set("app.debug", "true"))
+	verbose := base.DeliverTx([]byte("fail"))
+	assert.Equal(t, quiet.Code, verbose.Code)
+	assert.NotEqual(t, quiet.Log, verbose.Log)
+
+	err := options.Set("app.debug", "maybe")
+	assert.True(t, errors.ErrInvalidInput.Is(err))
+	assert.True(t, base.debug.IsOn())
+}
+
+func TestBaseAppInvalidConsensusChange(t *testing.T) {
+	storeApp := NewStoreApp("changes", iavl.MockCommitStore(), weave.NewQueryRouter(), context.Background())
+	decoder := func(raw []byte) (weave.Tx, error) {
+		return &weavetest.Tx{Msg: &weavetest.Msg{RoutePath: string(raw)}}, nil
+	}
+	handler := &changeHandler{}
+	base := NewBaseApp(storeApp, decoder, handler, nil, nil, false)
+	base.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	valid := validatorUpdate(1)
+	handler.res = weave.DeliverResult{Diff: []abci.ValidatorUpdate{valid}}
+	res := base.DeliverTx([]byte("valid"))
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+
+	// the transaction is rejected and its writes are dropped
+	invalid := validatorUpdate(2)
+	invalid.Power = -1
+	handler.res = weave.DeliverResult{Diff: []abci.ValidatorUpdate{invalid}}
+	res = base.DeliverTx([]byte("negative"))
+	assert.NotEqual(t, uint32(0), res.Code)
+
+	handler.res = weave.DeliverResult{
+		Diff:            []abci.ValidatorUpdate{validatorUpdate(3)},
+		ConsensusParams: &abci.ConsensusParams{BlockSize: &abci.BlockSizeParams{MaxBytes: 0}},
+	}
+	res = base.DeliverTx([]byte("params"))
+	assert.NotEqual(t, uint32(0), res.Code)
+
+	db := base.DeliverStore()
+	assert.NotNil(t, db.Get([]byte("valid")))
+	assert.Nil(t, db.Get([]byte("negative")))
+	assert.Nil(t, db.Get([]byte("params")))
+
+	end := base.EndBlock(abci.RequestEndBlock{Height: 1})
+	assert.Equal(t, []abci.ValidatorUpdate{valid}, end.ValidatorUpdates)
+	assert.Nil(t, end.ConsensusParamUpdates)
+}
+
+// changeHandler writes the path of the message and returns res
+type changeHandler struct {
+	weavetest.Handler
+	res weave.DeliverResult
+}
+
+func (h *changeHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	msg, _ := tx.GetMsg()
+	db.Set([]byte(msg.Path()), []byte(msg.Path()))
+	return h.res, nil
+}
+
+// emitHandler emits an event with the path of the message
+// on delivery, and fails if that path is "fail"
+type emitHandler struct {
+	weavetest.Handler
+}
+
+func (h *emitHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	msg, _ := tx.GetMsg()
+	weave.EmitEvents(ctx, weave.NewEvent("test").With("path", msg.Path()))
+	if msg.Path() == "fail" {
+		return weave.DeliverResult{}, errors.ErrInvalidInput.New("fail")
+	}
+	return weave.DeliverResult{
+		Tags: []common.KVPair{{Key: []byte("handler"), Value: []byte("ok")}},
+	}, nil
+}
+
+type emitTicker struct {
+	event weave.Event
+}
+
+func (t *emitTicker) Tick(ctx weave.Context, db weave.KVStore) (weave.TickResult, error) {
+	weave.EmitEvents(ctx, t.event)
+	return weave.TickResult{}, nil
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"github.com/iov-one/weave"
+)
+
+//------ begin and end block -----
+
+// ChainTickers lets you run many tickers at the beginning of a block
+func ChainTickers(tickers ...weave.Ticker) weave.Ticker {
+	return chainTicker{tickers}
+}
+
+type chainTicker struct {
+	tickers []weave.Ticker
+}
+
+// Tick calls all Tickers in the list, aborting at the first error.
+// Validator diffs of all tickers are combined.
+func (c chainTicker) Tick(ctx weave.Context, store weave.KVStore) (weave.TickResult, error) {
+	var res weave.TickResult
+	for _, t := range c.tickers {
+		r, err := t.Tick(ctx, store)
+		if err != nil {
+			return res, err
+		}
+		res.Diff = append(res.Diff, r.Diff...)
+	}
+	return res, nil
+}
+
+// ChainEndBlockers lets you run many end blockers at the end of a block
+func ChainEndBlockers(blockers ...weave.EndBlocker) weave.EndBlocker {
+	return chainEndBlocker{blockers}
+}
+
+type chainEndBlocker struct {
+	blockers []weave.EndBlocker
+}
+
+// EndBlock calls all EndBlockers in the list, aborting at the first error.
+// Validator diffs, consensus params and tags of all end blockers are combined.
+func (c chainEndBlocker) EndBlock(ctx weave.Context, store weave.KVStore) (weave.EndBlockResult, error) {
+	var res weave.EndBlockResult
+	for _, b := range c.blockers {
+		r, err := b.EndBlock(ctx, store)
+		if err != nil {
+			return res, err
+		}
+		res.Diff = append(res.Diff, r.Diff...)
+		res.ConsensusParams = weave.MergeConsensusParams(res.ConsensusParams, r.ConsensusParams)
+		res.Tags = append(res.Tags, r.Tags...)
+	}
+	return res, nil
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/common"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/store/iavl"
+	"github.com/iov-one/weave/weavetest"
+)
+
+func TestChainTickers(t *testing.T) {
+	d1 := validatorUpdate(1)
+	d2 := validatorUpdate(2)
+
+	ticker := ChainTickers(
+		&mockTicker{res: weave.TickResult{Diff: []abci.ValidatorUpdate{d1}}},
+		&mockTicker{res: weave.TickResult{Diff: []abci.ValidatorUpdate{d2}}},
+	)
+	res, err := ticker.Tick(context.Background(), store.MemStore())
+	require.NoError(t, err)
+	assert.Equal(t, []abci.ValidatorUpdate{d1, d2}, res.Diff)
+
+	last := &mockTicker{}
+	ticker = ChainTickers(&mockTicker{err: fmt.Errorf("failed")}, last)
+	_, err = ticker.Tick(context.Background(), store.MemStore())
+	assert.Error(t, err)
+	assert.Equal(t, 0, last.calls)
+}
+
+func TestChainEndBlockers(t *testing.T) {
+	d1 := validatorUpdate(1)
+	tag1 := common.KVPair{Key: []byte("a"), Value: []byte("1")}
+	tag2 := common.KVPair{Key: []byte("b"), Value: []byte("2")}
+
+	blocker := ChainEndBlockers(
+		&mockEndBlocker{res: weave.EndBlockResult{Tags: []common.KVPair{tag1}}},
+		&mockEndBlocker{res: weave.EndBlockResult{Diff: []abci.ValidatorUpdate{d1}, Tags: []common.KVPair{tag2}}},
+	)
+	res, err := blocker.EndBlock(context.Background(), store.MemStore())
+	require.NoError(t, err)
+	assert.Equal(t, []abci.ValidatorUpdate{d1}, res.Diff)
+	assert.Equal(t, []common.KVPair{tag1, tag2}, res.Tags)
+
+	last := &mockEndBlocker{}
+	blocker = ChainEndBlockers(&mockEndBlocker{err: fmt.Errorf("failed")}, last)
+	_, err = blocker.EndBlock(context.Background(), store.MemStore())
+	assert.Error(t, err)
+	assert.Equal(t, 0, last.calls)
+}
+
+func TestBaseAppEndBlock(t *testing.T) {
+	storeApp := NewStoreApp("endblock", iavl.MockCommitStore(), weave.NewQueryRouter(), context.Background())
+
+	// validator changes of txs and the end blocker are merged
+	tag := common.KVPair{Key: []byte("auction"), Value: []byte("settled")}
+	d1, d2 := validatorUpdate(1), validatorUpdate(2)
+	params := &abci.ConsensusParams{BlockSize: &abci.BlockSizeParams{MaxBytes: 2048}}
+	blocker := &mockEndBlocker{res: weave.EndBlockResult{
+		Diff:            []abci.ValidatorUpdate{d2},
+		ConsensusParams: params,
+		Tags:            []common.KVPair{tag},
+	}}
+	base := NewBaseApp(storeApp, nil, &weavetest.Handler{}, nil, blocker, false)
+	base.AddValChange([]abci.ValidatorUpdate{d1})
+
+	base.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	res := base.EndBlock(abci.RequestEndBlock{Height: 1})
+	assert.Equal(t, 1, blocker.calls)
+	assert.Equal(t, []abci.ValidatorUpdate{d1, d2}, res.ValidatorUpdates)
+	assert.Equal(t, []common.KVPair{tag}, res.Tags)
+	assert.Equal(t, params, res.ConsensusParamUpdates)
+
+	// a failing end blocker cannot be handled
+	base = NewBaseApp(storeApp, nil, &weavetest.Handler{}, nil, &mockEndBlocker{err: fmt.Errorf("failed")}, false)
+	assert.Panics(t, func() { base.EndBlock(abci.RequestEndBlock{Height: 2}) })
+}
+
+func validatorUpdate(i byte) abci.ValidatorUpdate {
+	return abci.ValidatorUpdate{
+		PubKey: abci.PubKey{Type: "ed25519", Data: append(make([]byte, 31), i)},
+		Power:  10,
+	}
+}
+
+type mockTicker struct {
+	res   weave.TickResult
+	err   error
+	calls int
+}
+
+func (t *mockTicker) Tick(weave.Context, weave.KVStore) (weave.TickResult, error) {
+	t.calls++
+	return t.res, t.err
+}
+
+type mockEndBlocker struct {
+	res   weave.EndBlockResult
+	err   error
+	calls int
+}
+
+func (e *mockEndBlocker) EndBlock(weave.Context, weave.KVStore) (weave.EndBlockResult, error) {
+	e.calls++
+	return e.res, e.err
+}
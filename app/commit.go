@@ -1,13 +1,23 @@
 package app
 
 import (
+	"sync"
+
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
 )
 
 // CommitStore handles loading from a KVCommitStore, maintaining different
 // CacheWraps for Deliver and Check, and returning useful state info.
+//
+// Commit modifies the committed store and replaces the caches, so it must
+// not run while they are in use. The check, deliver and query paths hold a
+// read lock (see RLock) while they use any of the stores, and may run
+// concurrently. Commit waits until all of them released it.
 type CommitStore struct {
+	mtx sync.RWMutex
+
 	committed weave.CommitKVStore
 	deliver   weave.KVCacheWrap
 	check     weave.KVCacheWrap
@@ -33,12 +43,25 @@ func (cs *CommitStore) CommitInfo() (version int64, hash []byte) {
 	return id.Version, id.Hash
 }
 
+// RLock locks the store for reading, so Commit cannot modify it while
+// it is used. It must not be called recursively.
+func (cs *CommitStore) RLock() {
+	cs.mtx.RLock()
+}
+
+// RUnlock releases the read lock taken with RLock
+func (cs *CommitStore) RUnlock() {
+	cs.mtx.RUnlock()
+}
+
 // Commit will flush deliver to the underlying store and commit it
-// to disk. It then regenerates new deliver/check caches
+// to disk. It then regenerates new deliver/check caches.
 //
-// TODO: this should probably be protected by a mutex....
-// need to think what concurrency we expect
+// It waits until no reader holds the store.
 func (cs *CommitStore) Commit() weave.CommitID {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+
 	// flush deliver to store and discard check
 	cs.deliver.Write()
 	cs.check.Discard()
@@ -52,6 +75,50 @@ func (cs *CommitStore) Commit() weave.CommitID {
 	return res
 }
 
+// cacheStatsReporter is implemented by stores caching values,
+// see store.ValueCache
+type cacheStatsReporter interface {
+	CacheStats() store.CacheStats
+}
+
+// CacheStats returns the hits and misses of the value cache of the
+// committed store, if it has one
+func (cs *CommitStore) CacheStats() (store.CacheStats, bool) {
+	c, ok := cs.committed.(cacheStatsReporter)
+	if !ok {
+		return store.CacheStats{}, false
+	}
+	return c.CacheStats(), true
+}
+
+// QueryStore returns a read-only snapshot of the committed state at the
+// given height, taken from the retained history. It is not affected by
+// later commits. Before the first commit, height 0 is an empty state.
+func (cs *CommitStore) QueryStore(height int64) (weave.ReadOnlyKVStore, error) {
+	latest := cs.committed.LatestVersion().Version
+	if height == 0 && latest == 0 {
+		return store.MemStore(), nil
+	}
+	if height <= 0 || height > latest {
+		return nil, errors.ErrInvalidInput.Newf("height %d, latest is %d", height, latest)
+	}
+	return cs.committed.ReadOnlyVersion(height)
+}
+
+// SimulateStore returns a throwaway cache over a snapshot of the latest
+// committed state. It must be discarded, as it can never be written.
+func (cs *CommitStore) SimulateStore() (weave.KVCacheWrap, error) {
+	latest := cs.committed.LatestVersion().Version
+	if latest == 0 {
+		return nil, errors.ErrInvalidInput.New("nothing committed")
+	}
+	snapshot, err := cs.QueryStore(latest)
+	if err != nil {
+		return nil, err
+	}
+	return store.NewBTreeCacheWrap(snapshot, store.NewNonAtomicBatch(nil), nil), nil
+}
+
 // CheckStore returns a store implementation that must be used during the
 // checking phase.
 func (cs *CommitStore) CheckStore() weave.CacheableKVStore {
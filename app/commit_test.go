@@ -0,0 +1,132 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/orm"
+	"github.com/iov-one/weave/store/iavl"
+	"github.com/iov-one/weave/weavetest"
+)
+
+// TestConcurrentCommitAndQuery runs blocks while checking transactions and
+// querying the state. Run with -race to detect unsafe access.
+func TestConcurrentCommitAndQuery(t *testing.T) {
+	const blocks = 50
+
+	qr := weave.NewQueryRouter()
+	orm.RegisterQuery(qr)
+	storeApp := NewStoreApp("race", iavl.MockCommitStore(), qr, context.Background())
+	decoder := func(raw []byte) (weave.Tx, error) {
+		return &weavetest.Tx{Msg: &weavetest.Msg{RoutePath: "race"}}, nil
+	}
+	base := NewBaseApp(storeApp, decoder, &pairHandler{}, nil, nil, false)
+
+	// commit the first block, so there is something to query
+	base.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	base.DeliverTx([]byte("tx"))
+	base.EndBlock(abci.RequestEndBlock{Height: 1})
+	base.Commit()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		for h := int64(2); h <= blocks; h++ {
+			base.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: h}})
+			if res := base.DeliverTx([]byte("tx")); res.Code != 0 {
+				t.Errorf("deliver at height %d: %s", h, res.Log)
+			}
+			base.EndBlock(abci.RequestEndBlock{Height: h})
+			base.Commit()
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if res := base.CheckTx([]byte("tx")); res.Code != 0 {
+				t.Errorf("check: %s", res.Log)
+			}
+		}
+	}()
+
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				res := base.Query(abci.RequestQuery{Path: "/?prefix", Data: []byte("pair")})
+				if res.Code != 0 {
+					t.Errorf("query: %s", res.Log)
+					continue
+				}
+				models := queryModels(t, res)
+				if len(models) != 2 || !bytes.Equal(models[0].Value, models[1].Value) {
+					t.Errorf("inconsistent query at height %d: %v", res.Height, models)
+				}
+				if want := encodeHeight(res.Height); len(models) > 0 && !bytes.Equal(want, models[0].Value) {
+					t.Errorf("query at height %d returned %X", res.Height, models[0].Value)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	info := base.Info(abci.RequestInfo{})
+	assert.Equal(t, int64(blocks), info.LastBlockHeight)
+	res := base.Query(abci.RequestQuery{Path: "/", Data: []byte("pair:a")})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	assert.Equal(t, encodeHeight(blocks), queryModels(t, res)[0].Value)
+}
+
+// pairHandler writes the block height to two keys on delivery,
+// and ensures both hold the same value on check
+type pairHandler struct{}
+
+var _ weave.Handler = (*pairHandler)(nil)
+
+func (h *pairHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.CheckResult, error) {
+	a, b := db.Get([]byte("pair:a")), db.Get([]byte("pair:b"))
+	if !bytes.Equal(a, b) {
+		return weave.CheckResult{}, errors.ErrInternal.Newf("inconsistent state: %X != %X", a, b)
+	}
+	return weave.CheckResult{}, nil
+}
+
+func (h *pairHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	height, _ := weave.GetHeight(ctx)
+	db.Set([]byte("pair:a"), encodeHeight(height))
+	db.Set([]byte("pair:b"), encodeHeight(height))
+	return weave.DeliverResult{}, nil
+}
+
+func encodeHeight(h int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(h))
+	return b
+}
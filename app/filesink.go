@@ -0,0 +1,249 @@
+package app
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/iov-one/weave/errors"
+)
+
+// FileSink is a CommitListener writing every change set as one line
+// of JSON to a file, eg.
+//
+//   {"height":3,"step":"deliver_tx","tx_index":0,"changes":[{"key":"<hex>","value":"<hex>"},{"key":"<hex>","deleted":true}]}
+//
+// Once the file is bigger than the maximum size, it is renamed to
+// <path>.<last height in it> after a block and a new file is started.
+// Only the newest rotated files are kept.
+//
+// If the file cannot be written or rotated, the sink reopens it with
+// the next block, so it recovers from a temporary failure.
+type FileSink struct {
+	mtx        sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	// file is nil after a failure, until it is reopened
+	file   *os.File
+	w      *bufio.Writer
+	size   int64
+	closed bool
+}
+
+var _ CommitListener = (*FileSink)(nil)
+
+// FileSinkFile is the name of the optional file in the home directory
+// of an application configuring its FileSink, see OpenFileSink
+const FileSinkFile = "sink.json"
+
+// FileSinkConfig is the content of the FileSinkFile
+type FileSinkConfig struct {
+	// Path is relative to the home directory
+	Path       string `json:"path"`
+	MaxSize    int64  `json:"max_size"`
+	MaxBackups int    `json:"max_backups"`
+}
+
+// OpenFileSink creates the FileSink configured by the FileSinkFile
+// in home. It returns nil if there is no such file.
+func OpenFileSink(home string) (*FileSink, error) {
+	raw, err := ioutil.ReadFile(filepath.Join(home, FileSinkFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "cannot read sink config")
+	}
+	var conf FileSinkConfig
+	if err := json.Unmarshal(raw, &conf); err != nil {
+		return nil, errors.ErrInvalidInput.Newf("sink config: %s", err)
+	}
+	if conf.Path == "" {
+		return nil, errors.ErrInvalidInput.New("sink config: missing path")
+	}
+	path := conf.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(home, path)
+	}
+	return NewFileSink(path, conf.MaxSize, conf.MaxBackups)
+}
+
+// NewFileSink opens the file at path, appending to it if it exists.
+// A maxSize of zero never rotates the file, maxBackups of zero keeps
+// all rotated files.
+func NewFileSink(path string, maxSize int64, maxBackups int) (*FileSink, error) {
+	if maxSize < 0 || maxBackups < 0 {
+		return nil, errors.ErrInvalidInput.Newf("max size %d, max backups %d", maxSize, maxBackups)
+	}
+	s := &FileSink{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "cannot open sink")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "cannot open sink")
+	}
+	s.file = f
+	s.w = bufio.NewWriter(f)
+	s.size = info.Size()
+	return nil
+}
+
+type jsonChangeSet struct {
+	Height  int64        `json:"height"`
+	Step    string       `json:"step"`
+	TxIndex int          `json:"tx_index,omitempty"`
+	Changes []jsonChange `json:"changes"`
+}
+
+// jsonChange has hex encoded key and value
+type jsonChange struct {
+	Key     string `json:"key"`
+	Value   string `json:"value,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// OnCommit writes all change sets and rotates the file if needed
+func (s *FileSink) OnCommit(height int64, appHash []byte, changes []ChangeSet) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.closed {
+		return errors.ErrInvalidState.New("sink closed")
+	}
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	if err := s.write(changes); err != nil {
+		// the file is reopened with the next block
+		s.file.Close()
+		s.file = nil
+		return err
+	}
+	if s.maxSize > 0 && s.size >= s.maxSize {
+		return s.rotate(height)
+	}
+	return nil
+}
+
+// write appends all change sets to the file
+func (s *FileSink) write(changes []ChangeSet) error {
+	for _, set := range changes {
+		line := jsonChangeSet{
+			Height:  set.Height,
+			Step:    set.Step,
+			TxIndex: set.TxIndex,
+			Changes: make([]jsonChange, len(set.Changes)),
+		}
+		for i, c := range set.Changes {
+			line.Changes[i] = jsonChange{
+				Key:     hex.EncodeToString(c.Key),
+				Value:   hex.EncodeToString(c.Value),
+				Deleted: c.Value == nil,
+			}
+		}
+		raw, err := json.Marshal(line)
+		if err != nil {
+			return errors.Wrap(err, "cannot serialize change set")
+		}
+		raw = append(raw, '\n')
+		if _, err := s.w.Write(raw); err != nil {
+			return errors.Wrap(err, "cannot write change set")
+		}
+		s.size += int64(len(raw))
+	}
+	if err := s.w.Flush(); err != nil {
+		return errors.Wrap(err, "cannot write change set")
+	}
+	return nil
+}
+
+// rotate renames the current file and starts a new one. If the file
+// cannot be renamed, it is reopened and rotated after the next block.
+func (s *FileSink) rotate(height int64) error {
+	err := s.file.Close()
+	s.file = nil
+	if err != nil {
+		return errors.Wrap(err, "cannot close sink")
+	}
+	if err := os.Rename(s.path, fmt.Sprintf("%s.%d", s.path, height)); err != nil {
+		if oerr := s.open(); oerr != nil {
+			return oerr
+		}
+		return errors.Wrap(err, "cannot rotate sink")
+	}
+	if err := s.open(); err != nil {
+		return err
+	}
+	if s.maxBackups == 0 {
+		return nil
+	}
+	backups, err := s.backups()
+	if err != nil {
+		return err
+	}
+	for len(backups) > s.maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return errors.Wrap(err, "cannot remove old sink")
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// backups returns all rotated files, oldest first
+func (s *FileSink) backups() ([]string, error) {
+	matches, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot list rotated sinks")
+	}
+	heights := make(map[string]int64)
+	var files []string
+	for _, m := range matches {
+		h, err := strconv.ParseInt(strings.TrimPrefix(m, s.path+"."), 10, 64)
+		if err != nil {
+			continue
+		}
+		heights[m] = h
+		files = append(files, m)
+	}
+	sort.Slice(files, func(i, j int) bool { return heights[files[i]] < heights[files[j]] })
+	return files, nil
+}
+
+// Close flushes and closes the file
+func (s *FileSink) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.closed = true
+	if s.file == nil {
+		return nil
+	}
+	err := s.w.Flush()
+	if cerr := s.file.Close(); err == nil {
+		err = cerr
+	}
+	s.file = nil
+	return errors.Wrap(err, "cannot close sink")
+}
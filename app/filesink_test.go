@@ -0,0 +1,110 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filesink-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "changes.jsonl")
+
+	sink, err := NewFileSink(path, 200, 1)
+	require.NoError(t, err)
+	block := func(height int64) []ChangeSet {
+		return []ChangeSet{
+			{Height: height, Step: StepBeginBlock, Changes: []Change{{Key: []byte{0xab}, Value: []byte{1}}}},
+			{Height: height, Step: StepDeliverTx, TxIndex: 2, Changes: []Change{{Key: []byte{0xcd}}}},
+		}
+	}
+
+	require.NoError(t, sink.OnCommit(1, nil, block(1)))
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	want := `{"height":1,"step":"begin_block","changes":[{"key":"ab","value":"01"}]}
+{"height":1,"step":"deliver_tx","tx_index":2,"changes":[{"key":"cd","deleted":true}]}
+`
+	assert.Equal(t, want, string(raw))
+
+	// every second block the file is bigger than 200 bytes and rotated,
+	// only the last rotated file is kept
+	for h := int64(2); h <= 5; h++ {
+		require.NoError(t, sink.OnCommit(h, nil, block(h)))
+	}
+	require.NoError(t, sink.Close())
+	files, err := filepath.Glob(path + "*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{path, path + ".4"}, files)
+	raw, err = ioutil.ReadFile(path + ".4")
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"height":3`)
+	assert.Contains(t, string(raw), `"height":4`)
+	raw, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"height":5`)
+
+	// a reopened sink appends to the file
+	sink, err = NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, sink.OnCommit(6, nil, block(6)))
+	require.NoError(t, sink.Close())
+	assert.Error(t, sink.OnCommit(7, nil, block(7)))
+	raw, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"height":6`)
+}
+
+func TestFileSinkRecovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filesink-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "changes.jsonl")
+
+	sink, err := NewFileSink(path, 1, 0)
+	require.NoError(t, err)
+	block := []ChangeSet{{Height: 1, Step: StepBeginBlock, Changes: []Change{{Key: []byte{0xab}}}}}
+
+	// the rotated file cannot be created
+	require.NoError(t, os.Mkdir(path+".1", 0755))
+	assert.Error(t, sink.OnCommit(1, nil, block))
+	require.NoError(t, os.Remove(path+".1"))
+
+	// the next block is written to the same file and rotated
+	require.NoError(t, sink.OnCommit(2, nil, block))
+	require.NoError(t, sink.Close())
+	raw, err := ioutil.ReadFile(path + ".2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(raw), "\n"))
+}
+
+func TestOpenFileSink(t *testing.T) {
+	home, err := ioutil.TempDir("", "filesink-")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	sink, err := OpenFileSink(home)
+	require.NoError(t, err)
+	assert.Nil(t, sink)
+
+	conf := `{"path": "changes.jsonl", "max_size": 1000000, "max_backups": 3}`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(home, FileSinkFile), []byte(conf), 0644))
+	sink, err = OpenFileSink(home)
+	require.NoError(t, err)
+	require.NotNil(t, sink)
+	assert.Equal(t, filepath.Join(home, "changes.jsonl"), sink.path)
+	assert.Equal(t, int64(1000000), sink.maxSize)
+	assert.Equal(t, 3, sink.maxBackups)
+	require.NoError(t, sink.Close())
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(home, FileSinkFile), []byte(`{}`), 0644))
+	_, err = OpenFileSink(home)
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
 )
 
 //------ init state -----
@@ -26,3 +27,31 @@ func (c chainInitializer) FromGenesis(opts weave.Options, kv weave.KVStore) erro
 	}
 	return nil
 }
+
+// ChainExporters lets you export many extensions with one function
+func ChainExporters(exps ...weave.Exporter) weave.Exporter {
+	return chainExporter{exps}
+}
+
+type chainExporter struct {
+	exps []weave.Exporter
+}
+
+// ToGenesis merges the options of all Exporters in the list,
+// aborting at the first error or if two export the same key.
+func (c chainExporter) ToGenesis(kv weave.ReadOnlyKVStore) (weave.Options, error) {
+	opts := make(weave.Options)
+	for _, e := range c.exps {
+		res, err := e.ToGenesis(kv)
+		if err != nil {
+			return nil, err
+		}
+		for key, raw := range res {
+			if _, ok := opts[key]; ok {
+				return nil, errors.ErrDuplicate.Newf("genesis key %s", key)
+			}
+			opts[key] = raw
+		}
+	}
+	return opts, nil
+}
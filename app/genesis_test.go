@@ -0,0 +1,54 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainExporters(t *testing.T) {
+	a := optsExporter{"a": []byte(`1`)}
+	b := optsExporter{"b": []byte(`"two"`), "c": []byte(`[]`)}
+
+	cases := map[string]struct {
+		exps    []weave.Exporter
+		want    weave.Options
+		wantErr bool
+	}{
+		"nothing": {
+			want: weave.Options{},
+		},
+		"merged": {
+			exps: []weave.Exporter{a, b},
+			want: weave.Options{"a": []byte(`1`), "b": []byte(`"two"`), "c": []byte(`[]`)},
+		},
+		"duplicate key": {
+			exps:    []weave.Exporter{a, b, a},
+			wantErr: true,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			opts, err := ChainExporters(tc.exps...).ToGenesis(store.MemStore())
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.ErrDuplicate.Is(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, opts)
+		})
+	}
+}
+
+// optsExporter always exports the same options
+type optsExporter weave.Options
+
+func (o optsExporter) ToGenesis(weave.ReadOnlyKVStore) (weave.Options, error) {
+	return weave.Options(o), nil
+}
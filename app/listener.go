@@ -0,0 +1,177 @@
+package app
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+)
+
+// Steps of a block that change the state
+const (
+	StepInitChain  = "init_chain"
+	StepBeginBlock = "begin_block"
+	StepDeliverTx  = "deliver_tx"
+	StepEndBlock   = "end_block"
+)
+
+// Change is the last value written to a key in one step,
+// Value is nil if the key was deleted
+type Change struct {
+	Key   []byte
+	Value []byte
+}
+
+// ChangeSet holds all changes of one step of a block,
+// ordered by key
+type ChangeSet struct {
+	Height int64
+	// Step is one of the Step constants
+	Step string
+	// TxIndex is the position of the transaction in the block,
+	// only set for StepDeliverTx
+	TxIndex int
+	Changes []Change
+}
+
+// CommitListener is notified of all state changes of a block,
+// once it was committed. Listeners are called in their own goroutine,
+// one block after the other.
+type CommitListener interface {
+	// OnCommit receives all non-empty change sets of the block, in the
+	// order they were made. Errors are only logged, as the block is
+	// already committed.
+	OnCommit(height int64, appHash []byte, changes []ChangeSet) error
+}
+
+// changeLog records all changes to the deliver store, grouped by the
+// step of the block that made them
+type changeLog struct {
+	sets []ChangeSet
+	// current is the step being recorded, its changes are in recorder
+	current  ChangeSet
+	recorder weave.CacheableKVStore
+	txs      int
+}
+
+// start finishes the current step and starts recording the next one
+// on top of the deliver store. Writes of the same step, other than a
+// transaction, are recorded together.
+func (c *changeLog) start(db weave.CacheableKVStore, height int64, step string) {
+	c.finish()
+
+	c.current = ChangeSet{Height: height, Step: step}
+	switch step {
+	case StepBeginBlock:
+		c.txs = 0
+	case StepDeliverTx:
+		c.current.TxIndex = c.txs
+		c.txs++
+	}
+	c.recorder = store.NewRecordingStore(db).(weave.CacheableKVStore)
+}
+
+// finish adds the changes of the current step to the log
+func (c *changeLog) finish() {
+	if c.recorder == nil {
+		return
+	}
+	kvs := c.recorder.(store.Recorder).KVPairs()
+	c.recorder = nil
+	if len(kvs) == 0 {
+		return
+	}
+	set := c.current
+	for k, v := range kvs {
+		set.Changes = append(set.Changes, Change{Key: []byte(k), Value: v})
+	}
+	sort.Slice(set.Changes, func(i, j int) bool {
+		return bytes.Compare(set.Changes[i].Key, set.Changes[j].Key) < 0
+	})
+	c.sets = append(c.sets, set)
+}
+
+// flush returns all recorded change sets and resets the log
+func (c *changeLog) flush() []ChangeSet {
+	c.finish()
+	sets := c.sets
+	c.sets = nil
+	return sets
+}
+
+// WithCommitListener adds a listener notified of all state changes
+// after every commit. Recording the changes does not affect the state.
+func (s *StoreApp) WithCommitListener(l CommitListener) *StoreApp {
+	if s.changes == nil {
+		s.changes = &changeLog{}
+	}
+	s.listeners = append(s.listeners, l)
+	return s
+}
+
+// recordStep starts recording the changes of the next step of the block,
+// if there are listeners
+func (s *StoreApp) recordStep(step string) {
+	if s.changes == nil {
+		return
+	}
+	height, _ := weave.GetHeight(s.BlockContext())
+	s.changes.start(s.store.deliver, height, step)
+}
+
+// listenerQueueSize is the number of committed blocks waiting for the
+// listeners. Once it is full, the changes of new blocks are dropped.
+const listenerQueueSize = 100
+
+// commitNotice holds the changes of a committed block
+type commitNotice struct {
+	id      weave.CommitID
+	changes []ChangeSet
+}
+
+// notify queues the changes of the committed block for the listeners,
+// so they never delay the blocks. The first call starts the goroutine
+// calling them.
+func (s *StoreApp) notify(id weave.CommitID, changes []ChangeSet) {
+	if len(s.listeners) == 0 {
+		return
+	}
+	if s.notices == nil {
+		s.notices = make(chan commitNotice, listenerQueueSize)
+		go s.runListeners(s.notices)
+	}
+	s.notifying.Add(1)
+	select {
+	case s.notices <- commitNotice{id: id, changes: changes}:
+	default:
+		s.notifying.Done()
+		s.logger.Error("Commit listeners too slow, changes dropped",
+			"height", id.Version)
+	}
+}
+
+// runListeners passes the changes of every committed block to all
+// listeners. Their failures must never affect the node.
+func (s *StoreApp) runListeners(notices <-chan commitNotice) {
+	for n := range notices {
+		for _, l := range s.listeners {
+			err := func() (err error) {
+				defer errors.Recover(&err)
+				return l.OnCommit(n.id.Version, n.id.Hash, n.changes)
+			}()
+			if err != nil {
+				s.logger.Error("Commit listener failed",
+					"height", n.id.Version,
+					"err", err)
+			}
+		}
+		s.notifying.Done()
+	}
+}
+
+// waitListeners blocks until the listeners handled all queued blocks
+func (s *StoreApp) waitListeners() {
+	s.notifying.Wait()
+}
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store/iavl"
+	"github.com/iov-one/weave/weavetest"
+)
+
+func TestCommitListener(t *testing.T) {
+	kv := iavl.MockCommitStore()
+	listener := &recordListener{}
+	storeApp := NewStoreApp("listen", kv, weave.NewQueryRouter(), context.Background()).
+		WithInit(ChainInitializers()).
+		WithCommitListener(&recordListener{err: errors.ErrInternal.New("broken")}).
+		WithCommitListener(listener)
+	decoder := func(raw []byte) (weave.Tx, error) {
+		if len(raw) == 0 {
+			return nil, errors.ErrInvalidInput.New("empty")
+		}
+		return &weavetest.Tx{Msg: &weavetest.Msg{RoutePath: string(raw)}}, nil
+	}
+	base := NewBaseApp(storeApp, decoder, &writeHandler{}, &writeTicker{}, &writeEndBlocker{}, false)
+
+	base.InitChain(abci.RequestInitChain{ChainId: "listen-chain", AppStateBytes: []byte(`{}`)})
+	base.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	base.DeliverTx([]byte("b"))
+	// transactions that cannot be decoded or fail still have an index
+	base.DeliverTx(nil)
+	base.DeliverTx([]byte("fail"))
+	base.DeliverTx([]byte("a"))
+	base.EndBlock(abci.RequestEndBlock{Height: 1})
+	hash := base.Commit().Data
+	storeApp.waitListeners()
+
+	// a broken listener does not affect the others
+	require.Len(t, listener.calls, 1)
+	call := listener.calls[0]
+	assert.Equal(t, int64(1), call.height)
+	assert.Equal(t, []byte(hash), call.hash)
+
+	want := []ChangeSet{
+		{Height: 0, Step: StepInitChain, Changes: []Change{{Key: []byte(chainIDKey), Value: []byte("listen-chain")}}},
+		{Height: 1, Step: StepBeginBlock, Changes: []Change{{Key: []byte("tick"), Value: []byte{1}}}},
+		{Height: 1, Step: StepDeliverTx, TxIndex: 0, Changes: []Change{
+			{Key: []byte("b"), Value: []byte("b")},
+			{Key: []byte("tick"), Value: nil},
+		}},
+		// deleting a missing key is still recorded
+		{Height: 1, Step: StepDeliverTx, TxIndex: 3, Changes: []Change{
+			{Key: []byte("a"), Value: []byte("a")},
+			{Key: []byte("tick"), Value: nil},
+		}},
+		// the changes of the end blocker are part of the end block step
+		{Height: 1, Step: StepEndBlock, Changes: []Change{{Key: []byte("end"), Value: []byte{1}}}},
+	}
+	assert.Equal(t, want, call.changes)
+
+	// next block only holds new changes
+	base.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	base.DeliverTx([]byte("c"))
+	base.Commit()
+	storeApp.waitListeners()
+	require.Len(t, listener.calls, 2)
+	want = []ChangeSet{
+		{Height: 2, Step: StepBeginBlock, Changes: []Change{{Key: []byte("tick"), Value: []byte{2}}}},
+		{Height: 2, Step: StepDeliverTx, TxIndex: 0, Changes: []Change{
+			{Key: []byte("c"), Value: []byte("c")},
+			{Key: []byte("tick"), Value: nil},
+		}},
+	}
+	assert.Equal(t, want, listener.calls[1].changes)
+}
+
+func TestSlowCommitListener(t *testing.T) {
+	started, release := make(chan bool), make(chan bool)
+	listener := &recordListener{started: started, release: release}
+	storeApp := NewStoreApp("listen", iavl.MockCommitStore(), weave.NewQueryRouter(), context.Background()).
+		WithCommitListener(listener)
+
+	storeApp.notify(weave.CommitID{Version: 1}, nil)
+	<-started
+	// the queue is filled, the last block is dropped
+	for v := int64(2); v <= listenerQueueSize+2; v++ {
+		storeApp.notify(weave.CommitID{Version: v}, nil)
+	}
+	close(release)
+	storeApp.waitListeners()
+	require.Len(t, listener.calls, listenerQueueSize+1)
+	assert.Equal(t, int64(listenerQueueSize+1), listener.calls[listenerQueueSize].height)
+}
+
+type listenerCall struct {
+	height  int64
+	hash    []byte
+	changes []ChangeSet
+}
+
+// recordListener remembers all calls and returns err.
+// If set, it signals started before the first call returns
+// and waits for release to be closed.
+type recordListener struct {
+	calls   []listenerCall
+	err     error
+	started chan bool
+	release chan bool
+}
+
+func (l *recordListener) OnCommit(height int64, appHash []byte, changes []ChangeSet) error {
+	if l.started != nil {
+		close(l.started)
+		l.started = nil
+		<-l.release
+	}
+	l.calls = append(l.calls, listenerCall{height: height, hash: appHash, changes: changes})
+	return l.err
+}
+
+// writeHandler sets the path of the message as key and value,
+// and deletes the "tick" key. It fails if the path is "fail".
+type writeHandler struct {
+	weavetest.Handler
+}
+
+func (h *writeHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	msg, _ := tx.GetMsg()
+	if msg.Path() == "fail" {
+		return weave.DeliverResult{}, errors.ErrInvalidInput.New("fail")
+	}
+	db.Set([]byte(msg.Path()), []byte(msg.Path()))
+	db.Delete([]byte("tick"))
+	return weave.DeliverResult{}, nil
+}
+
+// writeTicker stores the block height under the "tick" key
+type writeEndBlocker struct{}
+
+func (writeEndBlocker) EndBlock(ctx weave.Context, db weave.KVStore) (weave.EndBlockResult, error) {
+	height, _ := weave.GetHeight(ctx)
+	db.Set([]byte("end"), []byte{byte(height)})
+	return weave.EndBlockResult{}, nil
+}
+
+type writeTicker struct{}
+
+func (writeTicker) Tick(ctx weave.Context, db weave.KVStore) (weave.TickResult, error) {
+	height, _ := weave.GetHeight(ctx)
+	db.Set([]byte("tick"), []byte{byte(height)})
+	return weave.TickResult{}, nil
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"sync/atomic"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+// log levels of the LevelLogger, higher is more verbose
+const (
+	levelNone int32 = iota
+	levelError
+	levelInfo
+	levelDebug
+)
+
+var logLevels = map[string]int32{
+	"none":  levelNone,
+	"error": levelError,
+	"info":  levelInfo,
+	"debug": levelDebug,
+}
+
+// LevelLogger only passes on log entries up to a level,
+// which can be changed at runtime with the "log_level" option.
+// All loggers created by With share the level.
+type LevelLogger struct {
+	next  log.Logger
+	level *int32
+}
+
+var _ log.Logger = LevelLogger{}
+
+// NewLevelLogger wraps the logger, passing on entries of all levels
+func NewLevelLogger(next log.Logger) LevelLogger {
+	level := levelDebug
+	return LevelLogger{next: next, level: &level}
+}
+
+// SetLevel accepts one of "debug", "info", "error" and "none"
+func (l LevelLogger) SetLevel(value string) error {
+	level, ok := logLevels[value]
+	if !ok {
+		return errors.ErrInvalidInput.Newf("unknown log level %q", value)
+	}
+	atomic.StoreInt32(l.level, level)
+	return nil
+}
+
+// RegisterOptions adds the "log_level" option
+func (l LevelLogger) RegisterOptions(r weave.OptionRouter) {
+	r.Register("log_level", l.SetLevel)
+}
+
+func (l LevelLogger) enabled(level int32) bool {
+	return atomic.LoadInt32(l.level) >= level
+}
+
+// Debug implements log.Logger
+func (l LevelLogger) Debug(msg string, keyvals ...interface{}) {
+	if l.enabled(levelDebug) {
+		l.next.Debug(msg, keyvals...)
+	}
+}
+
+// Info implements log.Logger
+func (l LevelLogger) Info(msg string, keyvals ...interface{}) {
+	if l.enabled(levelInfo) {
+		l.next.Info(msg, keyvals...)
+	}
+}
+
+// Error implements log.Logger
+func (l LevelLogger) Error(msg string, keyvals ...interface{}) {
+	if l.enabled(levelError) {
+		l.next.Error(msg, keyvals...)
+	}
+}
+
+// With implements log.Logger, the returned logger shares the level
+func (l LevelLogger) With(keyvals ...interface{}) log.Logger {
+	return LevelLogger{next: l.next.With(keyvals...), level: l.level}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+func TestLevelLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLevelLogger(log.NewTMLogger(&buf))
+	child := logger.With("module", "test")
+
+	options := weave.NewOptionRouter()
+	logger.RegisterOptions(options)
+
+	child.Debug("first")
+	assert.Contains(t, buf.String(), "first")
+
+	// the level is shared with all derived loggers
+	require.NoError(t, options.Set("log_level", "error"))
+	buf.Reset()
+	child.Debug("second")
+	child.Info("third")
+	assert.Empty(t, buf.String())
+	child.Error("fourth")
+	assert.Contains(t, buf.String(), "fourth")
+
+	require.NoError(t, options.Set("log_level", "none"))
+	buf.Reset()
+	logger.Error("fifth")
+	assert.Empty(t, buf.String())
+
+	err := options.Set("log_level", "verbose")
+	assert.True(t, errors.ErrInvalidInput.Is(err))
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+// prove returns a merkle proof of all keys and ranges read through
+// the query store, as they were committed at the given height.
+//
+// Every key and range is proven by an independent chain of operations
+// computing the app hash, a multi store adds the root of the substore.
+func (cs *CommitStore) prove(r *queryStore, height int64) (*merkle.Proof, error) {
+	ops := make([]merkle.ProofOp, 0, len(r.keys)+len(r.ranges))
+	for _, key := range r.keys {
+		_, chain, err := cs.committed.GetVersionedWithProof(key, height)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, chain...)
+	}
+	for _, kr := range r.ranges {
+		_, chain, err := cs.committed.GetVersionedRangeWithProof(kr.start, kr.end, height)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, chain...)
+	}
+	return &merkle.Proof{Ops: ops}, nil
+}
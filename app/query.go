@@ -0,0 +1,175 @@
+package app
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+)
+
+// queryStore wraps the store a query is made against.
+//
+// It limits every iteration to a single page of results (see
+// weave.QueryOptions) and, if requested, remembers all keys and
+// ranges that were read, so we can prove them after the query.
+// Only the part of a range that was actually returned is recorded.
+type queryStore struct {
+	weave.ReadOnlyKVStore
+	opts weave.QueryOptions
+	// next is the key to continue the first cut iteration from
+	next []byte
+
+	record bool
+	keys   [][]byte
+	ranges []keyRange
+	seen   map[string]bool
+}
+
+type keyRange struct {
+	start, end []byte
+}
+
+var _ weave.ReadOnlyKVStore = (*queryStore)(nil)
+
+func newQueryStore(db weave.ReadOnlyKVStore, opts weave.QueryOptions, record bool) *queryStore {
+	return &queryStore{
+		ReadOnlyKVStore: db,
+		opts:            opts,
+		record:          record,
+		seen:            make(map[string]bool),
+	}
+}
+
+// Get records the key and returns the value
+func (q *queryStore) Get(key []byte) []byte {
+	q.recordKey(key)
+	return q.ReadOnlyKVStore.Get(key)
+}
+
+// Has records the key and checks if it exists
+func (q *queryStore) Has(key []byte) bool {
+	q.recordKey(key)
+	return q.ReadOnlyKVStore.Has(key)
+}
+
+// Iterator returns a page of the range, starting at the cursor
+// if it is inside of the range
+func (q *queryStore) Iterator(start, end []byte) weave.Iterator {
+	if !q.opts.Paginated() {
+		q.recordRange(start, end)
+		return q.ReadOnlyKVStore.Iterator(start, end)
+	}
+
+	if c := q.opts.Cursor; c != nil && bytes.Compare(c, start) > 0 {
+		start = c
+	}
+	if end != nil && bytes.Compare(start, end) >= 0 {
+		return store.NewSliceIterator(nil)
+	}
+
+	models, next := q.page(q.ReadOnlyKVStore.Iterator(start, end))
+	// skipped models are not returned, so we cannot prove them
+	if q.opts.Offset > 0 {
+		if len(models) == 0 {
+			return store.NewSliceIterator(nil)
+		}
+		start = models[0].Key
+	}
+	if next != nil {
+		end = next
+	}
+	q.recordRange(start, end)
+	return store.NewSliceIterator(models)
+}
+
+// ReverseIterator returns a page of the range, starting at the cursor
+// (going down) if it is inside of the range
+func (q *queryStore) ReverseIterator(start, end []byte) weave.Iterator {
+	if !q.opts.Paginated() {
+		q.recordRange(start, end)
+		return q.ReadOnlyKVStore.ReverseIterator(start, end)
+	}
+
+	if c := q.opts.Cursor; c != nil {
+		if after := keyAfter(c); end == nil || bytes.Compare(after, end) < 0 {
+			end = after
+		}
+	}
+	if start != nil && end != nil && bytes.Compare(start, end) >= 0 {
+		return store.NewSliceIterator(nil)
+	}
+
+	models, next := q.page(q.ReadOnlyKVStore.ReverseIterator(start, end))
+	if q.opts.Offset > 0 {
+		if len(models) == 0 {
+			return store.NewSliceIterator(nil)
+		}
+		end = keyAfter(models[0].Key)
+	}
+	if next != nil {
+		start = keyAfter(next)
+	}
+	q.recordRange(start, end)
+	return store.NewSliceIterator(models)
+}
+
+// page skips the offset and reads up to limit models from the iterator.
+// next is the key of the first model left out, nil if there is none.
+func (q *queryStore) page(itr weave.Iterator) (models []weave.Model, next []byte) {
+	defer itr.Close()
+
+	for i := 0; i < q.opts.Offset && itr.Valid(); i++ {
+		itr.Next()
+	}
+	for ; itr.Valid(); itr.Next() {
+		if q.opts.Limit > 0 && len(models) == q.opts.Limit {
+			next = itr.Key()
+			break
+		}
+		models = append(models, weave.Model{Key: itr.Key(), Value: itr.Value()})
+	}
+
+	if q.next == nil {
+		q.next = next
+	}
+	return models, next
+}
+
+func (q *queryStore) recordKey(key []byte) {
+	if !q.record || q.seen[string(key)] {
+		return
+	}
+	q.seen[string(key)] = true
+	q.keys = append(q.keys, key)
+}
+
+func (q *queryStore) recordRange(start, end []byte) {
+	if q.record {
+		q.ranges = append(q.ranges, keyRange{start: start, end: end})
+	}
+}
+
+// keyAfter returns the smallest key bigger than the given one
+func keyAfter(key []byte) []byte {
+	after := make([]byte, len(key), len(key)+1)
+	copy(after, key)
+	return append(after, 0)
+}
+
+// QueryNextKey returns the key to continue a paginated query from,
+// pass it as the cursor option to get the next page.
+// It returns nil if all results were returned.
+func QueryNextKey(res abci.ResponseQuery) ([]byte, error) {
+	if res.Info == "" {
+		return nil, nil
+	}
+	key, err := hex.DecodeString(res.Info)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "next key")
+	}
+	return key, nil
+}
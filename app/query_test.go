@@ -0,0 +1,187 @@
+package app
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/orm"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/store/iavl"
+)
+
+func TestPaginatedQuery(t *testing.T) {
+	qr := weave.NewQueryRouter()
+	orm.RegisterQuery(qr)
+	myApp := NewStoreApp("paging", iavl.MockCommitStore(), qr, context.Background())
+
+	db := myApp.DeliverStore()
+	for _, k := range []string{"a1", "a2", "a3", "a4", "a5", "b1"} {
+		db.Set([]byte(k), []byte("value of "+k))
+	}
+	myApp.Commit()
+
+	// follow the cursor until all keys were read
+	var keys []string
+	var cursor []byte
+	for pages := 0; ; pages++ {
+		require.True(t, pages < 3, "too many pages")
+		path := "/?prefix&limit=2"
+		if cursor != nil {
+			path = path + "&cursor=" + hex.EncodeToString(cursor)
+		}
+		res := myApp.Query(abci.RequestQuery{Path: path, Data: []byte("a")})
+		require.Equal(t, uint32(0), res.Code, res.Log)
+		for _, m := range queryModels(t, res) {
+			keys = append(keys, string(m.Key))
+		}
+		next, err := QueryNextKey(res)
+		require.NoError(t, err)
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+	assert.Equal(t, []string{"a1", "a2", "a3", "a4", "a5"}, keys)
+
+	// offset skips the first results
+	res := myApp.Query(abci.RequestQuery{Path: "/?prefix&offset=3", Data: []byte("a")})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	models := queryModels(t, res)
+	require.Len(t, models, 2)
+	assert.Equal(t, "a4", string(models[0].Key))
+	assert.Equal(t, "", res.Info)
+
+	// app limit applies without any options
+	myApp.WithQueryLimit(4)
+	res = myApp.Query(abci.RequestQuery{Path: "/?prefix", Data: []byte("a")})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	assert.Len(t, queryModels(t, res), 4)
+	next, err := QueryNextKey(res)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a5"), next)
+
+	// invalid options fail
+	res = myApp.Query(abci.RequestQuery{Path: "/?prefix&limit=x", Data: []byte("a")})
+	assert.NotEqual(t, uint32(0), res.Code)
+}
+
+func TestQueryBeforeCommit(t *testing.T) {
+	qr := weave.NewQueryRouter()
+	orm.RegisterQuery(qr)
+	myApp := NewStoreApp("empty", iavl.MockCommitStore(), qr, context.Background())
+
+	// nothing is committed, so the latest state is empty
+	res := myApp.Query(abci.RequestQuery{Path: "/", Data: []byte("a")})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	assert.Equal(t, int64(0), res.Height)
+	assert.Empty(t, queryModels(t, res))
+
+	res = myApp.Query(abci.RequestQuery{Path: "/?prefix", Data: []byte("a")})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	assert.Empty(t, queryModels(t, res))
+
+	// uncommitted writes are not visible
+	myApp.DeliverStore().Set([]byte("a1"), []byte("value"))
+	res = myApp.Query(abci.RequestQuery{Path: "/?prefix", Data: []byte("a")})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	assert.Empty(t, queryModels(t, res))
+
+	// a height that was never committed still fails
+	res = myApp.Query(abci.RequestQuery{Path: "/", Data: []byte("a"), Height: 1})
+	assert.NotEqual(t, uint32(0), res.Code)
+}
+
+func TestJSONQuery(t *testing.T) {
+	bucket := orm.NewBucket("cnts", orm.NewSimpleObj(nil, new(orm.Counter)))
+	qr := weave.NewQueryRouter()
+	bucket.Register("counters", qr)
+	myApp := NewStoreApp("json", iavl.MockCommitStore(), qr, context.Background())
+
+	db := myApp.DeliverStore()
+	for i, k := range []string{"a", "b"} {
+		obj := orm.NewSimpleObj([]byte(k), &orm.Counter{Count: int64(i + 1)})
+		require.NoError(t, bucket.Save(db, obj))
+	}
+	myApp.Commit()
+
+	// json must be supported by the application
+	res := myApp.Query(abci.RequestQuery{Path: "/counters?json", Data: []byte("a")})
+	assert.NotEqual(t, uint32(0), res.Code)
+
+	models := orm.NewModelRegistry()
+	bucket.RegisterModel(models)
+	myApp.WithModels(models)
+
+	res = myApp.Query(abci.RequestQuery{Path: "/counters?json", Data: []byte("a")})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	assert.Empty(t, res.Key)
+	assert.JSONEq(t, `[{"key": "636e74733a61", "value": {"count": 1}}]`, string(res.Value))
+
+	res = myApp.Query(abci.RequestQuery{Path: "/counters?prefix&json&limit=1", Data: nil})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	assert.JSONEq(t, `[{"key": "636e74733a61", "value": {"count": 1}}]`, string(res.Value))
+	next, err := QueryNextKey(res)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("cnts:b"), next)
+}
+
+func TestQueryStoreReversePages(t *testing.T) {
+	db := store.MemStore()
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		db.Set([]byte(k), []byte(k))
+	}
+
+	cases := map[string]struct {
+		opts     weave.QueryOptions
+		wantKeys []string
+		wantNext string
+	}{
+		"limit": {
+			opts:     weave.QueryOptions{Limit: 2},
+			wantKeys: []string{"e", "d"},
+			wantNext: "c",
+		},
+		"cursor": {
+			opts:     weave.QueryOptions{Limit: 2, Cursor: []byte("c")},
+			wantKeys: []string{"c", "b"},
+			wantNext: "a",
+		},
+		"offset": {
+			opts:     weave.QueryOptions{Offset: 1, Cursor: []byte("b")},
+			wantKeys: []string{"a"},
+		},
+		"cursor outside of range": {
+			opts:     weave.QueryOptions{Cursor: []byte("0")},
+			wantKeys: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			qs := newQueryStore(db, tc.opts, true)
+			itr := qs.ReverseIterator([]byte("a"), nil)
+			var keys []string
+			for ; itr.Valid(); itr.Next() {
+				keys = append(keys, string(itr.Key()))
+			}
+			assert.Equal(t, tc.wantKeys, keys)
+			assert.Equal(t, tc.wantNext, string(qs.next))
+		})
+	}
+}
+
+func queryModels(t *testing.T, res abci.ResponseQuery) []weave.Model {
+	t.Helper()
+	var keys, values ResultSet
+	require.NoError(t, keys.Unmarshal(res.Key))
+	require.NoError(t, values.Unmarshal(res.Value))
+	models, err := JoinResults(&keys, &values)
+	require.NoError(t, err)
+	return models
+}
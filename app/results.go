@@ -1,6 +1,8 @@
 package app
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 
 	"github.com/iov-one/weave"
@@ -26,6 +28,26 @@ func ResultsFromValues(models []weave.Model) *ResultSet {
 	return &ResultSet{Results: res}
 }
 
+// JSONResult is a model of a query rendered as JSON
+type JSONResult struct {
+	// Key is hex encoded
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// renderJSON serializes the models as a list of JSONResult
+func renderJSON(r weave.ModelRenderer, models []weave.Model) ([]byte, error) {
+	res := make([]JSONResult, len(models))
+	for i, m := range models {
+		value, err := r.RenderJSON(m.Key, m.Value)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = JSONResult{Key: hex.EncodeToString(m.Key), Value: value}
+	}
+	return json.Marshal(res)
+}
+
 // JoinResults inverts ResultsFromKeys and ResultsFromValues
 // and makes then a consistent whole again
 func JoinResults(keys, values *ResultSet) ([]weave.Model, error) {
@@ -6,6 +6,7 @@ package app
 import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import coin "github.com/iov-one/weave/coin"
 
 import io "io"
 
@@ -31,7 +32,7 @@ func (m *ResultSet) Reset()         { *m = ResultSet{} }
 func (m *ResultSet) String() string { return proto.CompactTextString(m) }
 func (*ResultSet) ProtoMessage()    {}
 func (*ResultSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_results_7a79b3e73fac19b7, []int{0}
+	return fileDescriptor_results_999acf87df887597, []int{0}
 }
 func (m *ResultSet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -67,8 +68,69 @@ func (m *ResultSet) GetResults() [][]byte {
 	return nil
 }
 
+// SimulateResult is the outcome of a transaction run by the
+// simulate query, as it would have been delivered.
+type SimulateResult struct {
+	// DeliverTx is the serialized abci.ResponseDeliverTx,
+	// with the error code and log if the transaction failed.
+	DeliverTx []byte `protobuf:"bytes,1,opt,name=deliver_tx,json=deliverTx,proto3" json:"deliver_tx,omitempty"`
+	// RequiredFee is the fee the handlers asked for, if the
+	// transaction succeeded.
+	RequiredFee          *coin.Coin `protobuf:"bytes,2,opt,name=required_fee,json=requiredFee" json:"required_fee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SimulateResult) Reset()         { *m = SimulateResult{} }
+func (m *SimulateResult) String() string { return proto.CompactTextString(m) }
+func (*SimulateResult) ProtoMessage()    {}
+func (*SimulateResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_results_999acf87df887597, []int{1}
+}
+func (m *SimulateResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SimulateResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SimulateResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SimulateResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateResult.Merge(dst, src)
+}
+func (m *SimulateResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *SimulateResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulateResult proto.InternalMessageInfo
+
+func (m *SimulateResult) GetDeliverTx() []byte {
+	if m != nil {
+		return m.DeliverTx
+	}
+	return nil
+}
+
+func (m *SimulateResult) GetRequiredFee() *coin.Coin {
+	if m != nil {
+		return m.RequiredFee
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ResultSet)(nil), "app.ResultSet")
+	proto.RegisterType((*SimulateResult)(nil), "app.SimulateResult")
 }
 func (m *ResultSet) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -96,6 +158,40 @@ func (m *ResultSet) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *SimulateResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SimulateResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.DeliverTx) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintResults(dAtA, i, uint64(len(m.DeliverTx)))
+		i += copy(dAtA[i:], m.DeliverTx)
+	}
+	if m.RequiredFee != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintResults(dAtA, i, uint64(m.RequiredFee.Size()))
+		n1, err := m.RequiredFee.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	return i, nil
+}
+
 func encodeVarintResults(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -117,6 +213,20 @@ func (m *ResultSet) Size() (n int) {
 	return n
 }
 
+func (m *SimulateResult) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.DeliverTx)
+	if l > 0 {
+		n += 1 + l + sovResults(uint64(l))
+	}
+	if m.RequiredFee != nil {
+		l = m.RequiredFee.Size()
+		n += 1 + l + sovResults(uint64(l))
+	}
+	return n
+}
+
 func sovResults(x uint64) (n int) {
 	for {
 		n++
@@ -209,6 +319,120 @@ func (m *ResultSet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SimulateResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowResults
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SimulateResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SimulateResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResults
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthResults
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeliverTx = append(m.DeliverTx[:0], dAtA[iNdEx:postIndex]...)
+			if m.DeliverTx == nil {
+				m.DeliverTx = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequiredFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResults
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthResults
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RequiredFee == nil {
+				m.RequiredFee = &coin.Coin{}
+			}
+			if err := m.RequiredFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipResults(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthResults
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipResults(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -314,15 +538,22 @@ var (
 	ErrIntOverflowResults   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("app/results.proto", fileDescriptor_results_7a79b3e73fac19b7) }
+func init() { proto.RegisterFile("app/results.proto", fileDescriptor_results_999acf87df887597) }
 
-var fileDescriptor_results_7a79b3e73fac19b7 = []byte{
-	// 97 bytes of a gzipped FileDescriptorProto
+var fileDescriptor_results_999acf87df887597 = []byte{
+	// 212 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4c, 0x2c, 0x28, 0xd0,
 	0x2f, 0x4a, 0x2d, 0x2e, 0xcd, 0x29, 0x29, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x4e,
-	0x2c, 0x28, 0x50, 0x52, 0xe5, 0xe2, 0x0c, 0x02, 0x8b, 0x06, 0xa7, 0x96, 0x08, 0x49, 0x70, 0xb1,
-	0x43, 0x95, 0x48, 0x30, 0x2a, 0x30, 0x6b, 0xf0, 0x04, 0xc1, 0xb8, 0x4e, 0x02, 0x27, 0x1e, 0xc9,
-	0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x49, 0x6c,
-	0x60, 0x43, 0x8c, 0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0x87, 0x7d, 0x95, 0x87, 0x59, 0x00, 0x00,
-	0x00,
+	0x2c, 0x28, 0x90, 0xd2, 0x4c, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0xcf,
+	0xcc, 0x2f, 0xd3, 0xcd, 0xcf, 0x4b, 0xd5, 0x2f, 0x4f, 0x4d, 0x2c, 0x4b, 0xd5, 0x4f, 0xce, 0xcf,
+	0xcc, 0xd3, 0x4f, 0xce, 0x4f, 0x49, 0x4d, 0x86, 0xa8, 0x57, 0x52, 0xe5, 0xe2, 0x0c, 0x02, 0x1b,
+	0x10, 0x9c, 0x5a, 0x22, 0x24, 0xc1, 0xc5, 0x0e, 0x35, 0x4d, 0x82, 0x51, 0x81, 0x59, 0x83, 0x27,
+	0x08, 0xc6, 0x55, 0x8a, 0xe3, 0xe2, 0x0b, 0xce, 0xcc, 0x2d, 0xcd, 0x49, 0x2c, 0x49, 0x85, 0x28,
+	0x17, 0x92, 0xe5, 0xe2, 0x4a, 0x49, 0xcd, 0xc9, 0x2c, 0x4b, 0x2d, 0x8a, 0x2f, 0xa9, 0x90, 0x60,
+	0x54, 0x60, 0xd4, 0xe0, 0x09, 0xe2, 0x84, 0x8a, 0x84, 0x54, 0x08, 0xe9, 0x72, 0xf1, 0x14, 0xa5,
+	0x16, 0x96, 0x66, 0x16, 0xa5, 0xa6, 0xc4, 0xa7, 0xa5, 0xa6, 0x4a, 0x30, 0x29, 0x30, 0x6a, 0x70,
+	0x1b, 0x71, 0xe9, 0x81, 0x1c, 0xa0, 0xe7, 0x9c, 0x9f, 0x99, 0x17, 0xc4, 0x0d, 0x93, 0x77, 0x4b,
+	0x4d, 0x75, 0x12, 0x38, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18,
+	0x27, 0x3c, 0x96, 0x63, 0x48, 0x62, 0x03, 0xbb, 0xcf, 0x18, 0x30, 0x00, 0x41, 0x47, 0x9a, 0xe0,
+	0xe4, 0x00, 0x00, 0x00,
 }
@@ -3,6 +3,8 @@ package app
 import (
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/errors"
@@ -11,18 +13,22 @@ import (
 // DefaultRouterSize preallocates this much space to hold routes
 const DefaultRouterSize = 10
 
-// isPath is the RegExp to ensure the routes make sense
-var isPath = regexp.MustCompile(`^[a-zA-Z0-9_/]+$`).MatchString
+// isPath is the RegExp to ensure the routes make sense,
+// a trailing "/*" registers a prefix route
+var isPath = regexp.MustCompile(`^[a-zA-Z0-9_/]+(/\*)?$`).MatchString
+
+// prefixWildcard ends a path that matches all paths below it
+const prefixWildcard = "/*"
 
 // Router allows us to register many handlers with different
 // paths and then direct each message to the proper handler.
 //
 // Minimal interface modeled after net/http.ServeMux
 //
-// TODO: look for better trie routers that handle patterns...
-// maybe take code from here?
-// https://github.com/julienschmidt/httprouter
-// https://github.com/julienschmidt/httprouter/blob/master/tree.go
+// A path ending with "/*", like "nft/*", registers a prefix route,
+// handling all paths below it ("nft/username", "nft/ticker/issue")
+// unless a more specific route is registered.
+// Use Group to register routes sharing a decorator chain.
 type Router struct {
 	routes map[string]weave.Handler
 }
@@ -49,15 +55,44 @@ func (r Router) Handle(path string, h weave.Handler) {
 	r.routes[path] = h
 }
 
-// Handler returns the registered Handler for this path.
+// Handler returns the registered Handler for this path,
+// falling back to the longest matching prefix route.
 // If no path is found, returns a noSuchPath Handler
 // Always returns a non-nil Handler
 func (r Router) Handler(path string) weave.Handler {
-	h, ok := r.routes[path]
-	if !ok {
-		return noSuchPathHandler{path}
+	if h, ok := r.routes[path]; ok {
+		return h
+	}
+	for prefix := path; ; {
+		i := strings.LastIndex(prefix, "/")
+		if i < 0 {
+			return noSuchPathHandler{path}
+		}
+		prefix = prefix[:i]
+		if h, ok := r.routes[prefix+prefixWildcard]; ok {
+			return h
+		}
+	}
+}
+
+// Routes returns all registered paths in alphabetical order,
+// prefix routes included with their wildcard
+func (r Router) Routes() []string {
+	paths := make([]string, 0, len(r.routes))
+	for p := range r.routes {
+		paths = append(paths, p)
 	}
-	return h
+	sort.Strings(paths)
+	return paths
+}
+
+// Group returns a registry adding routes to this router,
+// with every handler wrapped by the given decorators.
+//
+//   admin := r.Group(multisig.NewDecorator(auth), requireAdmin)
+//   validators.RegisterRoutes(admin, auth, ctrl)
+func (r Router) Group(decorators ...weave.Decorator) RouteGroup {
+	return RouteGroup{router: r}.Group(decorators...)
 }
 
 // Check dispatches to the proper handler based on path
@@ -86,6 +121,34 @@ func (r Router) Deliver(ctx weave.Context, store weave.KVStore,
 	return h.Deliver(ctx, store, tx)
 }
 
+//-------------------- route groups ---------------
+
+// RouteGroup registers routes in a Router, wrapping
+// each handler with the decorators of the group
+type RouteGroup struct {
+	router     Router
+	decorators []weave.Decorator
+}
+
+var _ weave.Registry = RouteGroup{}
+
+// Handle adds the handler, wrapped by the group decorators,
+// for the given path to the router
+func (g RouteGroup) Handle(path string, h weave.Handler) {
+	g.router.Handle(path, ChainDecorators(g.decorators...).WithHandler(h))
+}
+
+// Group returns a nested group, the decorators of this group
+// are executed before the given ones
+func (g RouteGroup) Group(decorators ...weave.Decorator) RouteGroup {
+	chain := make([]weave.Decorator, 0, len(g.decorators)+len(decorators))
+	chain = append(chain, g.decorators...)
+	return RouteGroup{
+		router:     g.router,
+		decorators: append(chain, decorators...),
+	}
+}
+
 //-------------------- error handler ---------------
 
 type noSuchPathHandler struct {
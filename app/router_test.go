@@ -49,3 +49,70 @@ func TestRouter(t *testing.T) {
 	assert.True(t, errors.ErrNotFound.Is(err))
 	assert.Equal(t, 2, h.CallCount())
 }
+
+func TestRouterPrefix(t *testing.T) {
+	r := NewRouter()
+	exact, nft, nftTicker := &weavetest.Handler{}, &weavetest.Handler{}, &weavetest.Handler{}
+	r.Handle("nft/username/issue", exact)
+	r.Handle("nft/*", nft)
+	r.Handle("nft/ticker/*", nftTicker)
+
+	assert.Panics(t, func() { r.Handle("nft/*", nft) })
+	assert.Panics(t, func() { r.Handle("nft*", nft) })
+	assert.Panics(t, func() { r.Handle("nft/*/issue", nft) })
+
+	cases := map[string]struct {
+		path string
+		want *weavetest.Handler
+	}{
+		"exact match wins":      {"nft/username/issue", exact},
+		"prefix":                {"nft/username/transfer", nft},
+		"direct child":          {"nft/blockchain", nft},
+		"longest prefix wins":   {"nft/ticker/issue", nftTicker},
+		"prefix itself missing": {"nft", nil},
+		"no match":              {"cash/send", nil},
+		"similar name":          {"nftx/issue", nil},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := r.Handler(tc.path)
+			if tc.want == nil {
+				_, err := h.Deliver(nil, nil, nil)
+				assert.True(t, errors.ErrNotFound.Is(err))
+				return
+			}
+			assert.Equal(t, tc.want, h)
+		})
+	}
+
+	assert.Equal(t, []string{"nft/*", "nft/ticker/*", "nft/username/issue"}, r.Routes())
+}
+
+func TestRouterGroup(t *testing.T) {
+	r := NewRouter()
+	fees, admin := &weavetest.Decorator{}, &weavetest.Decorator{}
+
+	public := &weavetest.Handler{}
+	r.Handle("public", public)
+	paid := r.Group(fees)
+	paid.Handle("paid", &weavetest.Handler{})
+	paid.Group(admin).Handle("admin", &weavetest.Handler{})
+
+	_, err := r.Handler("public").Deliver(nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, fees.DeliverCallCount())
+
+	_, err = r.Handler("paid").Deliver(nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fees.DeliverCallCount())
+	assert.Equal(t, 0, admin.DeliverCallCount())
+
+	// nested groups run the outer decorators first
+	admin.DeliverErr = errors.ErrUnauthorized
+	_, err = r.Handler("admin").Deliver(nil, nil, nil)
+	assert.True(t, errors.ErrUnauthorized.Is(err))
+	assert.Equal(t, 2, fees.DeliverCallCount())
+	assert.Equal(t, 1, admin.DeliverCallCount())
+
+	assert.Equal(t, []string{"admin", "paid", "public"}, r.Routes())
+}
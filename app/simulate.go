@@ -0,0 +1,81 @@
+package app
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+// SimulatePath is the query path to dry-run a transaction,
+// the query data is the serialized transaction
+const SimulatePath = "/simulate"
+
+// Query - ABCI - runs the transaction for SimulatePath,
+// all other paths are handled by StoreApp.Query
+func (b BaseApp) Query(req abci.RequestQuery) abci.ResponseQuery {
+	if req.Path == SimulatePath {
+		return b.simulate(req.Data)
+	}
+	return b.StoreApp.Query(req)
+}
+
+// simulate runs the transaction through the whole decorator and
+// handler stack, as DeliverTx would do, against the latest committed
+// state. All changes are discarded afterwards.
+//
+// Value holds a SimulateResult, Code and Log are the ones
+// DeliverTx would return.
+func (b BaseApp) simulate(txBytes []byte) abci.ResponseQuery {
+	b.store.RLock()
+	defer b.store.RUnlock()
+
+	height, _ := b.store.CommitInfo()
+	db, err := b.store.SimulateStore()
+	if err != nil {
+		return queryError(err)
+	}
+	defer db.Discard()
+
+	var res weave.DeliverResult
+	tx, err := b.loadTx(txBytes)
+	if err == nil {
+		ctx := weave.WithLogInfo(b.BlockContext(),
+			"call", "simulate",
+			"path", weave.GetPath(tx))
+		events := weave.NewEventManager()
+		ctx = weave.WithEventManager(ctx, events)
+		res, err = b.handler.Deliver(ctx, db, tx)
+		if err == nil {
+			res.Tags = append(res.Tags, events.Tags()...)
+		}
+	}
+
+	dres := weave.DeliverOrError(res, err, b.debug.IsOn())
+	result := SimulateResult{}
+	if err == nil && !res.RequiredFee.IsZero() {
+		result.RequiredFee = &res.RequiredFee
+	}
+	result.DeliverTx, err = dres.Marshal()
+	if err != nil {
+		return queryError(errors.Wrap(err, "cannot serialize result"))
+	}
+	value, err := result.Marshal()
+	if err != nil {
+		return queryError(errors.Wrap(err, "cannot serialize result"))
+	}
+
+	return abci.ResponseQuery{
+		Code:   dres.Code,
+		Log:    dres.Log,
+		Value:  value,
+		Height: height,
+	}
+}
+
+// ParseDeliverTx returns the response DeliverTx would have returned
+func (r *SimulateResult) ParseDeliverTx() (abci.ResponseDeliverTx, error) {
+	var res abci.ResponseDeliverTx
+	err := res.Unmarshal(r.GetDeliverTx())
+	return res, err
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store/iavl"
+	"github.com/iov-one/weave/weavetest"
+)
+
+func TestBaseAppSimulate(t *testing.T) {
+	storeApp := NewStoreApp("simulate", iavl.MockCommitStore(), weave.NewQueryRouter(), context.Background())
+	decoder := func(raw []byte) (weave.Tx, error) {
+		return &weavetest.Tx{Msg: &weavetest.Msg{RoutePath: string(raw)}}, nil
+	}
+	base := NewBaseApp(storeApp, decoder, &simulateHandler{}, nil, nil, false)
+
+	// nothing committed yet
+	res := base.Query(abci.RequestQuery{Path: SimulatePath, Data: []byte("ok")})
+	assert.Equal(t, errors.ErrInvalidInput.ABCICode(), res.Code)
+
+	base.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	base.Commit()
+
+	res = base.Query(abci.RequestQuery{Path: SimulatePath, Data: []byte("ok")})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	assert.Equal(t, int64(1), res.Height)
+	var result SimulateResult
+	require.NoError(t, result.Unmarshal(res.Value))
+	assert.Equal(t, coin.NewCoinp(0, 50, "IOV"), result.RequiredFee)
+	dres, err := result.ParseDeliverTx()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("written"), dres.Data)
+	assert.Equal(t, int64(123), dres.GasUsed)
+	assert.Equal(t, weave.NewEvent("simulated").Tags(), dres.Tags)
+
+	// nothing is persisted, neither in the committed nor in the block state
+	assert.Nil(t, base.DeliverStore().Get([]byte("ok")))
+	assert.Nil(t, base.CheckStore().Get([]byte("ok")))
+	db, err := base.store.QueryStore(1)
+	require.NoError(t, err)
+	assert.Nil(t, db.Get([]byte("ok")))
+
+	// failures return the code DeliverTx would
+	res = base.Query(abci.RequestQuery{Path: SimulatePath, Data: []byte("fail")})
+	assert.Equal(t, errors.ErrInvalidInput.ABCICode(), res.Code)
+	result = SimulateResult{}
+	require.NoError(t, result.Unmarshal(res.Value))
+	assert.Nil(t, result.RequiredFee)
+	dres, err = result.ParseDeliverTx()
+	require.NoError(t, err)
+	assert.Equal(t, res.Code, dres.Code)
+	assert.Equal(t, res.Log, dres.Log)
+
+	// other paths are still handled by the store
+	res = base.Query(abci.RequestQuery{Path: "/unknown"})
+	assert.Equal(t, errors.ErrNotFound.ABCICode(), res.Code)
+}
+
+// simulateHandler writes the path of the message to the store
+// on delivery, and fails if that path is "fail"
+type simulateHandler struct {
+	weavetest.Handler
+}
+
+func (h *simulateHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	msg, _ := tx.GetMsg()
+	if msg.Path() == "fail" {
+		return weave.DeliverResult{}, errors.ErrInvalidInput.New("fail")
+	}
+	db.Set([]byte(msg.Path()), []byte("written"))
+	weave.EmitEvents(ctx, weave.NewEvent("simulated"))
+	return weave.DeliverResult{
+		Data:        []byte("written"),
+		RequiredFee: coin.NewCoin(0, 50, "IOV"),
+		GasUsed:     123,
+	}, nil
+}
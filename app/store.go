@@ -2,12 +2,15 @@ package app
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/migration"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 )
@@ -36,6 +39,9 @@ type StoreApp struct {
 	// Code to initialize from a genesis file
 	initializer weave.Initializer
 
+	// Schema migrations, executed in BeginBlock
+	migrations *migration.Registry
+
 	// How to handle queries
 	queryRouter weave.QueryRouter
 
@@ -45,14 +51,36 @@ type StoreApp struct {
 
 	// cached validator changes from DeliverTx
 	pending []abci.ValidatorUpdate
+	// cached consensus params changes from DeliverTx
+	pendingParams *abci.ConsensusParams
 
 	// baseContext contains context info that is valid for
 	// lifetime of this app (eg. chainID)
 	baseContext weave.Context
 
 	// blockContext contains context info that is valid for the
-	// current block (eg. height, header), reset on BeginBlock
+	// current block (eg. height, header), reset on BeginBlock.
+	// It is guarded by ctxMtx, as CheckTx reads it concurrently
 	blockContext weave.Context
+	ctxMtx       sync.RWMutex
+
+	// models render query results as JSON, if requested
+	models weave.ModelRenderer
+
+	// options are the node-local settings changed by SetOption
+	options weave.OptionRouter
+
+	// listeners are notified of all changes after a commit,
+	// changes records them if there are any. The listeners run
+	// in their own goroutine, reading the notices.
+	listeners []CommitListener
+	changes   *changeLog
+	notices   chan commitNotice
+	notifying sync.WaitGroup
+
+	// queryLimit is the maximum number of items returned
+	// by a single iteration of a query, 0 means no limit
+	queryLimit int
 
 	// genesisFile (temporary) is used to store the file
 	// to read from on InitChain
@@ -97,6 +125,46 @@ func (s *StoreApp) WithInit(init weave.Initializer) *StoreApp {
 	return s
 }
 
+// WithMigrations sets the schema migrations executed in BeginBlock
+//
+// panics if the stored data does not match the schema versions of the code
+func (s *StoreApp) WithMigrations(reg *migration.Registry) *StoreApp {
+	if s.chainID != "" {
+		height, _ := s.store.CommitInfo()
+		if err := reg.Verify(s.DeliverStore(), height); err != nil {
+			panic(err)
+		}
+	}
+	s.migrations = reg
+	return s
+}
+
+// WithOptions sets the node-local options that can be changed
+// at runtime via SetOption
+func (s *StoreApp) WithOptions(options weave.OptionRouter) *StoreApp {
+	s.options = options
+	return s
+}
+
+// WithModels allows clients to request query results as JSON,
+// rendered by the given models (see orm.ModelRegistry)
+func (s *StoreApp) WithModels(models weave.ModelRenderer) *StoreApp {
+	s.models = models
+	return s
+}
+
+// DefaultQueryLimit is a query limit suitable for public nodes,
+// see WithQueryLimit
+const DefaultQueryLimit = 1000
+
+// WithQueryLimit caps the number of items a query iteration can return,
+// so a single prefix query cannot read the whole state.
+// Clients must follow the next key to read more.
+func (s *StoreApp) WithQueryLimit(limit int) *StoreApp {
+	s.queryLimit = limit
+	return s
+}
+
 // parseAppState is called from InitChain, the first time the chain
 // starts, and not on restarts.
 func (s *StoreApp) parseAppState(data []byte, chainID string, init weave.Initializer) error {
@@ -153,11 +221,16 @@ func (s *StoreApp) Logger() log.Logger {
 
 // BlockContext returns the block context for public use
 func (s *StoreApp) BlockContext() weave.Context {
+	s.ctxMtx.RLock()
+	defer s.ctxMtx.RUnlock()
 	return s.blockContext
 }
 
 // DeliverStore returns the current DeliverTx cache for methods
 func (s *StoreApp) DeliverStore() weave.CacheableKVStore {
+	if s.changes != nil && s.changes.recorder != nil {
+		return s.changes.recorder
+	}
 	return s.store.deliver
 }
 
@@ -173,7 +246,9 @@ func (s *StoreApp) CheckStore() weave.CacheableKVStore {
 //
 // The height is the block that holds the transactions, not the apphash itself.
 func (s *StoreApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	s.store.RLock()
 	height, hash := s.store.CommitInfo()
+	s.store.RUnlock()
 
 	s.logger.Info("Info synced",
 		"height", height,
@@ -186,10 +261,23 @@ func (s *StoreApp) Info(req abci.RequestInfo) abci.ResponseInfo {
 	}
 }
 
-// SetOption - ABCI
-// TODO: not implemented (ABCI spec still unclear....)
-func (s *StoreApp) SetOption(res abci.RequestSetOption) abci.ResponseSetOption {
-	return abci.ResponseSetOption{Log: "Not Implemented"}
+// SetOption - ABCI - changes a node-local setting registered with WithOptions.
+//
+// Options never touch the store, so the result is the same
+// on all nodes, whatever options they set.
+func (s *StoreApp) SetOption(req abci.RequestSetOption) abci.ResponseSetOption {
+	if err := s.options.Set(req.Key, req.Value); err != nil {
+		code := errors.ErrInternal.ABCICode()
+		if c, ok := err.(interface{ ABCICode() uint32 }); ok {
+			code = c.ABCICode()
+		}
+		return abci.ResponseSetOption{
+			Code: code,
+			Log:  err.Error(),
+		}
+	}
+	s.logger.Info("Option set", "key", req.Key, "value", req.Value)
+	return abci.ResponseSetOption{Log: "ok"}
 }
 
 /*
@@ -197,12 +285,23 @@ Query gets data from the app store.
 A query request has the following elements:
 * Path - the type of query
 * Data - what to query, interpretted based on Path
-* Height - the block height to query (if 0 most recent)
-* Prove - if true, also return a proof
+* Height - the block height to query (if 0 most recent),
+  it must still be kept in the history of the store
+* Prove - if true, also return a proof of all data read
+  to answer the query (see store/proofs to verify it)
 
 Path may be "/", "/<bucket>", or "/<bucket>/<index>"
-It may be followed by "?prefix" to make a prefix query.
-Soon we will support "?range" for powerful range queries
+It may be followed by "?prefix" to make a prefix query,
+or "?range" to query a key range (see weave.RangeQuery)
+
+Iterations can be paginated with options following the
+modifier, eg. "?prefix&limit=10&cursor=0a0b" (see weave.QueryOptions).
+If results were left out, Info holds the hex encoded key to
+continue from (see QueryNextKey).
+
+With the "json" option, eg. "?json" or "?prefix&json", Key is empty
+and Value is a JSON list of {"key": <hex>, "value": <model>} objects,
+if the application set models to render them (see WithModels).
 
 Key and Value in Results are always serialized ResultSet
 objects, able to support 0 to N values. They must be the
@@ -210,9 +309,22 @@ same size. This makes things a little more difficult for
 simple queries, but provides a consistent interface.
 */
 func (s *StoreApp) Query(reqQuery abci.RequestQuery) (resQuery abci.ResponseQuery) {
+	// Commit must not prune the state while it is read
+	s.store.RLock()
+	defer s.store.RUnlock()
 
 	// find the handler
 	path, mod := splitPath(reqQuery.Path)
+	mod, opts, err := weave.ParseQueryMod(mod)
+	if err != nil {
+		return queryError(err)
+	}
+	if s.queryLimit > 0 && (opts.Limit == 0 || opts.Limit > s.queryLimit) {
+		opts.Limit = s.queryLimit
+	}
+	if opts.JSON && s.models == nil {
+		return queryError(errors.ErrInvalidInput.New("json not supported"))
+	}
 	qh := s.queryRouter.Handler(path)
 	if qh == nil {
 		resQuery.Code = errors.ErrNotFound.ABCICode()
@@ -220,46 +332,56 @@ func (s *StoreApp) Query(reqQuery abci.RequestQuery) (resQuery abci.ResponseQuer
 		return
 	}
 
-	// TODO: support historical queries by getting old read-only
-	// height := reqQuery.Height
-	// if height == 0 {
-	// 	withProof := s.CommittedHeight() - 1
-	// 	if tree.Tree.VersionExists(uint64(withProof)) {
-	// 		height = withProof
-	// 	} else {
-	// 		height = s.CommittedHeight()
-	// 	}
-	// }
-	height, _ := s.store.CommitInfo()
+	// height 0 means the most recent committed state
+	height := reqQuery.Height
+	if height == 0 {
+		height, _ = s.store.CommitInfo()
+	}
 	resQuery.Height = height
-	// TODO: better version handling!
-	db := s.store.committed.CacheWrap()
-
-	// make the query
-	models, err := qh.Query(db, mod, reqQuery.Data)
+	db, err := s.store.QueryStore(height)
 	if err != nil {
 		return queryError(err)
 	}
 
-	// set the info as ResultSets....
-	resQuery.Key, err = ResultsFromKeys(models).Marshal()
-	if err != nil {
-		return queryError(err)
+	// paginate iterations and remember all reads, so we can prove them later
+	var qs *queryStore
+	if opts.Paginated() || reqQuery.Prove {
+		qs = newQueryStore(db, opts, reqQuery.Prove)
+		db = qs
 	}
-	resQuery.Value, err = ResultsFromValues(models).Marshal()
+
+	// make the query
+	models, err := qh.Query(db, mod, reqQuery.Data)
 	if err != nil {
 		return queryError(err)
 	}
 
-	// TODO: support proofs given this info....
-	// if reqQuery.Prove {
-	//  value, proof, err := tree.GetVersionedWithProof(key, height)
-	//  if err != nil {
-	//      resQuery.Log = err.Error()
-	//      break
-	//  }
-	//  resQuery.Value = value
-	//  resQuery.Proof = proof.Bytes()
+	if opts.JSON {
+		resQuery.Value, err = renderJSON(s.models, models)
+		if err != nil {
+			return queryError(err)
+		}
+	} else {
+		// set the info as ResultSets....
+		resQuery.Key, err = ResultsFromKeys(models).Marshal()
+		if err != nil {
+			return queryError(err)
+		}
+		resQuery.Value, err = ResultsFromValues(models).Marshal()
+		if err != nil {
+			return queryError(err)
+		}
+	}
+
+	if qs != nil && qs.next != nil {
+		resQuery.Info = hex.EncodeToString(qs.next)
+	}
+	if reqQuery.Prove {
+		resQuery.Proof, err = s.store.prove(qs, height)
+		if err != nil {
+			return queryError(err)
+		}
+	}
 
 	return resQuery
 }
@@ -276,64 +398,145 @@ func splitPath(path string) (string, string) {
 	return path, mod
 }
 
+// queryError converts an error into a query response, preserving
+// the abci code if the error provides one
 func queryError(err error) abci.ResponseQuery {
+	code := errors.ErrInternal.ABCICode()
+	if c, ok := err.(interface{ ABCICode() uint32 }); ok {
+		code = c.ABCICode()
+	}
 	return abci.ResponseQuery{
 		Log:  err.Error(),
-		Code: errors.ErrInternal.ABCICode(),
+		Code: code,
 	}
 }
 
 // Commit implements abci.Application
 func (s *StoreApp) Commit() (res abci.ResponseCommit) {
+	var changes []ChangeSet
+	if s.changes != nil {
+		changes = s.changes.flush()
+	}
 	commitID := s.store.Commit()
+	s.notify(commitID, changes)
 
 	s.logger.Debug("Commit synced",
 		"height", commitID.Version,
 		"hash", fmt.Sprintf("%X", commitID.Hash),
 	)
+	if stats, ok := s.store.CacheStats(); ok {
+		s.logger.Debug("Value cache",
+			"hits", stats.Hits,
+			"misses", stats.Misses,
+			"evictions", stats.Evictions,
+			"size", stats.Size,
+		)
+	}
 
 	return abci.ResponseCommit{Data: commitID.Hash}
 }
 
 // InitChain implements ABCI
-// TODO: store the original validators somewhere
 // Note: in tendermint 0.17, the genesis file is passed
 // in here, we should use this to trigger reading the genesis now
-// TODO: investigate validators and consensusParams in response
+//
+// The initial validators and consensus params are stored, so they
+// can be read with weave.GetValidators and weave.GetConsensusParams
 func (s *StoreApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitChain) {
+	s.store.RLock()
+	defer s.store.RUnlock()
+
+	s.recordStep(StepInitChain)
 	err := s.parseAppState(req.AppStateBytes, req.ChainId, s.initializer)
 	if err != nil {
 		// Read comment on type header
 		panic(err)
 	}
+	if err := weave.UpdateValidators(s.DeliverStore(), req.Validators); err != nil {
+		panic(err)
+	}
+	if err := weave.UpdateConsensusParams(s.DeliverStore(), req.ConsensusParams); err != nil {
+		panic(err)
+	}
 
 	return abci.ResponseInitChain{}
 }
 
 // BeginBlock implements ABCI
-// Sets up blockContext
+// Sets up blockContext, including the votes of the last commit
+// and the evidence of byzantine validators
+//
+// The caller must hold the read lock of the store
 // TODO: investigate response tags as of 0.11 abci
 func (s *StoreApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
 	// set the begin block context
 	ctx := weave.WithHeader(s.baseContext, req.Header)
 	ctx = weave.WithHeight(ctx, req.Header.GetHeight())
+	ctx = weave.WithCommitInfo(ctx, req.LastCommitInfo)
+	ctx = weave.WithEvidence(ctx, req.ByzantineValidators)
+	s.ctxMtx.Lock()
 	s.blockContext = ctx
-
+	s.ctxMtx.Unlock()
+	s.recordStep(StepBeginBlock)
+
+	if s.migrations != nil {
+		ctx = weave.WithLogInfo(ctx, "call", "migrate")
+		if err := s.migrations.Migrate(ctx, s.DeliverStore()); err != nil {
+			// Read comment on type header
+			panic(err)
+		}
+	}
 	return
 }
 
 // EndBlock - ABCI
-// Returns a list of all validator changes made in this block
+// Returns a list of all validator and consensus params changes made
+// in this block, after applying them to the stored ones
 // TODO: investigate response tags as of 0.11 abci
-func (s *StoreApp) EndBlock(_ abci.RequestEndBlock) (res abci.ResponseEndBlock) {
+func (s *StoreApp) EndBlock(_ abci.RequestEndBlock) abci.ResponseEndBlock {
+	return s.endBlock(nil)
+}
+
+// endBlock records the changes of the end block step, calls the
+// optional application end blocker and applies the pending changes,
+// including the ones added by the end blocker
+func (s *StoreApp) endBlock(endBlocker func()) (res abci.ResponseEndBlock) {
+	s.recordStep(StepEndBlock)
+	if endBlocker != nil {
+		endBlocker()
+	}
+	if err := weave.UpdateValidators(s.DeliverStore(), s.pending); err != nil {
+		// Read comment on type header
+		panic(err)
+	}
+	if err := weave.UpdateConsensusParams(s.DeliverStore(), s.pendingParams); err != nil {
+		panic(err)
+	}
 	res.ValidatorUpdates = s.pending
+	res.ConsensusParamUpdates = s.pendingParams
 	s.pending = nil
+	s.pendingParams = nil
 	return
 }
 
+// AddParamsChange is meant to be called by apps on DeliverTx
+// results, the sections set replace the ones of previous changes
+// in the same block. Nothing is changed if the params are invalid.
+func (s *StoreApp) AddParamsChange(params *abci.ConsensusParams) error {
+	if err := weave.ValidateConsensusParams(params); err != nil {
+		return err
+	}
+	s.pendingParams = weave.MergeConsensusParams(s.pendingParams, params)
+	return nil
+}
+
 // AddValChange is meant to be called by apps on DeliverTx
-// results, this is added to the cache for the endblock changeset
-func (s *StoreApp) AddValChange(diffs []abci.ValidatorUpdate) {
+// results, this is added to the cache for the endblock changeset.
+// Nothing is changed if any update is invalid.
+func (s *StoreApp) AddValChange(diffs []abci.ValidatorUpdate) error {
+	if err := weave.ValidateValidatorUpdates(diffs); err != nil {
+		return err
+	}
 	// ensures multiple updates for one validator are combined into one slot
 	for _, d := range diffs {
 		idx := pubKeyIndex(d, s.pending)
@@ -343,6 +546,7 @@ func (s *StoreApp) AddValChange(diffs []abci.ValidatorUpdate) {
 			s.pending = append(s.pending, d)
 		}
 	}
+	return nil
 }
 
 // return index of list with validator of same Pubkey, or -1 if no match
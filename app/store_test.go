@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/migration"
+	"github.com/iov-one/weave/store/iavl"
+)
+
+func TestStoreAppMigrations(t *testing.T) {
+	kv := iavl.MockCommitStore()
+	migrated := func(db weave.KVStore) []byte {
+		return db.Get([]byte("migrated"))
+	}
+	reg := migration.NewRegistry()
+	reg.Register("foo", 1, 2, func(ctx weave.Context, db weave.KVStore) error {
+		db.Set([]byte("migrated"), []byte{1})
+		return nil
+	})
+
+	// genesis data starts at version 0, the migration waits for its height
+	fresh := NewStoreApp("mig", kv, weave.NewQueryRouter(), context.Background()).
+		WithInit(ChainInitializers(&migration.Initializer{})).
+		WithMigrations(reg)
+	fresh.InitChain(abci.RequestInitChain{ChainId: "mig-chain", AppStateBytes: []byte(`{}`)})
+	assert.Equal(t, uint32(0), migration.Version(fresh.DeliverStore(), "foo"))
+	fresh.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	assert.Nil(t, migrated(fresh.DeliverStore()))
+	fresh.Commit()
+	fresh.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	assert.Equal(t, []byte{1}, migrated(fresh.DeliverStore()))
+	fresh.Commit()
+
+	// an upgraded application migrates the data at the agreed height
+	reg.Register("foo", 2, 4, func(ctx weave.Context, db weave.KVStore) error {
+		db.Set([]byte("migrated"), []byte{2})
+		return nil
+	})
+	upgraded := NewStoreApp("mig", kv, weave.NewQueryRouter(), context.Background()).
+		WithMigrations(reg)
+	upgraded.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	assert.Equal(t, []byte{1}, migrated(upgraded.DeliverStore()))
+	upgraded.Commit()
+	upgraded.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 4}})
+	assert.Equal(t, []byte{2}, migrated(upgraded.DeliverStore()))
+	upgraded.Commit()
+
+	// older code refuses to start on migrated data
+	old := migration.NewRegistry()
+	old.Register("foo", 1, 2, func(weave.Context, weave.KVStore) error { return nil })
+	assert.Panics(t, func() {
+		NewStoreApp("mig", kv, weave.NewQueryRouter(), context.Background()).
+			WithMigrations(old)
+	})
+
+	// a genesis written in a newer format declares its versions
+	declared := NewStoreApp("mig", iavl.MockCommitStore(), weave.NewQueryRouter(), context.Background()).
+		WithInit(ChainInitializers(&migration.Initializer{})).
+		WithMigrations(reg)
+	declared.InitChain(abci.RequestInitChain{ChainId: "mig-chain",
+		AppStateBytes: []byte(`{"migration": {"foo": 2}}`)})
+	declared.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 4}})
+	assert.Nil(t, migrated(declared.DeliverStore()))
+}
+
+func TestStoreAppSetOption(t *testing.T) {
+	s := NewStoreApp("opt", iavl.MockCommitStore(), weave.NewQueryRouter(), context.Background())
+
+	// no options registered
+	res := s.SetOption(abci.RequestSetOption{Key: "limit", Value: "1"})
+	assert.Equal(t, errors.ErrNotFound.ABCICode(), res.Code)
+
+	var limit string
+	options := weave.NewOptionRouter()
+	options.Register("limit", func(value string) error {
+		if value == "" {
+			return errors.ErrEmpty.New("limit")
+		}
+		limit = value
+		return nil
+	})
+	s.WithOptions(options)
+	before := s.Info(abci.RequestInfo{})
+
+	res = s.SetOption(abci.RequestSetOption{Key: "limit", Value: "7"})
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	assert.Equal(t, "7", limit)
+
+	res = s.SetOption(abci.RequestSetOption{Key: "limit", Value: ""})
+	assert.Equal(t, errors.ErrEmpty.ABCICode(), res.Code)
+	assert.Equal(t, "7", limit)
+
+	// the consensus state is not affected
+	after := s.Info(abci.RequestInfo{})
+	assert.Equal(t, before, after)
+}
+
+func TestStoreAppValidatorSet(t *testing.T) {
+	s := NewStoreApp("valset", iavl.MockCommitStore(), weave.NewQueryRouter(), context.Background()).
+		WithInit(ChainInitializers())
+	v1, v2 := validatorUpdate(1), validatorUpdate(2)
+	s.InitChain(abci.RequestInitChain{
+		ChainId:       "valset-chain",
+		AppStateBytes: []byte(`{}`),
+		Validators:    []abci.ValidatorUpdate{v1, v2},
+		ConsensusParams: &abci.ConsensusParams{
+			BlockSize: &abci.BlockSizeParams{MaxBytes: 1000, MaxGas: -1},
+			Evidence:  &abci.EvidenceParams{MaxAge: 100},
+		},
+	})
+	vals, err := weave.GetValidators(s.DeliverStore())
+	assert.NoError(t, err)
+	assert.Equal(t, []abci.ValidatorUpdate{v1, v2}, vals)
+
+	// changes are applied at the end of the block
+	s.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	v1.Power = 0
+	v3 := validatorUpdate(3)
+	s.AddValChange([]abci.ValidatorUpdate{v1, v3})
+	evidence := &abci.ConsensusParams{Evidence: &abci.EvidenceParams{MaxAge: 500}}
+	s.AddParamsChange(evidence)
+	res := s.EndBlock(abci.RequestEndBlock{Height: 1})
+	assert.Equal(t, []abci.ValidatorUpdate{v1, v3}, res.ValidatorUpdates)
+	assert.Equal(t, evidence, res.ConsensusParamUpdates)
+	s.Commit()
+
+	vals, err = weave.GetValidators(s.DeliverStore())
+	assert.NoError(t, err)
+	assert.Equal(t, []abci.ValidatorUpdate{v2, v3}, vals)
+	params, err := weave.GetConsensusParams(s.DeliverStore())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), params.BlockSize.MaxBytes)
+	assert.Equal(t, int64(500), params.Evidence.MaxAge)
+
+	// nothing changed in the next block
+	s.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	res = s.EndBlock(abci.RequestEndBlock{Height: 2})
+	assert.Empty(t, res.ValidatorUpdates)
+	assert.Nil(t, res.ConsensusParamUpdates)
+}
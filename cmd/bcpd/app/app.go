@@ -6,9 +6,6 @@ package app
 
 import (
 	"context"
-	"fmt"
-	"path/filepath"
-	"strings"
 
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/app"
@@ -33,6 +30,13 @@ func Authenticator() x.Authenticator {
 	return x.ChainAuth(sigs.Authenticate{}, hashlock.Authenticate{}, multisig.Authenticate{})
 }
 
+// TxGasLimit is the maximum gas a single transaction may consume
+const TxGasLimit = 1000000
+
+// CheckMinFee is the node-local minimal fee enforced on CheckTx,
+// it is set at runtime with the "cash.check_min_fee" option
+var CheckMinFee = cash.NewCheckMinFee()
+
 // Chain returns a chain of decorators, to handle authentication,
 // fees, logging, and recovery
 func Chain(authFn x.Authenticator) app.Decorators {
@@ -43,14 +47,24 @@ func Chain(authFn x.Authenticator) app.Decorators {
 	return app.ChainDecorators(
 		utils.NewLogging(),
 		utils.NewRecovery(),
+		utils.NewGasLimiter(TxGasLimit),
 		utils.NewKeyTagger(),
 		// on CheckTx, bad tx don't affect state
 		utils.NewSavepoint().OnCheck(),
+		// reject expired txs before verifying their signatures
+		sigs.NewExpiryDecorator(),
 		sigs.NewDecorator(),
 		multisig.NewDecorator(authFn),
-		cash.NewDynamicFeeDecorator(authFn, ctrl),
+		// prefer txs paying a higher fee per byte in the mempool,
+		// and refuse txs paying less than the node-local minimal fee
+		cash.NewDynamicFeeDecorator(authFn, ctrl).
+			WithPriority(cash.PriorityConfig{}).
+			WithCheckMinFee(CheckMinFee),
 		// cannot pay for fee with hashlock...
 		hashlock.NewDecorator(),
+		// on DeliverTx, bad tx will increment nonce and take fee
+		// even if the message fails
+		utils.NewSavepoint().OnDeliver(),
 		// make sure we execute all the transactions in batch after savepoint
 		batch.NewDecorator(),
 	)
@@ -90,6 +104,20 @@ func QueryRouter() weave.QueryRouter {
 	return r
 }
 
+// Models returns the models stored by all extensions,
+// so query results can be rendered as JSON
+func Models() orm.ModelRegistry {
+	r := orm.NewModelRegistry()
+	escrow.NewBucket().RegisterModel(r)
+	cash.NewBucket().RegisterModel(r)
+	sigs.NewBucket().RegisterModel(r)
+	multisig.NewContractBucket().RegisterModel(r)
+	validators.NewBucket().RegisterModel(r)
+	currency.NewTokenInfoBucket().RegisterModel(r)
+	distribution.NewRevenueBucket().RegisterModel(r)
+	return r
+}
+
 // Stack wires up a standard router with a standard decorator
 // chain. This can be passed into BaseApp.
 func Stack(issuer weave.Address) weave.Handler {
@@ -109,29 +137,19 @@ func Application(name string, h weave.Handler,
 		return app.BaseApp{}, err
 	}
 	store := app.NewStoreApp(name, kv, QueryRouter(), ctx)
-	base := app.NewBaseApp(store, tx, h, nil, debug)
+	base := app.NewBaseApp(store, tx, h, nil, nil, debug)
 	return base, nil
 }
 
 // CommitKVStore returns an initialized KVStore that persists
-// the data to the named path.
+// the data to the named path. The database is configured by the
+// iavl.OptionsFile in the same directory, if there is one, and it is
+// a multi store if the options mount any substore.
 func CommitKVStore(dbPath string) (weave.CommitKVStore, error) {
 	// memory backed case, just for testing
 	if dbPath == "" {
 		return iavl.MockCommitStore(), nil
 	}
 
-	// Expand the path fully
-	path, err := filepath.Abs(dbPath)
-	if err != nil {
-		return nil, fmt.Errorf("Invalid Database Name: %s", path)
-	}
-
-	// Some external calls accidently add a ".db", which is now removed
-	path = strings.TrimSuffix(path, filepath.Ext(path))
-
-	// Split the database name into it's components (dir, name)
-	dir := filepath.Dir(path)
-	name := filepath.Base(path)
-	return iavl.NewCommitStore(dir, name), nil
+	return iavl.OpenStore(dbPath)
 }
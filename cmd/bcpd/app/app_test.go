@@ -5,6 +5,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
@@ -13,6 +16,7 @@ import (
 	"github.com/iov-one/weave/app"
 	"github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/crypto"
+	"github.com/iov-one/weave/store/iavl"
 	"github.com/iov-one/weave/weavetest"
 	"github.com/iov-one/weave/x/batch"
 	"github.com/iov-one/weave/x/cash"
@@ -44,8 +48,14 @@ func TestSendTx(t *testing.T) {
 	pk2 := weavetest.NewKey()
 	addr2 := pk2.PublicKey().Address()
 	dres := sendBatch(t, false, myApp, chainID, 2, []*account{mainAccount}, mainAccount.address(), addr2, amount, "ETH", "Have a great trip!")
-	require.Equal(t, 3, len(dres.Tags), "%#v", dres.Tags)
 	addr := mainAccount.pk.PublicKey().Address()
+	// every message in the batch reports its transfer
+	transfer := cash.TransferEvent(addr, addr2, coin.NewCoin(amount, 0, "ETH")).Tags()
+	require.Equal(t, 3+batch.MaxBatchMessages*len(transfer), len(dres.Tags), "%#v", dres.Tags)
+	for i := 0; i < batch.MaxBatchMessages; i++ {
+		start := 3 + i*len(transfer)
+		assert.Equal(t, transfer, dres.Tags[start:start+len(transfer)])
+	}
 	wantKeys := []string{
 		toHex("cash:") + addr.String(),
 		toHex("cash:") + addr2.String(),
@@ -125,6 +135,28 @@ func TestQuery(t *testing.T) {
 	})
 }
 
+func TestMultiStore(t *testing.T) {
+	home, err := ioutil.TempDir("", "bcpd-multi-")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+	opts := `{"backend": "memdb", "mounts": [{"name": "cash", "prefixes": ["cash:"]}]}`
+	err = ioutil.WriteFile(filepath.Join(home, iavl.OptionsFile), []byte(opts), 0644)
+	require.NoError(t, err)
+
+	chainID := "test-net-22"
+	mainAccount := &account{pk: weavetest.NewKey()}
+	myApp := newTestAppAt(t, home, chainID, []*account{mainAccount})
+	addr2 := weavetest.NewKey().PublicKey().Address()
+	sendToken(t, myApp, chainID, 2, []*account{mainAccount}, mainAccount.address(), addr2, 2000, "ETH", "Have a great trip!")
+
+	// wallets are read from their own substore
+	queryAndCheckWallet(t, false, myApp, "/wallets", addr2, cash.Set{
+		Coins: coin.Coins{
+			{Ticker: "ETH", Whole: 2000},
+		},
+	})
+}
+
 func TestMultisigContract(t *testing.T) {
 	chainID := "test-net-22"
 	mainAccount := &account{pk: weavetest.NewKey()}
@@ -180,8 +212,15 @@ func (a *account) address() []byte {
 // coins and tokens are the same across all accounts and calls
 func newTestApp(t Tester, chainID string, accounts []*account) app.BaseApp {
 	t.Helper()
-	// no minimum fee, in-memory data-store
-	abciApp, err := GenerateApp("", log.NewNopLogger(), true)
+	return newTestAppAt(t, "", chainID, accounts)
+}
+
+// newTestAppAt creates the app in home, with an in-memory data-store
+// if home is empty
+func newTestAppAt(t Tester, home, chainID string, accounts []*account) app.BaseApp {
+	t.Helper()
+	// no minimum fee
+	abciApp, err := GenerateApp(home, log.NewNopLogger(), true)
 	require.NoError(t, err)
 	myApp := abciApp.(app.BaseApp) // let's set up a genesis file with some cash
 	appState := withWalletAppState(t, accounts)
@@ -261,8 +300,8 @@ func (c *contract) address() weave.Address {
 	return multisig.MultiSigCondition(c.id).Address()
 }
 
-func (c *contract) sigs() [][]byte {
-	var sigsAddr = make([][]byte, len(c.accountSigs))
+func (c *contract) sigs() []weave.Address {
+	var sigsAddr = make([]weave.Address, len(c.accountSigs))
 
 	for i, s := range c.accountSigs {
 		sigsAddr[i] = s.address()
@@ -429,7 +468,7 @@ func createContract(
 	height int64,
 	signers []*account,
 	activationThreshold int64,
-	contractSigs ...[]byte,
+	contractSigs ...weave.Address,
 ) []byte {
 	t.Helper()
 	msg := &multisig.CreateContractMsg{
@@ -594,7 +633,7 @@ func makeSendTxMultisig(t Tester, chainID string, sender, receiver *contract, ti
 	return txBytes
 }
 
-func makeCreateContractTx(t Tester, chainID string, signers [][]byte, threshold int64) *Tx {
+func makeCreateContractTx(t Tester, chainID string, signers []weave.Address, threshold int64) *Tx {
 	t.Helper()
 	msg := &multisig.CreateContractMsg{
 		Sigs:                signers,
@@ -679,20 +718,34 @@ func benchmarkSendTxWithMultisig(b *testing.B, nbAccounts, blockSize, nbContract
 	}
 }
 
+// valueCacheHome returns a home directory configuring a data-store
+// with the given backend that caches the given number of values
+func valueCacheHome(t Tester, backend string, size int) (string, func()) {
+	t.Helper()
+	home, err := ioutil.TempDir("", "bcpd-cache-")
+	require.NoError(t, err)
+	opts := fmt.Sprintf(`{"backend": %q, "value_cache_size": %d}`, backend, size)
+	err = ioutil.WriteFile(filepath.Join(home, iavl.OptionsFile), []byte(opts), 0644)
+	require.NoError(t, err)
+	return home, func() { os.RemoveAll(home) }
+}
+
 // benchmarkSendTx runs the actual benchmark sequence eg.
 // N * CheckTx
 // BeginBlock
 // N * DeliverTx
 // EndBlock
 // Commit
-func benchmarkSendTx(b *testing.B, nbAccounts, blockSize int) {
+//
+// The app is created in home, or in memory if it is empty.
+func benchmarkSendTx(b *testing.B, home string, nbAccounts, blockSize int) {
 	accounts := make([]*account, nbAccounts)
 	for i := 0; i < nbAccounts; i++ {
 		accounts[i] = &account{pk: weavetest.NewKey()}
 	}
 
 	chainID := "bench-net-22"
-	myApp := newTestApp(b, chainID, accounts)
+	myApp := newTestAppAt(b, home, chainID, accounts)
 
 	txs := make([][]byte, b.N)
 	for i := 0; i < b.N; i++ {
@@ -750,7 +803,38 @@ func BenchmarkSendTx(b *testing.B) {
 	for _, bb := range benchmarks {
 		prefix := fmt.Sprintf("%d-%d", bb.accounts, bb.blockSize)
 		b.Run(prefix, func(sub *testing.B) {
-			benchmarkSendTx(sub, bb.accounts, bb.blockSize)
+			benchmarkSendTx(sub, "", bb.accounts, bb.blockSize)
+		})
+	}
+}
+
+// Compares the value cache sizes of the memdb and goleveldb
+// data-stores, a size of zero disables the cache
+func BenchmarkSendTxValueCache(b *testing.B) {
+	benchmarks := []struct {
+		backend    string
+		accounts   int
+		blockSize  int
+		valueCache int
+	}{
+		{"memdb", 10000, 100, 0},
+		{"memdb", 10000, 100, 1000},
+		{"memdb", 10000, 100, 100000},
+		{"memdb", 100000, 100, 0},
+		{"memdb", 100000, 100, 1000},
+		{"memdb", 100000, 100, 100000},
+		{"goleveldb", 10000, 100, 0},
+		{"goleveldb", 10000, 100, 100000},
+		{"goleveldb", 100000, 100, 0},
+		{"goleveldb", 100000, 100, 100000},
+	}
+
+	for _, bb := range benchmarks {
+		prefix := fmt.Sprintf("%s-%d-%d-%d", bb.backend, bb.accounts, bb.blockSize, bb.valueCache)
+		b.Run(prefix, func(sub *testing.B) {
+			home, cleanup := valueCacheHome(sub, bb.backend, bb.valueCache)
+			defer cleanup()
+			benchmarkSendTx(sub, home, bb.accounts, bb.blockSize)
 		})
 	}
 }
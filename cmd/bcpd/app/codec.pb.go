@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: app/codec.proto
+// source: cmd/bcpd/app/codec.proto
 
 package app
 
@@ -31,11 +31,11 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 // Tx contains the message.
 //
 // When extending Tx, follow the rules:
-// - range 1-50 is reserved for middlewares,
-// - range 51-inf is reserved for different message types,
-// - keep the same numbers for the same message types in both bcpd and bnsd
-//   applications. For example, FeeInfo field is used by both and indexed at
-//   first position. Skip unused fields (leave index unused).
+//   - range 1-50 is reserved for middlewares,
+//   - range 51-inf is reserved for different message types,
+//   - keep the same numbers for the same message types in both bcpd and bnsd
+//     applications. For example, FeeInfo field is used by both and indexed at
+//     first position. Skip unused fields (leave index unused).
 type Tx struct {
 	Fees       *cash.FeeInfo        `protobuf:"bytes,1,opt,name=fees" json:"fees,omitempty"`
 	Signatures []*sigs.StdSignature `protobuf:"bytes,2,rep,name=signatures" json:"signatures,omitempty"`
@@ -43,6 +43,8 @@ type Tx struct {
 	Preimage []byte `protobuf:"bytes,3,opt,name=preimage,proto3" json:"preimage,omitempty"`
 	// ID of a multisig contract.
 	Multisig [][]byte `protobuf:"bytes,4,rep,name=multisig" json:"multisig,omitempty"`
+	// Optional expiration, the transaction is rejected once it passed.
+	ValidUntil *sigs.ValidUntil `protobuf:"bytes,5,opt,name=valid_until,json=validUntil" json:"valid_until,omitempty"`
 	// msg is a sum type over all allowed messages on this chain.
 	//
 	// Types that are valid to be assigned to Sum:
@@ -59,6 +61,7 @@ type Tx struct {
 	//	*Tx_NewRevenueMsg
 	//	*Tx_DistributeMsg
 	//	*Tx_ResetRevenueMsg
+	//	*Tx_SetConsensusParamsMsg
 	Sum                  isTx_Sum `protobuf_oneof:"sum"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -68,7 +71,7 @@ func (m *Tx) Reset()         { *m = Tx{} }
 func (m *Tx) String() string { return proto.CompactTextString(m) }
 func (*Tx) ProtoMessage()    {}
 func (*Tx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_5c05d1f53681ab0a, []int{0}
+	return fileDescriptor_codec_c3f96b5f78f6cecd, []int{0}
 }
 func (m *Tx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -142,20 +145,24 @@ type Tx_DistributeMsg struct {
 type Tx_ResetRevenueMsg struct {
 	ResetRevenueMsg *distribution.ResetRevenueMsg `protobuf:"bytes,68,opt,name=reset_revenue_msg,json=resetRevenueMsg,oneof"`
 }
-
-func (*Tx_SendMsg) isTx_Sum()           {}
-func (*Tx_CreateEscrowMsg) isTx_Sum()   {}
-func (*Tx_ReleaseEscrowMsg) isTx_Sum()  {}
-func (*Tx_ReturnEscrowMsg) isTx_Sum()   {}
-func (*Tx_UpdateEscrowMsg) isTx_Sum()   {}
-func (*Tx_CreateContractMsg) isTx_Sum() {}
-func (*Tx_UpdateContractMsg) isTx_Sum() {}
-func (*Tx_SetValidatorsMsg) isTx_Sum()  {}
-func (*Tx_NewTokenInfoMsg) isTx_Sum()   {}
-func (*Tx_BatchMsg) isTx_Sum()          {}
-func (*Tx_NewRevenueMsg) isTx_Sum()     {}
-func (*Tx_DistributeMsg) isTx_Sum()     {}
-func (*Tx_ResetRevenueMsg) isTx_Sum()   {}
+type Tx_SetConsensusParamsMsg struct {
+	SetConsensusParamsMsg *validators.SetConsensusParamsMsg `protobuf:"bytes,69,opt,name=set_consensus_params_msg,json=setConsensusParamsMsg,oneof"`
+}
+
+func (*Tx_SendMsg) isTx_Sum()               {}
+func (*Tx_CreateEscrowMsg) isTx_Sum()       {}
+func (*Tx_ReleaseEscrowMsg) isTx_Sum()      {}
+func (*Tx_ReturnEscrowMsg) isTx_Sum()       {}
+func (*Tx_UpdateEscrowMsg) isTx_Sum()       {}
+func (*Tx_CreateContractMsg) isTx_Sum()     {}
+func (*Tx_UpdateContractMsg) isTx_Sum()     {}
+func (*Tx_SetValidatorsMsg) isTx_Sum()      {}
+func (*Tx_NewTokenInfoMsg) isTx_Sum()       {}
+func (*Tx_BatchMsg) isTx_Sum()              {}
+func (*Tx_NewRevenueMsg) isTx_Sum()         {}
+func (*Tx_DistributeMsg) isTx_Sum()         {}
+func (*Tx_ResetRevenueMsg) isTx_Sum()       {}
+func (*Tx_SetConsensusParamsMsg) isTx_Sum() {}
 
 func (m *Tx) GetSum() isTx_Sum {
 	if m != nil {
@@ -192,6 +199,13 @@ func (m *Tx) GetMultisig() [][]byte {
 	return nil
 }
 
+func (m *Tx) GetValidUntil() *sigs.ValidUntil {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return nil
+}
+
 func (m *Tx) GetSendMsg() *cash.SendMsg {
 	if x, ok := m.GetSum().(*Tx_SendMsg); ok {
 		return x.SendMsg
@@ -283,6 +297,13 @@ func (m *Tx) GetResetRevenueMsg() *distribution.ResetRevenueMsg {
 	return nil
 }
 
+func (m *Tx) GetSetConsensusParamsMsg() *validators.SetConsensusParamsMsg {
+	if x, ok := m.GetSum().(*Tx_SetConsensusParamsMsg); ok {
+		return x.SetConsensusParamsMsg
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Tx) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Tx_OneofMarshaler, _Tx_OneofUnmarshaler, _Tx_OneofSizer, []interface{}{
@@ -299,6 +320,7 @@ func (*Tx) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, fun
 		(*Tx_NewRevenueMsg)(nil),
 		(*Tx_DistributeMsg)(nil),
 		(*Tx_ResetRevenueMsg)(nil),
+		(*Tx_SetConsensusParamsMsg)(nil),
 	}
 }
 
@@ -371,6 +393,11 @@ func _Tx_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ResetRevenueMsg); err != nil {
 			return err
 		}
+	case *Tx_SetConsensusParamsMsg:
+		_ = b.EncodeVarint(69<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SetConsensusParamsMsg); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Tx.Sum has unexpected type %T", x)
@@ -485,6 +512,14 @@ func _Tx_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bo
 		err := b.DecodeMessage(msg)
 		m.Sum = &Tx_ResetRevenueMsg{msg}
 		return true, err
+	case 69: // sum.set_consensus_params_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(validators.SetConsensusParamsMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &Tx_SetConsensusParamsMsg{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -559,6 +594,11 @@ func _Tx_OneofSizer(msg proto.Message) (n int) {
 		n += 2 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Tx_SetConsensusParamsMsg:
+		s := proto.Size(x.SetConsensusParamsMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -577,7 +617,7 @@ func (m *BatchMsg) Reset()         { *m = BatchMsg{} }
 func (m *BatchMsg) String() string { return proto.CompactTextString(m) }
 func (*BatchMsg) ProtoMessage()    {}
 func (*BatchMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_5c05d1f53681ab0a, []int{1}
+	return fileDescriptor_codec_c3f96b5f78f6cecd, []int{1}
 }
 func (m *BatchMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -624,6 +664,7 @@ type BatchMsg_Union struct {
 	//	*BatchMsg_Union_UpdateContractMsg
 	//	*BatchMsg_Union_SetValidatorsMsg
 	//	*BatchMsg_Union_NewTokenInfoMsg
+	//	*BatchMsg_Union_SetConsensusParamsMsg
 	Sum                  isBatchMsg_Union_Sum `protobuf_oneof:"sum"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -633,7 +674,7 @@ func (m *BatchMsg_Union) Reset()         { *m = BatchMsg_Union{} }
 func (m *BatchMsg_Union) String() string { return proto.CompactTextString(m) }
 func (*BatchMsg_Union) ProtoMessage()    {}
 func (*BatchMsg_Union) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_5c05d1f53681ab0a, []int{1, 0}
+	return fileDescriptor_codec_c3f96b5f78f6cecd, []int{1, 0}
 }
 func (m *BatchMsg_Union) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -695,16 +736,20 @@ type BatchMsg_Union_SetValidatorsMsg struct {
 type BatchMsg_Union_NewTokenInfoMsg struct {
 	NewTokenInfoMsg *currency.NewTokenInfoMsg `protobuf:"bytes,11,opt,name=new_token_info_msg,json=newTokenInfoMsg,oneof"`
 }
+type BatchMsg_Union_SetConsensusParamsMsg struct {
+	SetConsensusParamsMsg *validators.SetConsensusParamsMsg `protobuf:"bytes,12,opt,name=set_consensus_params_msg,json=setConsensusParamsMsg,oneof"`
+}
 
-func (*BatchMsg_Union_SendMsg) isBatchMsg_Union_Sum()           {}
-func (*BatchMsg_Union_CreateEscrowMsg) isBatchMsg_Union_Sum()   {}
-func (*BatchMsg_Union_ReleaseEscrowMsg) isBatchMsg_Union_Sum()  {}
-func (*BatchMsg_Union_ReturnEscrowMsg) isBatchMsg_Union_Sum()   {}
-func (*BatchMsg_Union_UpdateEscrowMsg) isBatchMsg_Union_Sum()   {}
-func (*BatchMsg_Union_CreateContractMsg) isBatchMsg_Union_Sum() {}
-func (*BatchMsg_Union_UpdateContractMsg) isBatchMsg_Union_Sum() {}
-func (*BatchMsg_Union_SetValidatorsMsg) isBatchMsg_Union_Sum()  {}
-func (*BatchMsg_Union_NewTokenInfoMsg) isBatchMsg_Union_Sum()   {}
+func (*BatchMsg_Union_SendMsg) isBatchMsg_Union_Sum()               {}
+func (*BatchMsg_Union_CreateEscrowMsg) isBatchMsg_Union_Sum()       {}
+func (*BatchMsg_Union_ReleaseEscrowMsg) isBatchMsg_Union_Sum()      {}
+func (*BatchMsg_Union_ReturnEscrowMsg) isBatchMsg_Union_Sum()       {}
+func (*BatchMsg_Union_UpdateEscrowMsg) isBatchMsg_Union_Sum()       {}
+func (*BatchMsg_Union_CreateContractMsg) isBatchMsg_Union_Sum()     {}
+func (*BatchMsg_Union_UpdateContractMsg) isBatchMsg_Union_Sum()     {}
+func (*BatchMsg_Union_SetValidatorsMsg) isBatchMsg_Union_Sum()      {}
+func (*BatchMsg_Union_NewTokenInfoMsg) isBatchMsg_Union_Sum()       {}
+func (*BatchMsg_Union_SetConsensusParamsMsg) isBatchMsg_Union_Sum() {}
 
 func (m *BatchMsg_Union) GetSum() isBatchMsg_Union_Sum {
 	if m != nil {
@@ -776,6 +821,13 @@ func (m *BatchMsg_Union) GetNewTokenInfoMsg() *currency.NewTokenInfoMsg {
 	return nil
 }
 
+func (m *BatchMsg_Union) GetSetConsensusParamsMsg() *validators.SetConsensusParamsMsg {
+	if x, ok := m.GetSum().(*BatchMsg_Union_SetConsensusParamsMsg); ok {
+		return x.SetConsensusParamsMsg
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*BatchMsg_Union) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _BatchMsg_Union_OneofMarshaler, _BatchMsg_Union_OneofUnmarshaler, _BatchMsg_Union_OneofSizer, []interface{}{
@@ -788,6 +840,7 @@ func (*BatchMsg_Union) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer
 		(*BatchMsg_Union_UpdateContractMsg)(nil),
 		(*BatchMsg_Union_SetValidatorsMsg)(nil),
 		(*BatchMsg_Union_NewTokenInfoMsg)(nil),
+		(*BatchMsg_Union_SetConsensusParamsMsg)(nil),
 	}
 }
 
@@ -840,6 +893,11 @@ func _BatchMsg_Union_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.NewTokenInfoMsg); err != nil {
 			return err
 		}
+	case *BatchMsg_Union_SetConsensusParamsMsg:
+		_ = b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SetConsensusParamsMsg); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("BatchMsg_Union.Sum has unexpected type %T", x)
@@ -922,6 +980,14 @@ func _BatchMsg_Union_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Sum = &BatchMsg_Union_NewTokenInfoMsg{msg}
 		return true, err
+	case 12: // sum.set_consensus_params_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(validators.SetConsensusParamsMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &BatchMsg_Union_SetConsensusParamsMsg{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -976,6 +1042,11 @@ func _BatchMsg_Union_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *BatchMsg_Union_SetConsensusParamsMsg:
+		s := proto.Size(x.SetConsensusParamsMsg)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1039,12 +1110,22 @@ func (m *Tx) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], b)
 		}
 	}
+	if m.ValidUntil != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.ValidUntil.Size()))
+		n2, err := m.ValidUntil.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
 	if m.Sum != nil {
-		nn2, err := m.Sum.MarshalTo(dAtA[i:])
+		nn3, err := m.Sum.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn2
+		i += nn3
 	}
 	return i, nil
 }
@@ -1057,11 +1138,11 @@ func (m *Tx_SendMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.SendMsg.Size()))
-		n3, err := m.SendMsg.MarshalTo(dAtA[i:])
+		n4, err := m.SendMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n4
 	}
 	return i, nil
 }
@@ -1073,11 +1154,11 @@ func (m *Tx_CreateEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.CreateEscrowMsg.Size()))
-		n4, err := m.CreateEscrowMsg.MarshalTo(dAtA[i:])
+		n5, err := m.CreateEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n5
 	}
 	return i, nil
 }
@@ -1089,11 +1170,11 @@ func (m *Tx_ReleaseEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.ReleaseEscrowMsg.Size()))
-		n5, err := m.ReleaseEscrowMsg.MarshalTo(dAtA[i:])
+		n6, err := m.ReleaseEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n6
 	}
 	return i, nil
 }
@@ -1105,11 +1186,11 @@ func (m *Tx_ReturnEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.ReturnEscrowMsg.Size()))
-		n6, err := m.ReturnEscrowMsg.MarshalTo(dAtA[i:])
+		n7, err := m.ReturnEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n6
+		i += n7
 	}
 	return i, nil
 }
@@ -1121,11 +1202,11 @@ func (m *Tx_UpdateEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.UpdateEscrowMsg.Size()))
-		n7, err := m.UpdateEscrowMsg.MarshalTo(dAtA[i:])
+		n8, err := m.UpdateEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n8
 	}
 	return i, nil
 }
@@ -1137,11 +1218,11 @@ func (m *Tx_CreateContractMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.CreateContractMsg.Size()))
-		n8, err := m.CreateContractMsg.MarshalTo(dAtA[i:])
+		n9, err := m.CreateContractMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n9
 	}
 	return i, nil
 }
@@ -1153,11 +1234,11 @@ func (m *Tx_UpdateContractMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.UpdateContractMsg.Size()))
-		n9, err := m.UpdateContractMsg.MarshalTo(dAtA[i:])
+		n10, err := m.UpdateContractMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n9
+		i += n10
 	}
 	return i, nil
 }
@@ -1169,11 +1250,11 @@ func (m *Tx_SetValidatorsMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.SetValidatorsMsg.Size()))
-		n10, err := m.SetValidatorsMsg.MarshalTo(dAtA[i:])
+		n11, err := m.SetValidatorsMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n10
+		i += n11
 	}
 	return i, nil
 }
@@ -1185,11 +1266,11 @@ func (m *Tx_NewTokenInfoMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.NewTokenInfoMsg.Size()))
-		n11, err := m.NewTokenInfoMsg.MarshalTo(dAtA[i:])
+		n12, err := m.NewTokenInfoMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n12
 	}
 	return i, nil
 }
@@ -1201,11 +1282,11 @@ func (m *Tx_BatchMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.BatchMsg.Size()))
-		n12, err := m.BatchMsg.MarshalTo(dAtA[i:])
+		n13, err := m.BatchMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n13
 	}
 	return i, nil
 }
@@ -1217,11 +1298,11 @@ func (m *Tx_NewRevenueMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.NewRevenueMsg.Size()))
-		n13, err := m.NewRevenueMsg.MarshalTo(dAtA[i:])
+		n14, err := m.NewRevenueMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n14
 	}
 	return i, nil
 }
@@ -1233,11 +1314,11 @@ func (m *Tx_DistributeMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.DistributeMsg.Size()))
-		n14, err := m.DistributeMsg.MarshalTo(dAtA[i:])
+		n15, err := m.DistributeMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n14
+		i += n15
 	}
 	return i, nil
 }
@@ -1249,11 +1330,27 @@ func (m *Tx_ResetRevenueMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.ResetRevenueMsg.Size()))
-		n15, err := m.ResetRevenueMsg.MarshalTo(dAtA[i:])
+		n16, err := m.ResetRevenueMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n16
+	}
+	return i, nil
+}
+func (m *Tx_SetConsensusParamsMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.SetConsensusParamsMsg != nil {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x4
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.SetConsensusParamsMsg.Size()))
+		n17, err := m.SetConsensusParamsMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
 	}
 	return i, nil
 }
@@ -1303,11 +1400,11 @@ func (m *BatchMsg_Union) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.Sum != nil {
-		nn16, err := m.Sum.MarshalTo(dAtA[i:])
+		nn18, err := m.Sum.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn16
+		i += nn18
 	}
 	return i, nil
 }
@@ -1318,11 +1415,11 @@ func (m *BatchMsg_Union_SendMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.SendMsg.Size()))
-		n17, err := m.SendMsg.MarshalTo(dAtA[i:])
+		n19, err := m.SendMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n17
+		i += n19
 	}
 	return i, nil
 }
@@ -1332,11 +1429,11 @@ func (m *BatchMsg_Union_CreateEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.CreateEscrowMsg.Size()))
-		n18, err := m.CreateEscrowMsg.MarshalTo(dAtA[i:])
+		n20, err := m.CreateEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n18
+		i += n20
 	}
 	return i, nil
 }
@@ -1346,11 +1443,11 @@ func (m *BatchMsg_Union_ReleaseEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.ReleaseEscrowMsg.Size()))
-		n19, err := m.ReleaseEscrowMsg.MarshalTo(dAtA[i:])
+		n21, err := m.ReleaseEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n19
+		i += n21
 	}
 	return i, nil
 }
@@ -1360,11 +1457,11 @@ func (m *BatchMsg_Union_ReturnEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x32
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.ReturnEscrowMsg.Size()))
-		n20, err := m.ReturnEscrowMsg.MarshalTo(dAtA[i:])
+		n22, err := m.ReturnEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n20
+		i += n22
 	}
 	return i, nil
 }
@@ -1374,11 +1471,11 @@ func (m *BatchMsg_Union_UpdateEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3a
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.UpdateEscrowMsg.Size()))
-		n21, err := m.UpdateEscrowMsg.MarshalTo(dAtA[i:])
+		n23, err := m.UpdateEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n21
+		i += n23
 	}
 	return i, nil
 }
@@ -1388,11 +1485,11 @@ func (m *BatchMsg_Union_CreateContractMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x42
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.CreateContractMsg.Size()))
-		n22, err := m.CreateContractMsg.MarshalTo(dAtA[i:])
+		n24, err := m.CreateContractMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n22
+		i += n24
 	}
 	return i, nil
 }
@@ -1402,11 +1499,11 @@ func (m *BatchMsg_Union_UpdateContractMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4a
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.UpdateContractMsg.Size()))
-		n23, err := m.UpdateContractMsg.MarshalTo(dAtA[i:])
+		n25, err := m.UpdateContractMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n23
+		i += n25
 	}
 	return i, nil
 }
@@ -1416,11 +1513,11 @@ func (m *BatchMsg_Union_SetValidatorsMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x52
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.SetValidatorsMsg.Size()))
-		n24, err := m.SetValidatorsMsg.MarshalTo(dAtA[i:])
+		n26, err := m.SetValidatorsMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n24
+		i += n26
 	}
 	return i, nil
 }
@@ -1430,11 +1527,25 @@ func (m *BatchMsg_Union_NewTokenInfoMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.NewTokenInfoMsg.Size()))
-		n25, err := m.NewTokenInfoMsg.MarshalTo(dAtA[i:])
+		n27, err := m.NewTokenInfoMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n25
+		i += n27
+	}
+	return i, nil
+}
+func (m *BatchMsg_Union_SetConsensusParamsMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.SetConsensusParamsMsg != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.SetConsensusParamsMsg.Size()))
+		n28, err := m.SetConsensusParamsMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
 	}
 	return i, nil
 }
@@ -1470,6 +1581,10 @@ func (m *Tx) Size() (n int) {
 			n += 1 + l + sovCodec(uint64(l))
 		}
 	}
+	if m.ValidUntil != nil {
+		l = m.ValidUntil.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
 	if m.Sum != nil {
 		n += m.Sum.Size()
 	}
@@ -1593,6 +1708,15 @@ func (m *Tx_ResetRevenueMsg) Size() (n int) {
 	}
 	return n
 }
+func (m *Tx_SetConsensusParamsMsg) Size() (n int) {
+	var l int
+	_ = l
+	if m.SetConsensusParamsMsg != nil {
+		l = m.SetConsensusParamsMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
 func (m *BatchMsg) Size() (n int) {
 	var l int
 	_ = l
@@ -1695,6 +1819,15 @@ func (m *BatchMsg_Union_NewTokenInfoMsg) Size() (n int) {
 	}
 	return n
 }
+func (m *BatchMsg_Union_SetConsensusParamsMsg) Size() (n int) {
+	var l int
+	_ = l
+	if m.SetConsensusParamsMsg != nil {
+		l = m.SetConsensusParamsMsg.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
 
 func sovCodec(x uint64) (n int) {
 	for {
@@ -1862,6 +1995,39 @@ func (m *Tx) Unmarshal(dAtA []byte) error {
 			m.Multisig = append(m.Multisig, make([]byte, postIndex-iNdEx))
 			copy(m.Multisig[len(m.Multisig)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidUntil", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValidUntil == nil {
+				m.ValidUntil = &sigs.ValidUntil{}
+			}
+			if err := m.ValidUntil.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 51:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field SendMsg", wireType)
@@ -2278,6 +2444,38 @@ func (m *Tx) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Tx_ResetRevenueMsg{v}
 			iNdEx = postIndex
+		case 69:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SetConsensusParamsMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &validators.SetConsensusParamsMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Tx_SetConsensusParamsMsg{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCodec(dAtA[iNdEx:])
@@ -2697,6 +2895,38 @@ func (m *BatchMsg_Union) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &BatchMsg_Union_NewTokenInfoMsg{v}
 			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SetConsensusParamsMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &validators.SetConsensusParamsMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &BatchMsg_Union_SetConsensusParamsMsg{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCodec(dAtA[iNdEx:])
@@ -2823,53 +3053,58 @@ var (
 	ErrIntOverflowCodec   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("app/codec.proto", fileDescriptor_codec_5c05d1f53681ab0a) }
-
-var fileDescriptor_codec_5c05d1f53681ab0a = []byte{
-	// 715 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x95, 0x4f, 0x4f, 0x13, 0x41,
-	0x18, 0xc6, 0x29, 0x6d, 0xa1, 0x0c, 0x22, 0x30, 0x1c, 0xac, 0x55, 0x6b, 0xe5, 0x44, 0x54, 0xa6,
-	0x11, 0xc4, 0xff, 0xa7, 0x02, 0x06, 0x23, 0x18, 0xb3, 0x05, 0xaf, 0xcd, 0x74, 0xf7, 0xed, 0x32,
-	0x91, 0xce, 0x6c, 0x66, 0x66, 0x5b, 0xfc, 0x16, 0x9e, 0xfc, 0x10, 0x7e, 0x12, 0x8e, 0x9e, 0x3d,
-	0x18, 0x83, 0x5f, 0xc4, 0xcc, 0xec, 0x6e, 0xd9, 0x69, 0x4c, 0xa3, 0xa6, 0xb7, 0x9d, 0xe7, 0x7d,
-	0x9e, 0x5f, 0xdf, 0xbe, 0x3b, 0x6f, 0x8b, 0x96, 0x69, 0x14, 0x35, 0x7d, 0x11, 0x80, 0x4f, 0x22,
-	0x29, 0xb4, 0xc0, 0x45, 0x1a, 0x45, 0xb5, 0xcd, 0x90, 0xe9, 0xd3, 0xb8, 0x4b, 0x7c, 0xd1, 0x6f,
-	0x86, 0x22, 0x14, 0x4d, 0x5b, 0xeb, 0xc6, 0x3d, 0x7b, 0xb2, 0x07, 0xfb, 0x94, 0x64, 0x6a, 0x0f,
-	0x72, 0x76, 0x26, 0x06, 0x9b, 0x82, 0x43, 0x73, 0x08, 0x74, 0x00, 0xcd, 0xf3, 0xa6, 0x4f, 0xd5,
-	0x69, 0xfe, 0x03, 0x6a, 0xcd, 0x49, 0xe6, 0x58, 0x4a, 0xe0, 0xfe, 0x27, 0x27, 0xb0, 0x39, 0x21,
-	0x00, 0xca, 0x97, 0x62, 0xf8, 0xd7, 0xfc, 0x7e, 0x7c, 0xa6, 0x99, 0x62, 0xa1, 0x13, 0x98, 0xd4,
-	0xbd, 0x62, 0xa1, 0x72, 0xcc, 0x8f, 0x26, 0x98, 0x07, 0xf4, 0x8c, 0x05, 0x54, 0x0b, 0xe9, 0x46,
-	0xb6, 0x27, 0x44, 0x02, 0xa6, 0xb4, 0x64, 0xdd, 0x58, 0x33, 0xc1, 0xf3, 0xa1, 0xf5, 0xaf, 0x15,
-	0x34, 0x7b, 0x7c, 0x8e, 0xef, 0xa1, 0x52, 0x0f, 0x40, 0x55, 0x0b, 0x8d, 0xc2, 0xc6, 0xe2, 0xd6,
-	0x12, 0x31, 0xd3, 0x24, 0xaf, 0x01, 0xde, 0xf0, 0x9e, 0xf0, 0x6c, 0x09, 0x6f, 0x21, 0xa4, 0x58,
-	0xc8, 0xa9, 0x8e, 0x25, 0xa8, 0xea, 0x6c, 0xa3, 0xb8, 0xb1, 0xb8, 0x85, 0x89, 0x69, 0x9c, 0xb4,
-	0x75, 0xd0, 0xce, 0x4a, 0x5e, 0xce, 0x85, 0x6b, 0xa8, 0x12, 0x49, 0x60, 0x7d, 0x1a, 0x42, 0xb5,
-	0xd8, 0x28, 0x6c, 0x5c, 0xf3, 0x46, 0x67, 0x53, 0xcb, 0xc6, 0x54, 0x2d, 0x35, 0x8a, 0xa6, 0x96,
-	0x9d, 0xf1, 0x7d, 0x54, 0x51, 0xc0, 0x83, 0x4e, 0x5f, 0x85, 0xd5, 0xed, 0x7c, 0x4b, 0x6d, 0xe0,
-	0xc1, 0x91, 0x0a, 0x0f, 0x66, 0xbc, 0x79, 0x95, 0x3c, 0xe2, 0x7d, 0xb4, 0xea, 0x4b, 0xa0, 0x1a,
-	0x3a, 0xc9, 0x4b, 0xb2, 0xa1, 0xc7, 0x36, 0x74, 0x83, 0x24, 0x12, 0xd9, 0xb5, 0x86, 0x7d, 0x7b,
-	0x48, 0xe2, 0xcb, 0xbe, 0x2b, 0xe1, 0x03, 0x84, 0x25, 0x9c, 0x01, 0x55, 0x0e, 0x67, 0xc7, 0x72,
-	0xaa, 0x19, 0xc7, 0x4b, 0x1c, 0x79, 0xd0, 0x8a, 0x1c, 0xd3, 0x4c, 0x43, 0x12, 0x74, 0x2c, 0x79,
-	0x1e, 0xf4, 0xc4, 0x6d, 0xc8, 0xb3, 0x06, 0xa7, 0x21, 0xe9, 0x4a, 0xf8, 0x10, 0xad, 0xc6, 0x51,
-	0x30, 0xf6, 0xbd, 0x9e, 0x5a, 0x4c, 0x3d, 0xc3, 0x9c, 0x58, 0x43, 0x92, 0x79, 0x4f, 0xa5, 0x66,
-	0xa0, 0x52, 0x5a, 0x9c, 0xab, 0x18, 0xda, 0x11, 0x5a, 0x4b, 0xa7, 0xe4, 0x0b, 0xae, 0x25, 0xf5,
-	0xb5, 0xe5, 0x3d, 0xb3, 0xbc, 0x5b, 0x24, 0x9b, 0x7c, 0x3a, 0xa9, 0xdd, 0xd4, 0x93, 0xc0, 0xd2,
-	0xf9, 0xe6, 0x44, 0x83, 0x4b, 0x9b, 0x73, 0x70, 0xcf, 0xc7, 0x71, 0x49, 0x83, 0x63, 0xb8, 0x78,
-	0x5c, 0xc4, 0x87, 0x08, 0x2b, 0xd0, 0x9d, 0xab, 0x8b, 0x6d, 0x69, 0x2f, 0x2c, 0xed, 0x36, 0xb9,
-	0x92, 0x49, 0x1b, 0xf4, 0x87, 0xd1, 0x29, 0x7d, 0x01, 0x6a, 0x4c, 0x33, 0xaf, 0x92, 0xc3, 0xb0,
-	0xa3, 0xc5, 0x47, 0xe0, 0x1d, 0xc6, 0x7b, 0xc2, 0xd2, 0x5e, 0x5a, 0xda, 0x4d, 0x92, 0xed, 0x3e,
-	0x79, 0x07, 0xc3, 0x63, 0x63, 0x31, 0x77, 0x3c, 0x9d, 0x1a, 0x77, 0x25, 0xfc, 0x10, 0x2d, 0x74,
-	0xa9, 0xf6, 0x4f, 0x2d, 0xe0, 0x55, 0x7a, 0x11, 0x69, 0x14, 0x91, 0x96, 0x51, 0x93, 0x50, 0xa5,
-	0x9b, 0x3e, 0xe3, 0x7d, 0x64, 0x00, 0x1d, 0x09, 0x03, 0xe0, 0x31, 0xd8, 0x4c, 0x2b, 0x1d, 0x48,
-	0x7e, 0xff, 0xcc, 0x07, 0x7b, 0x89, 0x27, 0x21, 0x2c, 0xf1, 0xbc, 0x80, 0xf7, 0xd0, 0xf5, 0x91,
-	0x3d, 0xa1, 0xec, 0xfe, 0x89, 0xb2, 0x37, 0xf2, 0xa4, 0x94, 0x20, 0x2f, 0xe0, 0xb7, 0xe6, 0x16,
-	0x9a, 0xa1, 0xe6, 0xdb, 0xd9, 0xb3, 0xa0, 0x3b, 0x2e, 0xc8, 0x33, 0x36, 0xa7, 0xa1, 0x65, 0xe9,
-	0x4a, 0xad, 0x32, 0x2a, 0xaa, 0xb8, 0xbf, 0xfe, 0xbd, 0x8c, 0x2a, 0xd9, 0x37, 0xc7, 0x3b, 0xa8,
-	0xd2, 0x07, 0xa5, 0x68, 0x68, 0x7f, 0x36, 0xcc, 0xaf, 0xc1, 0x9a, 0x33, 0x1a, 0x72, 0xc2, 0x99,
-	0xe0, 0xad, 0xd2, 0xc5, 0x8f, 0xbb, 0x33, 0xde, 0xc8, 0x5a, 0xfb, 0x52, 0x46, 0x65, 0x5b, 0x71,
-	0x96, 0xbc, 0xf0, 0x3f, 0x4b, 0x5e, 0x9a, 0xd2, 0x92, 0x97, 0xa7, 0xb5, 0xe4, 0x73, 0xd3, 0x59,
-	0xf2, 0xf9, 0x29, 0x2f, 0x79, 0x65, 0xba, 0x4b, 0xbe, 0x30, 0xd5, 0x25, 0x47, 0x53, 0x5d, 0xf2,
-	0xc5, 0x7f, 0x5f, 0xf2, 0xf4, 0x72, 0xb7, 0x56, 0x2e, 0x2e, 0xeb, 0x85, 0x6f, 0x97, 0xf5, 0xc2,
-	0xcf, 0xcb, 0x7a, 0xe1, 0xf3, 0xaf, 0xfa, 0x4c, 0x77, 0xce, 0xfe, 0x45, 0x6e, 0xff, 0x0e, 0x00,
-	0x00, 0xff, 0xff, 0x5a, 0x41, 0x00, 0xb5, 0xbc, 0x08, 0x00, 0x00,
+func init() { proto.RegisterFile("cmd/bcpd/app/codec.proto", fileDescriptor_codec_c3f96b5f78f6cecd) }
+
+var fileDescriptor_codec_c3f96b5f78f6cecd = []byte{
+	// 793 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x96, 0xdf, 0x4e, 0xf3, 0x36,
+	0x18, 0xc6, 0x29, 0x6d, 0xa1, 0xb8, 0x30, 0xc0, 0x68, 0x5a, 0xd6, 0x6d, 0x5d, 0xe1, 0x08, 0x6d,
+	0x23, 0x11, 0x30, 0xf6, 0xff, 0xa8, 0xa5, 0x13, 0xd3, 0x60, 0x42, 0x29, 0x70, 0x34, 0x29, 0x72,
+	0x13, 0x37, 0x44, 0x6b, 0xec, 0xc8, 0x76, 0x5a, 0x76, 0x17, 0xbb, 0x9c, 0x49, 0xbb, 0x01, 0x0e,
+	0x77, 0x05, 0xd3, 0xc4, 0x77, 0x0b, 0xdf, 0x05, 0x7c, 0xb2, 0x9d, 0x94, 0x38, 0x42, 0xd5, 0x27,
+	0x94, 0x33, 0xfb, 0x79, 0x9f, 0xf7, 0x97, 0xd7, 0xaf, 0xeb, 0x57, 0x05, 0x96, 0x1f, 0x07, 0xce,
+	0xd8, 0x4f, 0x02, 0x07, 0x25, 0x89, 0xe3, 0xd3, 0x00, 0xfb, 0x76, 0xc2, 0xa8, 0xa0, 0xb0, 0x8e,
+	0x92, 0xa4, 0x73, 0x14, 0x46, 0xe2, 0x3e, 0x1d, 0xdb, 0x3e, 0x8d, 0x9d, 0x90, 0x86, 0xd4, 0x51,
+	0xb1, 0x71, 0x3a, 0x51, 0x3b, 0xb5, 0x51, 0x2b, 0x9d, 0xd3, 0xf9, 0xb2, 0x60, 0x8f, 0xe8, 0xec,
+	0x88, 0x12, 0xec, 0xcc, 0x31, 0x9a, 0x61, 0xe7, 0xc1, 0xf1, 0x11, 0xbf, 0x2f, 0x7e, 0xa0, 0xe3,
+	0x2c, 0x33, 0xa7, 0x8c, 0x61, 0xe2, 0xff, 0x69, 0x24, 0x1c, 0x2d, 0x49, 0xc0, 0xdc, 0x67, 0x74,
+	0xfe, 0xde, 0xfc, 0x38, 0x9d, 0x8a, 0x88, 0x47, 0xa1, 0x91, 0xb0, 0xac, 0x7a, 0x1e, 0x85, 0xdc,
+	0x30, 0x1f, 0x2f, 0x31, 0xcf, 0xd0, 0x34, 0x0a, 0x90, 0xa0, 0xcc, 0x4c, 0x39, 0x5d, 0x92, 0x12,
+	0x44, 0x5c, 0xb0, 0x68, 0x9c, 0x8a, 0x88, 0x92, 0x62, 0xd2, 0xc1, 0x3f, 0x1b, 0x60, 0xf5, 0xe6,
+	0x01, 0xee, 0x83, 0xc6, 0x04, 0x63, 0x6e, 0xd5, 0x7a, 0xb5, 0xc3, 0xf6, 0xc9, 0x96, 0x2d, 0xbb,
+	0x69, 0xff, 0x8c, 0xf1, 0x2f, 0x64, 0x42, 0x5d, 0x15, 0x82, 0x27, 0x00, 0xf0, 0x28, 0x24, 0x48,
+	0xa4, 0x0c, 0x73, 0x6b, 0xb5, 0x57, 0x3f, 0x6c, 0x9f, 0x40, 0x5b, 0x16, 0x6e, 0x8f, 0x44, 0x30,
+	0xca, 0x43, 0x6e, 0xc1, 0x05, 0x3b, 0xa0, 0x95, 0x30, 0x1c, 0xc5, 0x28, 0xc4, 0x56, 0xbd, 0x57,
+	0x3b, 0xdc, 0x74, 0x17, 0x7b, 0x19, 0xcb, 0xdb, 0x64, 0x35, 0x7a, 0x75, 0x19, 0xcb, 0xf7, 0xf0,
+	0x18, 0xb4, 0xd5, 0x21, 0xbd, 0x94, 0x88, 0x68, 0x6a, 0x35, 0x55, 0x55, 0x3b, 0xfa, 0x63, 0x77,
+	0x32, 0x70, 0x2b, 0x75, 0x17, 0xcc, 0x16, 0x6b, 0xf8, 0x05, 0x68, 0x71, 0x4c, 0x02, 0x2f, 0xe6,
+	0xa1, 0x75, 0x5a, 0x3c, 0xc5, 0x08, 0x93, 0xe0, 0x8a, 0x87, 0x17, 0x2b, 0xee, 0x3a, 0xd7, 0x4b,
+	0x38, 0x04, 0xbb, 0x3e, 0xc3, 0x48, 0x60, 0x4f, 0xdf, 0xab, 0x4a, 0xfa, 0x5a, 0x25, 0x7d, 0x64,
+	0x6b, 0xc9, 0x1e, 0x28, 0xc3, 0x50, 0x6d, 0x74, 0xfa, 0xb6, 0x6f, 0x4a, 0xf0, 0x02, 0x40, 0x86,
+	0xa7, 0x18, 0x71, 0x83, 0x73, 0xa6, 0x38, 0x56, 0xce, 0x71, 0xb5, 0xa3, 0x08, 0xda, 0x61, 0x25,
+	0x4d, 0x16, 0xc4, 0xb0, 0x48, 0x19, 0x29, 0x82, 0xbe, 0x31, 0x0b, 0x72, 0x95, 0xc1, 0x28, 0x88,
+	0x99, 0x12, 0xbc, 0x04, 0xbb, 0x69, 0x12, 0x94, 0xce, 0xf5, 0xad, 0xc2, 0x74, 0x73, 0xcc, 0xad,
+	0x32, 0xe8, 0x9c, 0x6b, 0xc4, 0x44, 0x84, 0x79, 0x46, 0x4b, 0x0b, 0x11, 0x49, 0xbb, 0x02, 0x7b,
+	0x59, 0x97, 0x7c, 0x4a, 0x04, 0x43, 0xbe, 0x50, 0xbc, 0xef, 0x14, 0xef, 0x13, 0x3b, 0xbf, 0xac,
+	0xac, 0x53, 0x83, 0xcc, 0xa3, 0x61, 0xbb, 0x7e, 0x59, 0x94, 0xb8, 0xac, 0x38, 0x03, 0xf7, 0x7d,
+	0x19, 0xa7, 0x0b, 0x2c, 0xe1, 0xd2, 0xb2, 0x08, 0x2f, 0x01, 0xe4, 0x58, 0x78, 0xcf, 0x6f, 0x41,
+	0xd1, 0x7e, 0x50, 0xb4, 0x4f, 0xed, 0x67, 0xd9, 0x1e, 0x61, 0x71, 0xb7, 0xd8, 0x65, 0x17, 0xc0,
+	0x4b, 0x9a, 0xbc, 0x4a, 0x82, 0xe7, 0x9e, 0xa0, 0x7f, 0x60, 0xe2, 0x45, 0x64, 0x42, 0x15, 0xed,
+	0x47, 0x45, 0xfb, 0xd8, 0xce, 0xc7, 0x85, 0xfd, 0x1b, 0x9e, 0xdf, 0x48, 0x8b, 0x7c, 0x16, 0x59,
+	0xd7, 0x88, 0x29, 0xc1, 0xaf, 0xc0, 0xc6, 0x18, 0x09, 0xff, 0x5e, 0x01, 0x7e, 0xca, 0x7e, 0x88,
+	0x28, 0x49, 0xec, 0xbe, 0x54, 0x75, 0x52, 0x6b, 0x9c, 0xad, 0xe1, 0x10, 0x48, 0x80, 0xc7, 0xf0,
+	0x0c, 0x93, 0x14, 0xab, 0x9c, 0x7e, 0xd6, 0x90, 0xe2, 0x93, 0x95, 0x1f, 0x76, 0xb5, 0x47, 0x13,
+	0xb6, 0x48, 0x51, 0x80, 0xe7, 0xe0, 0x83, 0x85, 0x5d, 0x53, 0x06, 0x2f, 0x51, 0xce, 0x17, 0x9e,
+	0x8c, 0x12, 0x14, 0x05, 0xf8, 0xab, 0xfc, 0x15, 0xca, 0xa6, 0x16, 0xcb, 0x39, 0x57, 0xa0, 0xcf,
+	0x4c, 0x90, 0x2b, 0x6d, 0x46, 0x41, 0xdb, 0xcc, 0x94, 0xe0, 0xef, 0xc0, 0x92, 0x28, 0x9f, 0x12,
+	0x8e, 0x09, 0x4f, 0xb9, 0x97, 0x20, 0x86, 0x62, 0x7d, 0x4b, 0x43, 0xc5, 0xdc, 0x2f, 0xdd, 0xd2,
+	0x20, 0xb7, 0x5e, 0x2b, 0xa7, 0xe6, 0x7e, 0xc8, 0x5f, 0x0a, 0xf4, 0x9b, 0xa0, 0xce, 0xd3, 0xf8,
+	0xe0, 0xef, 0x35, 0xd0, 0xca, 0xfb, 0x0a, 0xcf, 0x40, 0x2b, 0xc6, 0x9c, 0xa3, 0x50, 0xcd, 0x31,
+	0x39, 0x9e, 0xf6, 0x8c, 0xc6, 0xdb, 0xb7, 0x24, 0xa2, 0xa4, 0xdf, 0x78, 0xfc, 0xef, 0xf3, 0x15,
+	0x77, 0x61, 0xed, 0xbc, 0x6d, 0x82, 0xa6, 0x8a, 0x18, 0x23, 0xa4, 0xf6, 0x9a, 0x11, 0xd2, 0xa8,
+	0x68, 0x84, 0x34, 0xab, 0x1a, 0x21, 0x6b, 0xd5, 0x8c, 0x90, 0xf5, 0x8a, 0x47, 0x48, 0xab, 0xda,
+	0x11, 0xb2, 0x51, 0xe9, 0x08, 0x01, 0x95, 0x8e, 0x90, 0xf6, 0x2b, 0x46, 0xc8, 0xb2, 0xa7, 0xb3,
+	0x59, 0xd1, 0xd3, 0xe9, 0xef, 0x3c, 0x3e, 0x75, 0x6b, 0xff, 0x3e, 0x75, 0x6b, 0xff, 0x3f, 0x75,
+	0x6b, 0x7f, 0xbd, 0xe9, 0xae, 0x8c, 0xd7, 0xd4, 0x3f, 0x82, 0xd3, 0x77, 0x01, 0x00, 0x00, 0xff,
+	0xff, 0x5c, 0xac, 0x93, 0xec, 0xb4, 0x09, 0x00, 0x00,
 }
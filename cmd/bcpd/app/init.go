@@ -14,6 +14,7 @@ import (
 	"github.com/iov-one/weave/x/cash"
 	"github.com/iov-one/weave/x/currency"
 	"github.com/iov-one/weave/x/distribution"
+	"github.com/iov-one/weave/x/escrow"
 	"github.com/iov-one/weave/x/multisig"
 	"github.com/iov-one/weave/x/validators"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -89,13 +90,50 @@ func GenerateApp(home string, logger log.Logger, debug bool) (abci.Application,
 		&currency.Initializer{},
 		&validators.Initializer{},
 		&distribution.Initializer{},
+		&escrow.Initializer{},
 	))
+	application.WithModels(Models())
+	application.WithQueryLimit(app.DefaultQueryLimit)
+
+	// node-local settings, changed at runtime via SetOption
+	levels := app.NewLevelLogger(logger)
+	options := weave.NewOptionRouter()
+	options.RegisterAll(
+		application.RegisterOptions,
+		levels.RegisterOptions,
+		CheckMinFee.RegisterOptions,
+	)
+	application.WithOptions(options)
+
+	if home != "" {
+		sink, err := app.OpenFileSink(home)
+		if err != nil {
+			return nil, err
+		}
+		if sink != nil {
+			application.WithCommitListener(sink)
+		}
+	}
 
 	// set the logger and return
-	application.WithLogger(logger)
+	application.WithLogger(levels)
 	return application, nil
 }
 
+// Exporter returns the counterpart of the initializers set by GenerateApp,
+// writing the application state in the genesis format
+func Exporter() weave.Exporter {
+	return app.ChainExporters(
+		&gconf.Initializer{},
+		&multisig.Initializer{},
+		&cash.Initializer{},
+		&currency.Initializer{},
+		&validators.Initializer{},
+		&distribution.Initializer{},
+		&escrow.Initializer{},
+	)
+}
+
 type output struct {
 	Pubkey *crypto.PublicKey  `json:"pub_key"`
 	Secret *crypto.PrivateKey `json:"secret"`
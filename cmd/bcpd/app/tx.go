@@ -27,6 +27,7 @@ func TxDecoder(bz []byte) (weave.Tx, error) {
 var _ weave.Tx = (*Tx)(nil)
 var _ cash.FeeTx = (*Tx)(nil)
 var _ sigs.SignedTx = (*Tx)(nil)
+var _ sigs.ExpiringTx = (*Tx)(nil)
 var _ hashlock.HashKeyTx = (*Tx)(nil)
 var _ multisig.MultiSigTx = (*Tx)(nil)
 
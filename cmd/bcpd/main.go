@@ -27,12 +27,15 @@ func init() {
 
 func helpMessage() {
 	fmt.Println("bcp")
-	fmt.Println("        Blockchain of Value node")
+	fmt.Println("          Blockchain of Value node")
 	fmt.Println("")
-	fmt.Println("help    Print this message")
-	fmt.Println("init    Initialize app options in genesis file")
-	fmt.Println("start   Run the abci server")
-	fmt.Println("version Print the app version")
+	fmt.Println("help      Print this message")
+	fmt.Println("init      Initialize app options in genesis file")
+	fmt.Println("start     Run the abci server")
+	fmt.Println("export    Write the app state of a stopped node as genesis json")
+	fmt.Println("inspect   Print the buckets, keys and values of a stopped node")
+	fmt.Println("rollback  Delete the app state above a height, to replay those blocks")
+	fmt.Println("version   Print the app version")
 	fmt.Println(`
   -home string
         directory to store files under (default "$HOME/.bcp")`)
@@ -60,6 +63,12 @@ func main() {
 		err = server.InitCmd(app.GenInitOptions, logger, *varHome, rest)
 	case "start":
 		err = server.StartCmd(app.GenerateApp, logger, *varHome, rest)
+	case "export":
+		err = server.ExportCmd(app.Exporter(), logger, *varHome, rest)
+	case "inspect":
+		err = server.InspectCmd(app.Models(), logger, *varHome, rest)
+	case "rollback":
+		err = server.RollbackCmd(logger, *varHome, rest)
 	case "testgen":
 		err = commands.TestGenCmd(app.Examples(), rest)
 	case "version":
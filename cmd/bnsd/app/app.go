@@ -6,17 +6,16 @@ package app
 
 import (
 	"context"
-	"fmt"
-	"path/filepath"
-	"strings"
 
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/app"
 	"github.com/iov-one/weave/cmd/bnsd/x/nft/username"
+	"github.com/iov-one/weave/migration"
 	"github.com/iov-one/weave/orm"
 	"github.com/iov-one/weave/store/iavl"
 	"github.com/iov-one/weave/x"
 	"github.com/iov-one/weave/x/cash"
+	"github.com/iov-one/weave/x/cron"
 	"github.com/iov-one/weave/x/currency"
 	"github.com/iov-one/weave/x/distribution"
 	"github.com/iov-one/weave/x/escrow"
@@ -25,16 +24,25 @@ import (
 	"github.com/iov-one/weave/x/nft"
 	"github.com/iov-one/weave/x/nft/base"
 	"github.com/iov-one/weave/x/sigs"
+	"github.com/iov-one/weave/x/slashing"
 	"github.com/iov-one/weave/x/utils"
 	"github.com/iov-one/weave/x/validators"
 )
 
 // Authenticator returns the typical authentication,
-// just using public key signatures
+// just using public key signatures, and the authority
+// of scheduled tasks
 func Authenticator() x.Authenticator {
-	return x.ChainAuth(sigs.Authenticate{}, hashlock.Authenticate{}, multisig.Authenticate{})
+	return x.ChainAuth(sigs.Authenticate{}, hashlock.Authenticate{}, multisig.Authenticate{}, cron.Authenticate{})
 }
 
+// TxGasLimit is the maximum gas a single transaction may consume
+const TxGasLimit = 1000000
+
+// CheckMinFee is the node-local minimal fee enforced on CheckTx,
+// it is set at runtime with the "cash.check_min_fee" option
+var CheckMinFee = cash.NewCheckMinFee()
+
 // Chain returns a chain of decorators, to handle authentication,
 // fees, logging, and recovery
 func Chain(authFn x.Authenticator) app.Decorators {
@@ -45,14 +53,24 @@ func Chain(authFn x.Authenticator) app.Decorators {
 	return app.ChainDecorators(
 		utils.NewLogging(),
 		utils.NewRecovery(),
+		utils.NewGasLimiter(TxGasLimit),
 		utils.NewKeyTagger(),
 		// on CheckTx, bad tx don't affect state
 		utils.NewSavepoint().OnCheck(),
+		// reject expired txs before verifying their signatures
+		sigs.NewExpiryDecorator(),
 		sigs.NewDecorator(),
 		multisig.NewDecorator(authFn),
-		cash.NewDynamicFeeDecorator(authFn, ctrl),
+		// prefer txs paying a higher fee per byte in the mempool,
+		// and refuse txs paying less than the node-local minimal fee
+		cash.NewDynamicFeeDecorator(authFn, ctrl).
+			WithPriority(cash.PriorityConfig{}).
+			WithCheckMinFee(CheckMinFee),
 		// cannot pay for fee with hashlock...
 		hashlock.NewDecorator(),
+		// on DeliverTx, bad tx will increment nonce and take fee
+		// even if the message fails
+		utils.NewSavepoint().OnDeliver(),
 		// batch commented out temporarily to minimize release features
 		// make sure we execute all the transactions in batch before the save point
 		//batch.NewDecorator(),
@@ -83,7 +101,7 @@ func Router(authFn x.Authenticator, issuer weave.Address, nftBuckets map[string]
 
 // QueryRouter returns a default query router,
 // allowing access to "/wallets", "/auth", "/", "/escrows", "/nft/usernames",
-// "/nft/blockchains", "/nft/tickers", "/validators"
+// "/nft/blockchains", "/nft/tickers", "/validators", "/crontasks", "/cronresults"
 func QueryRouter() weave.QueryRouter {
 	r := weave.NewQueryRouter()
 
@@ -97,10 +115,47 @@ func QueryRouter() weave.QueryRouter {
 		orm.RegisterQuery,
 		currency.RegisterQuery,
 		distribution.RegisterQuery,
+		slashing.RegisterQuery,
+		cron.RegisterQuery,
 	)
 	return r
 }
 
+// Models returns the models stored by all extensions,
+// so query results can be rendered as JSON
+func Models() orm.ModelRegistry {
+	r := orm.NewModelRegistry()
+	escrow.NewBucket().RegisterModel(r)
+	cash.NewBucket().RegisterModel(r)
+	sigs.NewBucket().RegisterModel(r)
+	multisig.NewContractBucket().RegisterModel(r)
+	username.NewBucket().RegisterModel(r)
+	validators.NewBucket().RegisterModel(r)
+	currency.NewTokenInfoBucket().RegisterModel(r)
+	distribution.NewRevenueBucket().RegisterModel(r)
+	slashing.NewValidatorBucket().RegisterModel(r)
+	slashing.NewEvidenceBucket().RegisterModel(r)
+	cron.NewTaskBucket().RegisterModel(r)
+	cron.NewResultBucket().RegisterModel(r)
+	return r
+}
+
+// Ticker returns the tasks executed at the beginning of every block,
+// the transactions scheduled with x/cron are delivered to the handler
+func Ticker(h weave.Handler) weave.Ticker {
+	return app.ChainTickers(
+		slashing.NewTicker(cash.NewController(cash.NewBucket())),
+		cron.NewTicker(h, TxDecoder),
+	)
+}
+
+// Migrations returns the schema migrations of all extensions.
+// When a model changes, register the function converting its stored data here,
+// at the height agreed on for the upgrade.
+func Migrations() *migration.Registry {
+	return migration.NewRegistry()
+}
+
 // Register nft types and actions for shared action handling via base handler
 func RegisterNft() {
 	// Default nft actions.
@@ -114,10 +169,20 @@ func Stack(issuer weave.Address, nftBuckets map[string]orm.Bucket) weave.Handler
 	return Chain(authFn).WithHandler(Router(authFn, issuer, nftBuckets))
 }
 
+// CronStack wires up the standard router for the transactions
+// scheduled with x/cron. They are neither signed nor pay fees,
+// so the decorators checking that are left out.
+func CronStack(issuer weave.Address, nftBuckets map[string]orm.Bucket) weave.Handler {
+	authFn := Authenticator()
+	return app.ChainDecorators(
+		utils.NewLogging(),
+	).WithHandler(Router(authFn, issuer, nftBuckets))
+}
+
 // Application constructs a basic ABCI application with
 // the given arguments. If you are not sure what to use
-// for the Handler, just use Stack().
-func Application(name string, h weave.Handler,
+// for the Handlers, just use Stack() and CronStack().
+func Application(name string, h, cronHandler weave.Handler,
 	tx weave.TxDecoder, dbPath string, debug bool) (app.BaseApp, error) {
 
 	ctx := context.Background()
@@ -127,29 +192,19 @@ func Application(name string, h weave.Handler,
 	}
 	RegisterNft()
 	store := app.NewStoreApp(name, kv, QueryRouter(), ctx)
-	base := app.NewBaseApp(store, tx, h, nil, debug)
+	base := app.NewBaseApp(store, tx, h, Ticker(cronHandler), nil, debug)
 	return base, nil
 }
 
 // CommitKVStore returns an initialized KVStore that persists
-// the data to the named path.
+// the data to the named path. The database is configured by the
+// iavl.OptionsFile in the same directory, if there is one, and it is
+// a multi store if the options mount any substore.
 func CommitKVStore(dbPath string) (weave.CommitKVStore, error) {
 	// memory backed case, just for testing
 	if dbPath == "" {
 		return iavl.MockCommitStore(), nil
 	}
 
-	// Expand the path fully
-	path, err := filepath.Abs(dbPath)
-	if err != nil {
-		return nil, fmt.Errorf("Invalid Database Name: %s", path)
-	}
-
-	// Some external calls accidently add a ".db", which is now removed
-	path = strings.TrimSuffix(path, filepath.Ext(path))
-
-	// Split the database name into it's components (dir, name)
-	dir := filepath.Dir(path)
-	name := filepath.Base(path)
-	return iavl.NewCommitStore(dir, name), nil
+	return iavl.OpenStore(dbPath)
 }
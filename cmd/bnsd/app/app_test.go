@@ -2,10 +2,12 @@ package app_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"sort"
 	"strings"
 	"testing"
 
+	"github.com/iov-one/weave"
 	weaveApp "github.com/iov-one/weave/app"
 	"github.com/iov-one/weave/cmd/bnsd/app"
 	"github.com/iov-one/weave/cmd/bnsd/app/testdata/fixtures"
@@ -39,8 +41,9 @@ func TestApp(t *testing.T) {
 	addr2 := pk2.PublicKey().Address()
 	dres := sendToken(t, myApp, appFixture.ChainID, 2, []Signer{{pk, 0}}, addr, addr2, 2000, "ETH", "Have a great trip!")
 
-	// ensure 3 keys with proper values
-	if assert.Equal(t, 3, len(dres.Tags), "%#v", dres.Tags) {
+	// ensure 3 keys with proper values, followed by the transfer event
+	transfer := cash.TransferEvent(addr, addr2, coin.NewCoin(2000, 0, "ETH")).Tags()
+	if assert.Equal(t, 3+len(transfer), len(dres.Tags), "%#v", dres.Tags) {
 		wantKeys := []string{
 			toHex("cash:") + addr.String(),
 			toHex("cash:") + addr2.String(),
@@ -59,6 +62,7 @@ func TestApp(t *testing.T) {
 			string(dres.Tags[1].Value),
 			string(dres.Tags[2].Value),
 		})
+		assert.Equal(t, transfer, dres.Tags[3:])
 	}
 
 	// Query for new balances (same query, new state)
@@ -92,6 +96,14 @@ func TestApp(t *testing.T) {
 		},
 	})
 
+	// the same wallet can be rendered as json
+	jres := myApp.Query(abci.RequestQuery{Path: "/wallets?json", Data: addr2})
+	require.Equal(t, uint32(0), jres.Code, jres.Log)
+	var wallets []weaveApp.JSONResult
+	require.NoError(t, json.Unmarshal(jres.Value, &wallets))
+	require.Len(t, wallets, 1)
+	assert.Contains(t, string(wallets[0].Value), "ETH")
+
 	// create recoveryContract
 	recovery1 := crypto.GenPrivKeyEd25519()
 	recovery2 := crypto.GenPrivKeyEd25519()
@@ -156,7 +168,7 @@ func sendToken(t *testing.T, baseApp weaveApp.BaseApp, chainID string, height in
 // createContract creates an immutable contract, signs the transaction and sends it
 // checks contract has been created correctly
 func createContract(t *testing.T, baseApp weaveApp.BaseApp, chainID string, height int64, signers []Signer,
-	activationThreshold int64, contractSigs ...[]byte) []byte {
+	activationThreshold int64, contractSigs ...weave.Address) []byte {
 	msg := &multisig.CreateContractMsg{
 		Sigs:                contractSigs,
 		ActivationThreshold: activationThreshold,
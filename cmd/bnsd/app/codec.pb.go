@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: app/codec.proto
+// source: cmd/bnsd/app/codec.proto
 
 package app
 
@@ -33,12 +33,12 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 // Tx contains the message.
 //
 // When extending Tx, follow the rules:
-// - range 1-50 is reserved for middlewares,
-// - range 51-inf is reserved for different message types,
-// - keep the same numbers for the same message types in both bcpd and bnsd
-//   applications. For example, FeeInfo field is used by both and indexed at
-//   first position. Skip unused fields (leave index unused or comment out for
-//   clarity).
+//   - range 1-50 is reserved for middlewares,
+//   - range 51-inf is reserved for different message types,
+//   - keep the same numbers for the same message types in both bcpd and bnsd
+//     applications. For example, FeeInfo field is used by both and indexed at
+//     first position. Skip unused fields (leave index unused or comment out for
+//     clarity).
 type Tx struct {
 	Fees       *cash.FeeInfo        `protobuf:"bytes,1,opt,name=fees" json:"fees,omitempty"`
 	Signatures []*sigs.StdSignature `protobuf:"bytes,2,rep,name=signatures" json:"signatures,omitempty"`
@@ -46,6 +46,8 @@ type Tx struct {
 	Preimage []byte `protobuf:"bytes,3,opt,name=preimage,proto3" json:"preimage,omitempty"`
 	// ID of a multisig contract.
 	Multisig [][]byte `protobuf:"bytes,4,rep,name=multisig" json:"multisig,omitempty"`
+	// Optional expiration, the transaction is rejected once it passed.
+	ValidUntil *sigs.ValidUntil `protobuf:"bytes,5,opt,name=valid_until,json=validUntil" json:"valid_until,omitempty"`
 	// msg is a sum type over all allowed messages on this chain.
 	//
 	// Types that are valid to be assigned to Sum:
@@ -66,6 +68,7 @@ type Tx struct {
 	//	*Tx_NewRevenueMsg
 	//	*Tx_DistributeMsg
 	//	*Tx_ResetRevenueMsg
+	//	*Tx_SetConsensusParamsMsg
 	Sum                  isTx_Sum `protobuf_oneof:"sum"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -75,7 +78,7 @@ func (m *Tx) Reset()         { *m = Tx{} }
 func (m *Tx) String() string { return proto.CompactTextString(m) }
 func (*Tx) ProtoMessage()    {}
 func (*Tx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_b8bc71b719c27f15, []int{0}
+	return fileDescriptor_codec_d7fd68e03dded43a, []int{0}
 }
 func (m *Tx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -161,6 +164,9 @@ type Tx_DistributeMsg struct {
 type Tx_ResetRevenueMsg struct {
 	ResetRevenueMsg *distribution.ResetRevenueMsg `protobuf:"bytes,68,opt,name=reset_revenue_msg,json=resetRevenueMsg,oneof"`
 }
+type Tx_SetConsensusParamsMsg struct {
+	SetConsensusParamsMsg *validators.SetConsensusParamsMsg `protobuf:"bytes,69,opt,name=set_consensus_params_msg,json=setConsensusParamsMsg,oneof"`
+}
 
 func (*Tx_SendMsg) isTx_Sum()                  {}
 func (*Tx_CreateEscrowMsg) isTx_Sum()          {}
@@ -179,6 +185,7 @@ func (*Tx_RemoveUsernameAddressMsg) isTx_Sum() {}
 func (*Tx_NewRevenueMsg) isTx_Sum()            {}
 func (*Tx_DistributeMsg) isTx_Sum()            {}
 func (*Tx_ResetRevenueMsg) isTx_Sum()          {}
+func (*Tx_SetConsensusParamsMsg) isTx_Sum()    {}
 
 func (m *Tx) GetSum() isTx_Sum {
 	if m != nil {
@@ -215,6 +222,13 @@ func (m *Tx) GetMultisig() [][]byte {
 	return nil
 }
 
+func (m *Tx) GetValidUntil() *sigs.ValidUntil {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return nil
+}
+
 func (m *Tx) GetSendMsg() *cash.SendMsg {
 	if x, ok := m.GetSum().(*Tx_SendMsg); ok {
 		return x.SendMsg
@@ -334,6 +348,13 @@ func (m *Tx) GetResetRevenueMsg() *distribution.ResetRevenueMsg {
 	return nil
 }
 
+func (m *Tx) GetSetConsensusParamsMsg() *validators.SetConsensusParamsMsg {
+	if x, ok := m.GetSum().(*Tx_SetConsensusParamsMsg); ok {
+		return x.SetConsensusParamsMsg
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Tx) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Tx_OneofMarshaler, _Tx_OneofUnmarshaler, _Tx_OneofSizer, []interface{}{
@@ -354,6 +375,7 @@ func (*Tx) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, fun
 		(*Tx_NewRevenueMsg)(nil),
 		(*Tx_DistributeMsg)(nil),
 		(*Tx_ResetRevenueMsg)(nil),
+		(*Tx_SetConsensusParamsMsg)(nil),
 	}
 }
 
@@ -446,6 +468,11 @@ func _Tx_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ResetRevenueMsg); err != nil {
 			return err
 		}
+	case *Tx_SetConsensusParamsMsg:
+		_ = b.EncodeVarint(69<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SetConsensusParamsMsg); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Tx.Sum has unexpected type %T", x)
@@ -592,6 +619,14 @@ func _Tx_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bo
 		err := b.DecodeMessage(msg)
 		m.Sum = &Tx_ResetRevenueMsg{msg}
 		return true, err
+	case 69: // sum.set_consensus_params_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(validators.SetConsensusParamsMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &Tx_SetConsensusParamsMsg{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -686,6 +721,11 @@ func _Tx_OneofSizer(msg proto.Message) (n int) {
 		n += 2 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Tx_SetConsensusParamsMsg:
+		s := proto.Size(x.SetConsensusParamsMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -747,12 +787,22 @@ func (m *Tx) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], b)
 		}
 	}
+	if m.ValidUntil != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.ValidUntil.Size()))
+		n2, err := m.ValidUntil.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
 	if m.Sum != nil {
-		nn2, err := m.Sum.MarshalTo(dAtA[i:])
+		nn3, err := m.Sum.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn2
+		i += nn3
 	}
 	return i, nil
 }
@@ -765,11 +815,11 @@ func (m *Tx_SendMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.SendMsg.Size()))
-		n3, err := m.SendMsg.MarshalTo(dAtA[i:])
+		n4, err := m.SendMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n4
 	}
 	return i, nil
 }
@@ -781,11 +831,11 @@ func (m *Tx_CreateEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.CreateEscrowMsg.Size()))
-		n4, err := m.CreateEscrowMsg.MarshalTo(dAtA[i:])
+		n5, err := m.CreateEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n5
 	}
 	return i, nil
 }
@@ -797,11 +847,11 @@ func (m *Tx_ReleaseEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.ReleaseEscrowMsg.Size()))
-		n5, err := m.ReleaseEscrowMsg.MarshalTo(dAtA[i:])
+		n6, err := m.ReleaseEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n6
 	}
 	return i, nil
 }
@@ -813,11 +863,11 @@ func (m *Tx_ReturnEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.ReturnEscrowMsg.Size()))
-		n6, err := m.ReturnEscrowMsg.MarshalTo(dAtA[i:])
+		n7, err := m.ReturnEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n6
+		i += n7
 	}
 	return i, nil
 }
@@ -829,11 +879,11 @@ func (m *Tx_UpdateEscrowMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.UpdateEscrowMsg.Size()))
-		n7, err := m.UpdateEscrowMsg.MarshalTo(dAtA[i:])
+		n8, err := m.UpdateEscrowMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n8
 	}
 	return i, nil
 }
@@ -845,11 +895,11 @@ func (m *Tx_CreateContractMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.CreateContractMsg.Size()))
-		n8, err := m.CreateContractMsg.MarshalTo(dAtA[i:])
+		n9, err := m.CreateContractMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n9
 	}
 	return i, nil
 }
@@ -861,11 +911,11 @@ func (m *Tx_UpdateContractMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.UpdateContractMsg.Size()))
-		n9, err := m.UpdateContractMsg.MarshalTo(dAtA[i:])
+		n10, err := m.UpdateContractMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n9
+		i += n10
 	}
 	return i, nil
 }
@@ -877,11 +927,11 @@ func (m *Tx_SetValidatorsMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.SetValidatorsMsg.Size()))
-		n10, err := m.SetValidatorsMsg.MarshalTo(dAtA[i:])
+		n11, err := m.SetValidatorsMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n10
+		i += n11
 	}
 	return i, nil
 }
@@ -893,11 +943,11 @@ func (m *Tx_NewTokenInfoMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.NewTokenInfoMsg.Size()))
-		n11, err := m.NewTokenInfoMsg.MarshalTo(dAtA[i:])
+		n12, err := m.NewTokenInfoMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n12
 	}
 	return i, nil
 }
@@ -909,11 +959,11 @@ func (m *Tx_AddApprovalMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.AddApprovalMsg.Size()))
-		n12, err := m.AddApprovalMsg.MarshalTo(dAtA[i:])
+		n13, err := m.AddApprovalMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n13
 	}
 	return i, nil
 }
@@ -925,11 +975,11 @@ func (m *Tx_RemoveApprovalMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.RemoveApprovalMsg.Size()))
-		n13, err := m.RemoveApprovalMsg.MarshalTo(dAtA[i:])
+		n14, err := m.RemoveApprovalMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n14
 	}
 	return i, nil
 }
@@ -941,11 +991,11 @@ func (m *Tx_IssueUsernameNftMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.IssueUsernameNftMsg.Size()))
-		n14, err := m.IssueUsernameNftMsg.MarshalTo(dAtA[i:])
+		n15, err := m.IssueUsernameNftMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n14
+		i += n15
 	}
 	return i, nil
 }
@@ -957,11 +1007,11 @@ func (m *Tx_AddUsernameAddressNftMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.AddUsernameAddressNftMsg.Size()))
-		n15, err := m.AddUsernameAddressNftMsg.MarshalTo(dAtA[i:])
+		n16, err := m.AddUsernameAddressNftMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n16
 	}
 	return i, nil
 }
@@ -973,11 +1023,11 @@ func (m *Tx_RemoveUsernameAddressMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.RemoveUsernameAddressMsg.Size()))
-		n16, err := m.RemoveUsernameAddressMsg.MarshalTo(dAtA[i:])
+		n17, err := m.RemoveUsernameAddressMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n17
 	}
 	return i, nil
 }
@@ -989,11 +1039,11 @@ func (m *Tx_NewRevenueMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.NewRevenueMsg.Size()))
-		n17, err := m.NewRevenueMsg.MarshalTo(dAtA[i:])
+		n18, err := m.NewRevenueMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n17
+		i += n18
 	}
 	return i, nil
 }
@@ -1005,11 +1055,11 @@ func (m *Tx_DistributeMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.DistributeMsg.Size()))
-		n18, err := m.DistributeMsg.MarshalTo(dAtA[i:])
+		n19, err := m.DistributeMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n18
+		i += n19
 	}
 	return i, nil
 }
@@ -1021,11 +1071,27 @@ func (m *Tx_ResetRevenueMsg) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4
 		i++
 		i = encodeVarintCodec(dAtA, i, uint64(m.ResetRevenueMsg.Size()))
-		n19, err := m.ResetRevenueMsg.MarshalTo(dAtA[i:])
+		n20, err := m.ResetRevenueMsg.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n19
+		i += n20
+	}
+	return i, nil
+}
+func (m *Tx_SetConsensusParamsMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.SetConsensusParamsMsg != nil {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x4
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.SetConsensusParamsMsg.Size()))
+		n21, err := m.SetConsensusParamsMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
 	}
 	return i, nil
 }
@@ -1061,6 +1127,10 @@ func (m *Tx) Size() (n int) {
 			n += 1 + l + sovCodec(uint64(l))
 		}
 	}
+	if m.ValidUntil != nil {
+		l = m.ValidUntil.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
 	if m.Sum != nil {
 		n += m.Sum.Size()
 	}
@@ -1220,6 +1290,15 @@ func (m *Tx_ResetRevenueMsg) Size() (n int) {
 	}
 	return n
 }
+func (m *Tx_SetConsensusParamsMsg) Size() (n int) {
+	var l int
+	_ = l
+	if m.SetConsensusParamsMsg != nil {
+		l = m.SetConsensusParamsMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
 
 func sovCodec(x uint64) (n int) {
 	for {
@@ -1387,6 +1466,39 @@ func (m *Tx) Unmarshal(dAtA []byte) error {
 			m.Multisig = append(m.Multisig, make([]byte, postIndex-iNdEx))
 			copy(m.Multisig[len(m.Multisig)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidUntil", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValidUntil == nil {
+				m.ValidUntil = &sigs.ValidUntil{}
+			}
+			if err := m.ValidUntil.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 51:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field SendMsg", wireType)
@@ -1931,6 +2043,38 @@ func (m *Tx) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Tx_ResetRevenueMsg{v}
 			iNdEx = postIndex
+		case 69:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SetConsensusParamsMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &validators.SetConsensusParamsMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Tx_SetConsensusParamsMsg{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCodec(dAtA[iNdEx:])
@@ -2057,58 +2201,61 @@ var (
 	ErrIntOverflowCodec   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("app/codec.proto", fileDescriptor_codec_b8bc71b719c27f15) }
+func init() { proto.RegisterFile("cmd/bnsd/app/codec.proto", fileDescriptor_codec_d7fd68e03dded43a) }
 
-var fileDescriptor_codec_b8bc71b719c27f15 = []byte{
-	// 786 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xdd, 0x72, 0x1b, 0x35,
-	0x14, 0xc7, 0xeb, 0xba, 0x40, 0x47, 0xa1, 0x4d, 0x22, 0xcf, 0x14, 0xe3, 0x16, 0x63, 0xb8, 0xca,
-	0x94, 0x89, 0x76, 0x48, 0xf8, 0xfe, 0x2a, 0x8e, 0x53, 0x26, 0x1d, 0xda, 0x0c, 0xb3, 0x6e, 0xb9,
-	0x64, 0x91, 0x57, 0x67, 0x37, 0x3b, 0x78, 0xa5, 0x1d, 0x49, 0x6b, 0x87, 0xb7, 0xe0, 0x96, 0x37,
-	0xe2, 0x92, 0x47, 0x60, 0xc2, 0x8b, 0x30, 0xfa, 0x58, 0x67, 0xb5, 0xb4, 0x9e, 0xde, 0x59, 0xff,
-	0xf3, 0xff, 0xff, 0xf6, 0xe8, 0x48, 0x16, 0xda, 0xa5, 0x55, 0x15, 0xa5, 0x82, 0x41, 0x4a, 0x2a,
-	0x29, 0xb4, 0xc0, 0x7d, 0x5a, 0x55, 0xa3, 0xc3, 0xbc, 0xd0, 0x17, 0xf5, 0x82, 0xa4, 0xa2, 0x8c,
-	0x72, 0x91, 0x8b, 0xc8, 0xd6, 0x16, 0x75, 0x66, 0x57, 0x76, 0x61, 0x7f, 0xb9, 0xcc, 0xe8, 0x9b,
-	0x96, 0xbd, 0x10, 0xab, 0x43, 0xc1, 0x21, 0x5a, 0x03, 0x5d, 0x41, 0x94, 0x96, 0x2c, 0x5a, 0x70,
-	0xc5, 0xa2, 0xcb, 0x88, 0x67, 0x3a, 0xaa, 0x15, 0x48, 0x4e, 0x4b, 0x68, 0x7f, 0x71, 0xf4, 0xd1,
-	0x2b, 0xd3, 0x97, 0x51, 0x4a, 0xd5, 0x45, 0x60, 0x8e, 0xb6, 0x99, 0x6b, 0x29, 0x81, 0xa7, 0xbf,
-	0x07, 0x81, 0xc3, 0x2d, 0x01, 0x50, 0xa9, 0x14, 0xeb, 0xd7, 0xe6, 0x97, 0xf5, 0x52, 0x17, 0xaa,
-	0xc8, 0x83, 0xc0, 0xc3, 0x2d, 0x01, 0xb3, 0xe5, 0xd7, 0xdd, 0xa9, 0x2a, 0x72, 0x15, 0x98, 0x3f,
-	0xde, 0x62, 0x5e, 0xd1, 0x65, 0xc1, 0xa8, 0x16, 0x32, 0x8c, 0x1c, 0x6f, 0x89, 0xb0, 0x42, 0x69,
-	0x59, 0x2c, 0x6a, 0x5d, 0x08, 0xde, 0x0e, 0x7d, 0xf8, 0xe7, 0x0e, 0xba, 0xf9, 0xfc, 0x12, 0x7f,
-	0x80, 0x6e, 0x65, 0x00, 0x6a, 0xd8, 0x9b, 0xf4, 0x0e, 0x76, 0x8e, 0xee, 0x10, 0x33, 0x79, 0xf2,
-	0x03, 0xc0, 0x13, 0x9e, 0x89, 0xd8, 0x96, 0xf0, 0x11, 0x42, 0xaa, 0xc8, 0x39, 0xd5, 0xb5, 0x04,
-	0x35, 0xbc, 0x39, 0xe9, 0x1f, 0xec, 0x1c, 0x61, 0x62, 0x1a, 0x27, 0x73, 0xcd, 0xe6, 0x4d, 0x29,
-	0x6e, 0xb9, 0xf0, 0x08, 0xdd, 0xae, 0x24, 0x14, 0x25, 0xcd, 0x61, 0xd8, 0x9f, 0xf4, 0x0e, 0xde,
-	0x8e, 0x37, 0x6b, 0x53, 0x6b, 0x46, 0x3a, 0xbc, 0x35, 0xe9, 0x9b, 0x5a, 0xb3, 0xc6, 0x0f, 0xd1,
-	0x6d, 0x05, 0x9c, 0x25, 0xa5, 0xca, 0x87, 0xc7, 0xed, 0x96, 0xe6, 0xc0, 0xd9, 0x33, 0x95, 0x9f,
-	0xdd, 0x88, 0xdf, 0x52, 0xee, 0x27, 0x7e, 0x8c, 0xf6, 0x53, 0x09, 0x54, 0x43, 0xe2, 0x0e, 0xd4,
-	0x86, 0x3e, 0xb1, 0xa1, 0x77, 0x88, 0x93, 0xc8, 0xcc, 0x1a, 0x1e, 0xdb, 0x85, 0x8b, 0xef, 0xa6,
-	0xa1, 0x84, 0xcf, 0x10, 0x96, 0xb0, 0x04, 0xaa, 0x02, 0xce, 0xa7, 0x96, 0x33, 0x6c, 0x38, 0xb1,
-	0x73, 0xb4, 0x41, 0x7b, 0xb2, 0xa3, 0x99, 0x86, 0x24, 0xe8, 0x5a, 0xf2, 0x36, 0xe8, 0xb3, 0xb0,
-	0xa1, 0xd8, 0x1a, 0x82, 0x86, 0x64, 0x28, 0xe1, 0xa7, 0x68, 0xbf, 0xae, 0x58, 0x67, 0x5f, 0x9f,
-	0x5b, 0xcc, 0xb8, 0xc1, 0xbc, 0xb0, 0x06, 0x97, 0xf9, 0x89, 0x4a, 0x5d, 0x80, 0xf2, 0xb4, 0xba,
-	0x55, 0x31, 0xb4, 0x67, 0x68, 0xe0, 0xa7, 0x94, 0x0a, 0xae, 0x25, 0x4d, 0xb5, 0xe5, 0x7d, 0x61,
-	0x79, 0xf7, 0x49, 0x33, 0x79, 0x3f, 0xa9, 0x99, 0xf7, 0x38, 0x98, 0x9f, 0x6f, 0x4b, 0x34, 0x38,
-	0xdf, 0x5c, 0x80, 0xfb, 0xb2, 0x8b, 0x73, 0x0d, 0x76, 0x70, 0x75, 0x57, 0xc4, 0x4f, 0x11, 0x56,
-	0xa0, 0x93, 0xeb, 0x8b, 0x6d, 0x69, 0x5f, 0x59, 0xda, 0x03, 0x72, 0x2d, 0x93, 0x39, 0xe8, 0x9f,
-	0x37, 0x2b, 0x7f, 0x00, 0xaa, 0xa3, 0x99, 0xa3, 0xe4, 0xb0, 0x4e, 0xb4, 0xf8, 0x0d, 0x78, 0x52,
-	0xf0, 0x4c, 0x58, 0xda, 0xd7, 0x96, 0xf6, 0x2e, 0x69, 0xde, 0x09, 0x72, 0x0e, 0xeb, 0xe7, 0xc6,
-	0x62, 0xee, 0xb8, 0x9f, 0x1a, 0x0f, 0x25, 0xfc, 0x08, 0xed, 0x51, 0xc6, 0x12, 0x5a, 0x55, 0x52,
-	0xac, 0xe8, 0xd2, 0x72, 0xbe, 0xb5, 0x9c, 0x01, 0xe1, 0x99, 0x26, 0x53, 0xc6, 0xa6, 0xbe, 0xe6,
-	0x08, 0x77, 0x69, 0xa0, 0xe0, 0x33, 0x34, 0x90, 0x50, 0x8a, 0x15, 0x84, 0x8c, 0xef, 0x2c, 0xe3,
-	0x9e, 0x65, 0xc4, 0xb6, 0x1e, 0x62, 0xf6, 0x65, 0x57, 0xc4, 0xe7, 0xe8, 0x5e, 0xa1, 0x54, 0x0d,
-	0x49, 0xf3, 0x8a, 0x26, 0x3c, 0x73, 0x43, 0x7f, 0xe4, 0xaf, 0x56, 0x53, 0x20, 0x4f, 0x8c, 0xcf,
-	0xee, 0xc3, 0xd1, 0x06, 0x36, 0xf8, 0xc2, 0x97, 0xcf, 0x33, 0x3b, 0xf2, 0x5f, 0xd0, 0x03, 0xb3,
-	0xb5, 0x0d, 0x8d, 0x32, 0x26, 0x41, 0xa9, 0x0d, 0xf5, 0x7b, 0x3f, 0xfc, 0x0d, 0x75, 0xca, 0xd8,
-	0xec, 0x82, 0x16, 0x7c, 0xea, 0x8c, 0x0e, 0x3d, 0xa4, 0x8c, 0x35, 0x60, 0x5f, 0xf0, 0xfc, 0x5f,
-	0xd1, 0x7d, 0xbf, 0xf3, 0xff, 0x7d, 0xc2, 0xe0, 0xa7, 0x16, 0xff, 0xfe, 0x35, 0xde, 0x8d, 0xe1,
-	0x25, 0x5f, 0x70, 0x94, 0xce, 0x47, 0xdc, 0xff, 0xcc, 0x9c, 0x57, 0x22, 0x61, 0x05, 0xbc, 0x06,
-	0x4b, 0x3d, 0xf1, 0xf7, 0xaf, 0xfd, 0xdc, 0x99, 0x73, 0x8e, 0x9d, 0xc7, 0x11, 0xef, 0xf0, 0xb6,
-	0x80, 0x4f, 0xd1, 0xdd, 0x8d, 0xdd, 0x51, 0x66, 0x2f, 0xa3, 0x9c, 0x6e, 0x3c, 0x9e, 0xc2, 0xda,
-	0x02, 0xfe, 0xd1, 0xfc, 0xe9, 0xcd, 0x1d, 0x6e, 0xb7, 0x73, 0x6a, 0x41, 0xef, 0x85, 0xa0, 0xd8,
-	0xd8, 0x82, 0x86, 0x76, 0x65, 0x28, 0x9d, 0xbc, 0x81, 0xfa, 0xaa, 0x2e, 0x4f, 0xf6, 0xfe, 0xba,
-	0x1a, 0xf7, 0xfe, 0xbe, 0x1a, 0xf7, 0xfe, 0xb9, 0x1a, 0xf7, 0xfe, 0xf8, 0x77, 0x7c, 0x63, 0xf1,
-	0xa6, 0x7d, 0xb4, 0x8f, 0xff, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x1c, 0xf8, 0x43, 0xa5, 0xb8, 0x07,
-	0x00, 0x00,
+var fileDescriptor_codec_d7fd68e03dded43a = []byte{
+	// 846 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x95, 0xdb, 0x6e, 0xdc, 0x36,
+	0x10, 0x86, 0xb3, 0xd9, 0xa4, 0x0d, 0xe8, 0x1c, 0x6c, 0x1a, 0x4d, 0x55, 0x27, 0xdd, 0x3a, 0xbd,
+	0x32, 0x52, 0x58, 0x42, 0xec, 0x9e, 0x4f, 0xe9, 0x7a, 0xed, 0xc2, 0x41, 0x13, 0x23, 0x90, 0xe3,
+	0x5c, 0x15, 0x55, 0xb9, 0xe2, 0xac, 0x2c, 0x74, 0x45, 0x0a, 0x24, 0xb5, 0x76, 0xdf, 0xa2, 0xcf,
+	0xd1, 0x27, 0xe9, 0x65, 0x1f, 0xa1, 0x70, 0x5f, 0xa4, 0xe0, 0x50, 0x92, 0x45, 0xd5, 0x59, 0xe4,
+	0x6e, 0xf9, 0xcf, 0x3f, 0x1f, 0x67, 0x86, 0xa4, 0x96, 0x04, 0x69, 0xc1, 0xa3, 0xa9, 0xd0, 0x3c,
+	0x62, 0x65, 0x19, 0xa5, 0x92, 0x43, 0x1a, 0x96, 0x4a, 0x1a, 0x49, 0x87, 0xac, 0x2c, 0x37, 0xb6,
+	0xb3, 0xdc, 0x9c, 0x56, 0xd3, 0x30, 0x95, 0x45, 0x94, 0xc9, 0x4c, 0x46, 0x18, 0x9b, 0x56, 0x33,
+	0x5c, 0xe1, 0x02, 0x7f, 0xb9, 0x9c, 0x8d, 0x6f, 0x3b, 0xf6, 0x5c, 0x2e, 0xb6, 0xa5, 0x80, 0xe8,
+	0x0c, 0xd8, 0x02, 0xa2, 0x76, 0x9b, 0xf3, 0x48, 0xcc, 0x4c, 0x54, 0x69, 0x50, 0x82, 0x15, 0xd0,
+	0xdd, 0x71, 0xe3, 0x93, 0x37, 0x66, 0x9f, 0x47, 0x29, 0xd3, 0xa7, 0x9e, 0x39, 0x5a, 0x66, 0xae,
+	0x94, 0x02, 0x91, 0xfe, 0xee, 0x25, 0x6c, 0x2f, 0x49, 0x00, 0x9d, 0x2a, 0x79, 0xf6, 0xd6, 0xfc,
+	0xa2, 0x9a, 0x9b, 0x5c, 0xe7, 0x99, 0x97, 0xf0, 0x78, 0x49, 0x82, 0x6d, 0xf9, 0x6d, 0x3b, 0xd5,
+	0x79, 0xa6, 0x3d, 0xf3, 0x93, 0x25, 0xe6, 0x05, 0x9b, 0xe7, 0x9c, 0x19, 0xa9, 0xfc, 0x94, 0xdd,
+	0x25, 0x29, 0x3c, 0xd7, 0x46, 0xe5, 0xd3, 0xca, 0xe4, 0x52, 0x74, 0x93, 0x3e, 0xfe, 0xf3, 0x36,
+	0xb9, 0xfe, 0xea, 0x9c, 0x3e, 0x22, 0x37, 0x66, 0x00, 0x3a, 0x18, 0x6c, 0x0e, 0xb6, 0x56, 0x76,
+	0xee, 0x84, 0x76, 0xf2, 0xe1, 0x8f, 0x00, 0xcf, 0xc4, 0x4c, 0xc6, 0x18, 0xa2, 0x3b, 0x84, 0xe8,
+	0x3c, 0x13, 0xcc, 0x54, 0x0a, 0x74, 0x70, 0x7d, 0x73, 0xb8, 0xb5, 0xb2, 0x43, 0x43, 0x5b, 0x78,
+	0x78, 0x6c, 0xf8, 0x71, 0x13, 0x8a, 0x3b, 0x2e, 0xba, 0x41, 0x6e, 0x95, 0x0a, 0xf2, 0x82, 0x65,
+	0x10, 0x0c, 0x37, 0x07, 0x5b, 0xb7, 0xe3, 0x76, 0x6d, 0x63, 0xcd, 0x48, 0x83, 0x1b, 0x9b, 0x43,
+	0x1b, 0x6b, 0xd6, 0xf4, 0x09, 0x59, 0xc1, 0x26, 0x93, 0x4a, 0x98, 0x7c, 0x1e, 0xdc, 0xc4, 0xaa,
+	0x56, 0xdd, 0x66, 0xaf, 0x6d, 0xe0, 0xc4, 0xea, 0x31, 0x59, 0xb4, 0xbf, 0xe9, 0x63, 0x72, 0x4b,
+	0x83, 0xe0, 0x49, 0xa1, 0xb3, 0x60, 0xb7, 0xdb, 0xc5, 0x31, 0x08, 0xfe, 0x42, 0x67, 0x87, 0xd7,
+	0xe2, 0x77, 0xb5, 0xfb, 0x49, 0x0f, 0xc8, 0x5a, 0xaa, 0x80, 0x19, 0x48, 0xdc, 0x1d, 0xc0, 0xa4,
+	0x4f, 0x31, 0xe9, 0xfd, 0xd0, 0x49, 0xe1, 0x04, 0x0d, 0x07, 0xb8, 0x70, 0xe9, 0xf7, 0x52, 0x5f,
+	0xa2, 0x87, 0x84, 0x2a, 0x98, 0x03, 0xd3, 0x1e, 0xe7, 0x33, 0xe4, 0x04, 0x0d, 0x27, 0x76, 0x8e,
+	0x2e, 0x68, 0x55, 0xf5, 0x34, 0x5b, 0x90, 0x02, 0x53, 0x29, 0xd1, 0x05, 0x7d, 0xee, 0x17, 0x14,
+	0xa3, 0xc1, 0x2b, 0x48, 0xf9, 0x12, 0x7d, 0x4e, 0xd6, 0xaa, 0x92, 0xf7, 0xfa, 0xfa, 0x02, 0x31,
+	0xa3, 0x06, 0x73, 0x82, 0x06, 0x97, 0xf3, 0x92, 0x29, 0x93, 0x83, 0xae, 0x69, 0x55, 0x27, 0x62,
+	0x69, 0x2f, 0xc8, 0x7a, 0x3d, 0xa5, 0x54, 0x0a, 0xa3, 0x58, 0x6a, 0x90, 0xf7, 0x25, 0xf2, 0x1e,
+	0x84, 0xcd, 0x61, 0xd5, 0x93, 0x9a, 0xd4, 0x1e, 0x07, 0x5b, 0x4b, 0xfb, 0xa2, 0xc5, 0xd5, 0xc5,
+	0x79, 0xb8, 0xaf, 0xfa, 0x38, 0x57, 0x60, 0x0f, 0x57, 0xf5, 0x45, 0xfa, 0x9c, 0x50, 0x0d, 0x26,
+	0xb9, 0x7c, 0x0b, 0x48, 0xfb, 0x1a, 0x69, 0x0f, 0xc3, 0x4b, 0x39, 0x3c, 0x06, 0xf3, 0xba, 0x5d,
+	0xd5, 0x07, 0xa0, 0x7b, 0x9a, 0x3d, 0x4a, 0x01, 0x67, 0x89, 0x91, 0xbf, 0x81, 0x48, 0x72, 0x31,
+	0x93, 0x48, 0xfb, 0x06, 0x69, 0x1f, 0x84, 0xcd, 0xa7, 0x25, 0x3c, 0x82, 0xb3, 0x57, 0xd6, 0x62,
+	0x9f, 0x45, 0x3d, 0x35, 0xe1, 0x4b, 0xf4, 0x29, 0x59, 0x65, 0x9c, 0x27, 0xac, 0x2c, 0x95, 0x5c,
+	0xb0, 0x39, 0x72, 0xbe, 0x43, 0xce, 0x7a, 0x28, 0x66, 0x26, 0x1c, 0x73, 0x3e, 0xae, 0x63, 0x8e,
+	0x70, 0x97, 0x79, 0x0a, 0x3d, 0x24, 0xeb, 0x0a, 0x0a, 0xb9, 0x00, 0x9f, 0xf1, 0x3d, 0x32, 0xee,
+	0x23, 0x23, 0xc6, 0xb8, 0x8f, 0x59, 0x53, 0x7d, 0x91, 0x1e, 0x91, 0xfb, 0xb9, 0xd6, 0x15, 0x24,
+	0xcd, 0x87, 0x37, 0x11, 0x33, 0x37, 0xf4, 0xa7, 0xf5, 0xd5, 0x6a, 0x02, 0xe1, 0x33, 0xeb, 0xc3,
+	0x3e, 0x1c, 0x6d, 0x1d, 0x13, 0x4f, 0xea, 0xf0, 0xd1, 0x0c, 0x47, 0xfe, 0x0b, 0x79, 0x68, 0x5b,
+	0x6b, 0x69, 0x8c, 0x73, 0x05, 0x5a, 0xb7, 0xd4, 0x1f, 0xea, 0xe1, 0xb7, 0xd4, 0x31, 0xe7, 0x93,
+	0x53, 0x96, 0x8b, 0xb1, 0x33, 0x3a, 0x74, 0xc0, 0x38, 0x6f, 0xc0, 0x75, 0xa0, 0xe6, 0xff, 0x4a,
+	0x1e, 0xd4, 0x9d, 0xff, 0x6f, 0x0b, 0x8b, 0x1f, 0x23, 0xfe, 0xa3, 0x4b, 0xbc, 0x1b, 0xc3, 0x15,
+	0x3b, 0x38, 0x4a, 0x6f, 0x13, 0xf7, 0xce, 0xec, 0x79, 0x25, 0x0a, 0x16, 0x20, 0x2a, 0x40, 0xea,
+	0x5e, 0x7d, 0xff, 0xba, 0x5f, 0x48, 0x7b, 0xce, 0xb1, 0xf3, 0x38, 0xe2, 0x1d, 0xd1, 0x15, 0xe8,
+	0x3e, 0xb9, 0xdb, 0xda, 0x1d, 0x65, 0x72, 0x15, 0x65, 0xbf, 0xf5, 0xd4, 0x14, 0xde, 0x15, 0xe8,
+	0x4f, 0xf6, 0xd1, 0xdb, 0x3b, 0xdc, 0x2d, 0x67, 0x1f, 0x41, 0x1f, 0xfa, 0xa0, 0xd8, 0xda, 0xbc,
+	0x82, 0xee, 0x29, 0x5f, 0xa2, 0x3f, 0x93, 0xc0, 0xa2, 0x52, 0x29, 0x34, 0x08, 0x5d, 0xe9, 0xa4,
+	0x64, 0x8a, 0x15, 0x6e, 0x70, 0x07, 0xc8, 0x7c, 0xd4, 0x7b, 0x14, 0x93, 0xc6, 0xfa, 0x12, 0x9d,
+	0x8e, 0xfb, 0x9e, 0xbe, 0x2a, 0xb0, 0x77, 0x93, 0x0c, 0x75, 0x55, 0xec, 0xad, 0xfe, 0x75, 0x31,
+	0x1a, 0xfc, 0x7d, 0x31, 0x1a, 0xfc, 0x73, 0x31, 0x1a, 0xfc, 0xf1, 0xef, 0xe8, 0xda, 0xf4, 0x1d,
+	0xfc, 0x17, 0xd9, 0xfd, 0x6f, 0x00, 0x45, 0xd7, 0x3a, 0x1d, 0x52, 0x08, 0x00, 0x00,
 }
@@ -13,11 +13,14 @@ import (
 	"github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/crypto"
 	"github.com/iov-one/weave/gconf"
+	"github.com/iov-one/weave/migration"
 	"github.com/iov-one/weave/orm"
 	"github.com/iov-one/weave/x/cash"
 	"github.com/iov-one/weave/x/currency"
 	"github.com/iov-one/weave/x/distribution"
+	"github.com/iov-one/weave/x/escrow"
 	"github.com/iov-one/weave/x/multisig"
+	"github.com/iov-one/weave/x/slashing"
 	"github.com/iov-one/weave/x/validators"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -86,10 +89,20 @@ func GenerateApp(home string, logger log.Logger, debug bool) (abci.Application,
 		username.ModelName: username.NewBucket().Bucket,
 	}
 	stack := Stack(nil, nftBuckets)
-	application, err := Application("bnsd", stack, TxDecoder, dbPath, debug)
+	cronStack := CronStack(nil, nftBuckets)
+	application, err := Application("bnsd", stack, cronStack, TxDecoder, dbPath, debug)
 	if err != nil {
 		return nil, err
 	}
+	if home != "" {
+		sink, err := app.OpenFileSink(home)
+		if err != nil {
+			return nil, err
+		}
+		if sink != nil {
+			application.WithCommitListener(sink)
+		}
+	}
 	return DecorateApp(application, logger), nil
 }
 
@@ -102,21 +115,56 @@ func DecorateApp(application app.BaseApp, logger log.Logger) app.BaseApp {
 		&currency.Initializer{},
 		&validators.Initializer{},
 		&distribution.Initializer{},
+		&escrow.Initializer{},
+		&username.Initializer{},
+		&slashing.Initializer{},
+		&migration.Initializer{},
 	))
-	application.WithLogger(logger)
+	application.WithMigrations(Migrations())
+	application.WithModels(Models())
+	application.WithQueryLimit(app.DefaultQueryLimit)
+
+	// node-local settings, changed at runtime via SetOption
+	levels := app.NewLevelLogger(logger)
+	options := weave.NewOptionRouter()
+	options.RegisterAll(
+		application.RegisterOptions,
+		levels.RegisterOptions,
+		CheckMinFee.RegisterOptions,
+	)
+	application.WithOptions(options)
+	application.WithLogger(levels)
 	return application
 }
 
+// Exporter returns the counterpart of the initializers set by DecorateApp,
+// writing the application state in the genesis format
+func Exporter() weave.Exporter {
+	return app.ChainExporters(
+		&gconf.Initializer{},
+		&multisig.Initializer{},
+		&cash.Initializer{},
+		&currency.Initializer{},
+		&validators.Initializer{},
+		&distribution.Initializer{},
+		&escrow.Initializer{},
+		&username.Initializer{},
+		&slashing.Initializer{},
+		&migration.Initializer{},
+	)
+}
+
 // InlineApp will take a previously prepared CommitStore and return a complete Application
 func InlineApp(kv weave.CommitKVStore, logger log.Logger, debug bool) abci.Application {
 	nftBuckets := map[string]orm.Bucket{
 		username.ModelName: username.NewBucket().Bucket,
 	}
 	stack := Stack(nil, nftBuckets)
+	cronStack := CronStack(nil, nftBuckets)
 	ctx := context.Background()
 	RegisterNft()
 	store := app.NewStoreApp("bnsd", kv, QueryRouter(), ctx)
-	base := app.NewBaseApp(store, TxDecoder, stack, nil, debug)
+	base := app.NewBaseApp(store, TxDecoder, stack, Ticker(cronStack), nil, debug)
 	return DecorateApp(base, logger)
 }
 
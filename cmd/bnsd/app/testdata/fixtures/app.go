@@ -40,10 +40,12 @@ func NewApp() *AppFixture {
 
 func (f AppFixture) Build() weaveApp.BaseApp {
 	// setup app
-	stack := app.Stack(nil, map[string]orm.Bucket{
+	nftBuckets := map[string]orm.Bucket{
 		username.ModelName: username.NewBucket().Bucket,
-	})
-	myApp, err := app.Application(f.Name, stack, app.TxDecoder, "", true)
+	}
+	stack := app.Stack(nil, nftBuckets)
+	cronStack := app.CronStack(nil, nftBuckets)
+	myApp, err := app.Application(f.Name, stack, cronStack, app.TxDecoder, "", true)
 	if err != nil {
 		panic(err)
 	}
@@ -53,6 +55,7 @@ func (f AppFixture) Build() weaveApp.BaseApp {
 		&currency.Initializer{},
 	))
 	myApp.WithLogger(log.NewNopLogger())
+	myApp.WithModels(app.Models())
 	// load state
 
 	myApp.InitChain(abci.RequestInitChain{
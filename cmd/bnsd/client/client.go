@@ -9,9 +9,11 @@ import (
 
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/app"
+	"github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/x/currency"
 	"github.com/iov-one/weave/x/sigs"
 	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -148,6 +150,9 @@ type AbciResponse struct {
 	// a list of key/value pairs
 	Models []weave.Model
 	Height int64
+	// NextKey is set if the query was paginated and
+	// there are more results to read from this key
+	NextKey []byte
 }
 
 // AbciQuery calls abci query on tendermint rpc,
@@ -155,17 +160,24 @@ type AbciResponse struct {
 // data pulls out the ResultSets from keys and values into
 // a useful AbciResponse struct
 func (b *BnsClient) AbciQuery(path string, data []byte) (AbciResponse, error) {
-	var out AbciResponse
-
 	q, err := b.conn.ABCIQuery(path, data)
 	if err != nil {
-		return out, err
+		return AbciResponse{}, err
 	}
-	resp := q.Response
+	return parseAbciResponse(q.Response)
+}
+
+func parseAbciResponse(resp abci.ResponseQuery) (AbciResponse, error) {
+	var out AbciResponse
 	if resp.IsErr() {
 		return out, errors.Errorf("(%d): %s", resp.Code, resp.Log)
 	}
 	out.Height = resp.Height
+	var err error
+	out.NextKey, err = app.QueryNextKey(resp)
+	if err != nil {
+		return out, err
+	}
 
 	if len(resp.Key) == 0 {
 		return out, nil
@@ -186,6 +198,70 @@ func (b *BnsClient) AbciQuery(path string, data []byte) (AbciResponse, error) {
 	return out, err
 }
 
+// QueryPages returns an iterator reading the results of a prefix
+// or range query, at most limit models at a time.
+// Path must contain the query modifier, eg. "/wallets?prefix"
+func (b *BnsClient) QueryPages(path string, data []byte, limit int) *PageIterator {
+	return &PageIterator{
+		client: b,
+		path:   path,
+		data:   data,
+		limit:  limit,
+	}
+}
+
+// PageIterator reads the results of a query page by page,
+// continuing every page from the next key of the previous one
+type PageIterator struct {
+	client *BnsClient
+	path   string
+	data   []byte
+	limit  int
+	// height is fixed by the first page, so all pages
+	// read the same state
+	height int64
+	cursor []byte
+	done   bool
+}
+
+// Next returns the next page of results.
+// It returns (nil, nil) once all results were read.
+func (p *PageIterator) Next() ([]weave.Model, error) {
+	if p.done {
+		return nil, nil
+	}
+	path := fmt.Sprintf("%s&limit=%d", p.path, p.limit)
+	if p.cursor != nil {
+		path = fmt.Sprintf("%s&cursor=%X", path, p.cursor)
+	}
+	q, err := p.client.conn.ABCIQueryWithOptions(path, p.data,
+		client.ABCIQueryOptions{Height: p.height})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := parseAbciResponse(q.Response)
+	if err != nil {
+		return nil, err
+	}
+	p.height = resp.Height
+	p.cursor = resp.NextKey
+	p.done = resp.NextKey == nil
+	return resp.Models, nil
+}
+
+// All reads all remaining pages and returns their results
+func (p *PageIterator) All() ([]weave.Model, error) {
+	var all []weave.Model
+	for !p.done {
+		models, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, models...)
+	}
+	return all, nil
+}
+
 func (b *BnsClient) TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
 	return b.conn.TxSearch(query, prove, page, perPage)
 }
@@ -213,6 +289,60 @@ func (b BroadcastTxResponse) IsError() error {
 	return nil
 }
 
+// SimulateResponse is the outcome of a dry-run of a transaction
+type SimulateResponse struct {
+	// Height of the committed state the transaction was run against
+	Height int64
+	// DeliverTx is what delivering the transaction would have returned
+	DeliverTx abci.ResponseDeliverTx
+	// RequiredFee is the fee the transaction must pay, if any
+	RequiredFee coin.Coin
+}
+
+// IsError returns the error for failure if the transaction
+// would fail, or null if it would succeed
+func (s SimulateResponse) IsError() error {
+	if s.DeliverTx.IsErr() {
+		return errors.Errorf("DeliverTx error: (%d) %s", s.DeliverTx.Code, s.DeliverTx.Log)
+	}
+	return nil
+}
+
+// Simulate runs a signed transaction against the latest committed
+// state, without persisting anything. A failing transaction is
+// reported by IsError of the response, an error is only returned
+// if the simulation could not be run.
+func (b *BnsClient) Simulate(tx weave.Tx) (*SimulateResponse, error) {
+	data, err := tx.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	q, err := b.conn.ABCIQuery(app.SimulatePath, data)
+	if err != nil {
+		return nil, err
+	}
+	// a failed simulation carries no result
+	if q.Response.IsErr() && len(q.Response.Value) == 0 {
+		return nil, errors.Errorf("(%d): %s", q.Response.Code, q.Response.Log)
+	}
+	var result app.SimulateResult
+	if err := result.Unmarshal(q.Response.Value); err != nil {
+		return nil, err
+	}
+	dres, err := result.ParseDeliverTx()
+	if err != nil {
+		return nil, err
+	}
+	res := &SimulateResponse{
+		Height:    q.Response.Height,
+		DeliverTx: dres,
+	}
+	if result.RequiredFee != nil {
+		res.RequiredFee = *result.RequiredFee
+	}
+	return res, nil
+}
+
 // BroadcastTx serializes a signed transaction and writes to the
 // blockchain. It returns when the tx is committed to the
 // blockchain.
@@ -398,17 +528,22 @@ type CurrenciesResponse struct {
 	Currencies map[string]currency.TokenInfo
 }
 
+// currenciesPageSize is the number of currencies read with a single query
+const currenciesPageSize = 100
+
 // Currencies will returns all currencies configured for the blockchain with their token details.
 func (b *BnsClient) Currencies() (CurrenciesResponse, error) {
 	out := CurrenciesResponse{
 		Currencies: make(map[string]currency.TokenInfo),
 	}
 
-	resp, err := b.AbciQuery("/tokens?prefix", nil)
+	pages := b.QueryPages("/tokens?prefix", nil, currenciesPageSize)
+	models, err := pages.All()
 	if err != nil {
 		return out, errors.Wrap(err, "failed to query for all currencies")
 	}
-	for _, v := range resp.Models {
+	out.Height = pages.height
+	for _, v := range models {
 		var ti currency.TokenInfo
 		if err := ti.Unmarshal(v.Value); err != nil {
 			return out, errors.Wrapf(err, "failed to unmarshal value of key %q", string(v.Key))
@@ -128,6 +128,42 @@ func TestSendMoney(t *testing.T) {
 	assert.Equal(t, initBalance.Ticker, coin.Ticker)
 }
 
+func TestSimulate(t *testing.T) {
+	conn := NewLocalConnection(node)
+	bcp := NewClient(conn)
+
+	rcpt := GenPrivateKey().PublicKey().Address()
+	src := faucet.PublicKey().Address()
+	nonce := NewNonce(bcp, src)
+	chainID := getChainID()
+
+	amount := coin.Coin{Whole: 1000, Ticker: initBalance.Ticker}
+	tx := BuildSendTx(src, rcpt, amount, "Simulated")
+	n, err := nonce.Query()
+	require.NoError(t, err)
+	SignTx(tx, faucet, chainID, n)
+
+	res, err := bcp.Simulate(tx)
+	require.NoError(t, err)
+	require.NoError(t, res.IsError())
+	assert.True(t, res.DeliverTx.GasUsed > 0)
+
+	// nothing was persisted
+	n2, err := nonce.Query()
+	require.NoError(t, err)
+	assert.Equal(t, n, n2)
+	wallet, err := bcp.GetWallet(rcpt)
+	require.NoError(t, err)
+	assert.Nil(t, wallet)
+
+	// a transaction with a wrong nonce would fail
+	tx.Signatures = nil
+	SignTx(tx, faucet, chainID, n+5)
+	res, err = bcp.Simulate(tx)
+	require.NoError(t, err)
+	assert.Error(t, res.IsError())
+}
+
 func TestSubscribeHeaders(t *testing.T) {
 	conn := NewLocalConnection(node)
 	bcp := NewClient(conn)
@@ -33,7 +33,10 @@ func helpMessage() {
 	fmt.Println("init      Initialize app options in genesis file")
 	fmt.Println("start     Run the abci server")
 	fmt.Println("getblock  Extract a block from blockchain.db")
+	fmt.Println("export    Write the app state of a stopped node as genesis json")
+	fmt.Println("inspect   Print the buckets, keys and values of a stopped node")
 	fmt.Println("retry     Run last block again to ensure it produces same result")
+	fmt.Println("rollback  Delete the app state above a height, to replay those blocks")
 	fmt.Println("version   Print the app version")
 	fmt.Println(`
   -home string
@@ -64,8 +67,14 @@ func main() {
 		err = server.StartCmd(app.GenerateApp, logger, *varHome, rest)
 	case "getblock":
 		err = server.GetBlockCmd(logger, *varHome, rest)
+	case "export":
+		err = server.ExportCmd(app.Exporter(), logger, *varHome, rest)
+	case "inspect":
+		err = server.InspectCmd(app.Models(), logger, *varHome, rest)
 	case "retry":
 		err = server.RetryCmd(app.InlineApp, logger, *varHome, rest)
+	case "rollback":
+		err = server.RollbackCmd(logger, *varHome, rest)
 	case "testgen":
 		err = commands.TestGenCmd(app.Examples(), rest)
 	case "version":
@@ -0,0 +1,89 @@
+package username
+
+import (
+	"fmt"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/x/nft"
+)
+
+// genesisToken is the genesis format of a UsernameToken
+type genesisToken struct {
+	ID        string                `json:"id"`
+	Owner     weave.Address         `json:"owner"`
+	Approvals []nft.ActionApprovals `json:"approvals"`
+	Addresses []genesisAddress      `json:"addresses"`
+}
+
+// genesisAddress is the genesis format of a ChainAddress
+type genesisAddress struct {
+	BlockchainID string `json:"blockchain_id"`
+	Address      string `json:"address"`
+}
+
+// Initializer fulfils the Initializer interface to load data from the genesis
+// file
+type Initializer struct{}
+
+var _ weave.Initializer = (*Initializer)(nil)
+
+// FromGenesis will parse initial usernames from genesis and save them in the
+// database
+func (*Initializer) FromGenesis(opts weave.Options, db weave.KVStore) error {
+	var tokens []genesisToken
+	if err := opts.ReadOptions("usernames", &tokens); err != nil {
+		return errors.Wrap(err, "cannot load usernames")
+	}
+
+	bucket := NewBucket()
+	for _, t := range tokens {
+		addresses := make([]ChainAddress, 0, len(t.Addresses))
+		for _, a := range t.Addresses {
+			addresses = append(addresses, ChainAddress{
+				BlockchainID: []byte(a.BlockchainID),
+				Address:      a.Address,
+			})
+		}
+		obj, err := bucket.Create(db, t.Owner, []byte(t.ID), t.Approvals, addresses)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("cannot create username %q", t.ID))
+		}
+		if err := bucket.Save(db, obj); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("cannot store username %q", t.ID))
+		}
+	}
+	return nil
+}
+
+var _ weave.Exporter = (*Initializer)(nil)
+
+// ToGenesis exports all usernames in the format read by FromGenesis
+func (*Initializer) ToGenesis(db weave.ReadOnlyKVStore) (weave.Options, error) {
+	objs, err := NewBucket().All(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load usernames")
+	}
+	tokens := make([]genesisToken, 0, len(objs))
+	for _, obj := range objs {
+		u, ok := obj.Value().(*UsernameToken)
+		if !ok {
+			return nil, errors.ErrInvalidModel.New("unknown username type")
+		}
+		var addresses []genesisAddress
+		for _, a := range u.GetChainAddresses() {
+			addresses = append(addresses, genesisAddress{
+				BlockchainID: string(a.BlockchainID),
+				Address:      a.Address,
+			})
+		}
+		tokens = append(tokens, genesisToken{
+			ID:        string(obj.Key()),
+			Owner:     u.OwnerAddress(),
+			Approvals: u.GetBase().ActionApprovals,
+			Addresses: addresses,
+		})
+	}
+	opts := weave.Options{}
+	return opts, opts.WriteOptions("usernames", tokens)
+}
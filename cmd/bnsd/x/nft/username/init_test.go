@@ -0,0 +1,72 @@
+package username_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/cmd/bnsd/x/nft/username"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/x/nft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesisExport(t *testing.T) {
+	nft.RegisterAction(nft.DefaultActions...)
+
+	const genesis = `
+		{
+			"usernames": [
+				{
+					"id": "alice@example.com",
+					"owner": "E94323317C46BDA2268FA3698BAF4F95B893E8C7",
+					"approvals": [
+						{
+							"action": "ActionUpdateDetails",
+							"approvals": [
+								{"address": "/lUm3ggzff71z0XvPtjFd7hU3jQ=", "options": {"count": 3}}
+							]
+						}
+					],
+					"addresses": [
+						{"blockchain_id": "myNet", "address": "aliceChainAddress"}
+					]
+				},
+				{
+					"id": "bob@example.com",
+					"owner": "FE5526DE08337DFEF5CF45EF3ED8C577B854DE34"
+				}
+			]
+		}
+	`
+
+	var opts weave.Options
+	require.NoError(t, json.Unmarshal([]byte(genesis), &opts))
+
+	var ini username.Initializer
+	db := store.MemStore()
+	require.NoError(t, ini.FromGenesis(opts, db))
+
+	obj, err := username.NewBucket().Get(db, []byte("alice@example.com"))
+	require.NoError(t, err)
+	require.NotNil(t, obj)
+	u, err := username.AsUsername(obj)
+	require.NoError(t, err)
+	assert.Equal(t, []username.ChainAddress{{BlockchainID: []byte("myNet"), Address: "aliceChainAddress"}}, u.GetChainAddresses())
+	assert.Len(t, u.Approvals().List().ForAction(nft.UpdateDetails), 1)
+
+	exported, err := ini.ToGenesis(db)
+	require.NoError(t, err)
+
+	// the export can be loaded into a fresh store and exported again
+	db2 := store.MemStore()
+	require.NoError(t, ini.FromGenesis(exported, db2))
+	again, err := ini.ToGenesis(db2)
+	require.NoError(t, err)
+	assert.Equal(t, exported, again)
+
+	var tokens []map[string]interface{}
+	require.NoError(t, exported.ReadOptions("usernames", &tokens))
+	assert.Len(t, tokens, 2)
+}
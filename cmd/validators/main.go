@@ -13,10 +13,12 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/iov-one/weave/cmd/bnsd/app"
 	"github.com/iov-one/weave/cmd/bnsd/client"
 	"github.com/iov-one/weave/crypto"
+	"github.com/iov-one/weave/x/sigs"
 	"github.com/iov-one/weave/x/validators"
 )
 
@@ -142,6 +144,8 @@ Create a new validator update request with multi signature authentication.
 Created request is binary serialized and written to standard output.
 
 Returned request must be signed by other parties before it can be submitted.
+Use -valid-until-height or -valid-until-time to limit how long the collected
+signatures can be used.
 
 `)
 		fl.PrintDefaults()
@@ -150,6 +154,8 @@ Returned request must be signed by other parties before it can be submitted.
 		pubKeyFl       = fl.String("pubkey", "", "Base64 encoded, ed25519 public key.")
 		multisigAddrFl = fl.String("multisig", "", "Address of the multisig contract that this request will authenticate with.")
 		powerFl        = fl.Int64("power", 10, "Validator node power. Set to 0 to delete a node.")
+		heightFl       = fl.Int64("valid-until-height", 0, "Last block height the transaction can be included in. Zero means no limit.")
+		timeFl         = fl.String("valid-until-time", "", "Latest block time the transaction can be included at, in RFC3339 format. Empty means no limit.")
 	)
 	fl.Parse(args)
 
@@ -165,6 +171,18 @@ Returned request must be signed by other parties before it can be submitted.
 		return errors.New("multisig address is required")
 	}
 
+	validUntil := &sigs.ValidUntil{Height: *heightFl}
+	if *timeFl != "" {
+		t, err := time.Parse(time.RFC3339, *timeFl)
+		if err != nil {
+			return fmt.Errorf("cannot parse valid until time: %s", err)
+		}
+		validUntil.Time = t.Unix()
+	}
+	if err := validUntil.Validate(); err != nil {
+		return err
+	}
+
 	addValidatorTx := client.SetValidatorTx(
 		&validators.ValidatorUpdate{
 			Pubkey: validators.Pubkey{
@@ -174,6 +192,9 @@ Returned request must be signed by other parties before it can be submitted.
 			Power: *powerFl,
 		},
 	)
+	if !validUntil.IsZero() {
+		addValidatorTx.ValidUntil = validUntil
+	}
 
 	raw, err := addValidatorTx.Marshal()
 	if err != nil {
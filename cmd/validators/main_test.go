@@ -7,7 +7,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+
+	"github.com/iov-one/weave/cmd/bnsd/app"
+	"github.com/iov-one/weave/x/sigs"
 )
 
 func TestMultisig(t *testing.T) {
@@ -58,6 +62,49 @@ func TestMultisig(t *testing.T) {
 	}
 }
 
+func TestMultisigValidUntil(t *testing.T) {
+	cases := map[string]struct {
+		args []string
+		want *sigs.ValidUntil
+	}{
+		"no limit": {
+			want: nil,
+		},
+		"height": {
+			args: []string{"-valid-until-height", "1000"},
+			want: &sigs.ValidUntil{Height: 1000},
+		},
+		"time": {
+			args: []string{"-valid-until-time", "2019-06-01T12:00:00Z"},
+			want: &sigs.ValidUntil{Time: 1559390400},
+		},
+		"height and time": {
+			args: []string{"-valid-until-height", "1000", "-valid-until-time", "2019-06-01T12:00:00Z"},
+			want: &sigs.ValidUntil{Height: 1000, Time: 1559390400},
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			var out bytes.Buffer
+			args := append([]string{
+				"-pubkey", "j4JRVstX",
+				"-multisig", "5AE2C58796B0AD48FFE7602EAC3353488C859A2B",
+			}, tc.args...)
+			if err := cmdMultisigNew(nil, &out, args); err != nil {
+				t.Fatalf("cannot create a multisig request: %s", err)
+			}
+			var tx app.Tx
+			if err := tx.Unmarshal(out.Bytes()); err != nil {
+				t.Fatalf("cannot unmarshal tx: %s", err)
+			}
+			if !reflect.DeepEqual(tc.want, tx.ValidUntil) {
+				t.Fatalf("want valid until %v, got %v", tc.want, tx.ValidUntil)
+			}
+		})
+	}
+}
+
 func newTendermintServer(t *testing.T) *httptest.Server {
 	t.Helper()
 
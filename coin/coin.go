@@ -97,7 +97,7 @@ func (c Coin) Multiply(times int64) (Coin, error) {
 	}
 
 	// Normalize if fractional value overflows.
-	if frac > FracUnit {
+	if frac >= FracUnit {
 		if n := whole + frac/FracUnit; n < whole {
 			return Coin{}, errors.ErrOverflow
 		} else {
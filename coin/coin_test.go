@@ -380,6 +380,11 @@ func TestCoinMultiply(t *testing.T) {
 			times: 3,
 			want:  NewCoin(1, FracUnit/2, "DOGE"),
 		},
+		"multiply to exactly one whole": {
+			coin:  NewCoin(0, FracUnit/100, "DOGE"),
+			times: 100,
+			want:  NewCoin(1, 0, "DOGE"),
+		},
 		"multiply zero times": {
 			coin:  NewCoin(1, 1, "DOGE"),
 			times: 0,
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/iov-one/weave"
+)
+
+const (
+	flagOut = "out"
+)
+
+type exportArgs struct {
+	dbPath string
+	height int
+	out    string
+}
+
+func parseExportArgs(args []string) (exportArgs, error) {
+	if len(args) == 0 {
+		return exportArgs{}, fmt.Errorf("Usage: cmd export <path to abci.db> [-height=H] [-out=file]")
+	}
+	res := exportArgs{dbPath: args[0]}
+	exportFlags := flag.NewFlagSet("export", flag.ExitOnError)
+	exportFlags.IntVar(&res.height, flagHeight, 0, "height of the state to export (default latest)")
+	exportFlags.StringVar(&res.out, flagOut, "", "file to write the app_state to (default stdout)")
+	err := exportFlags.Parse(args[1:])
+	return res, err
+}
+
+// ExportCmd reads the state of a stopped node and writes it as json,
+// in the format expected by app_state in genesis.json.
+// It takes the last committed state unless -height is explicitly specified
+// It writes the json to stdout unless -out is specified
+func ExportCmd(exporter weave.Exporter, logger log.Logger, home string, args []string) error {
+	flags, err := parseExportArgs(args)
+	if err != nil {
+		return err
+	}
+
+	kv, _, err := loadStore(flags.dbPath, flags.height, true)
+	if err != nil {
+		return fmt.Errorf("error reading abci data: %s", err)
+	}
+
+	appState, err := exporter.ToGenesis(kv.CacheWrap())
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(appState, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if flags.out == "" {
+		_, err = fmt.Fprintln(os.Stdout, string(out))
+		return err
+	}
+	return ioutil.WriteFile(flags.out, out, 0644)
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/iov-one/weave"
+)
+
+const (
+	flagPrefix = "prefix"
+	flagLimit  = "limit"
+	flagRefs   = "refs"
+)
+
+// ModelInspector decodes the stored values for InspectCmd,
+// see orm.ModelRegistry
+type ModelInspector interface {
+	weave.ModelRenderer
+	// References returns the keys of the models referenced
+	// by an index entry
+	References(key, value []byte) ([][]byte, error)
+}
+
+type inspectArgs struct {
+	dbPath string
+	height int
+	prefix string
+	limit  int
+	refs   bool
+}
+
+func parseInspectArgs(args []string) (inspectArgs, error) {
+	if len(args) == 0 {
+		return inspectArgs{}, fmt.Errorf("Usage: cmd inspect <path to abci.db> [-height=H] [-prefix=P] [-limit=N] [-refs]")
+	}
+	res := inspectArgs{dbPath: args[0]}
+	inspectFlags := flag.NewFlagSet("inspect", flag.ExitOnError)
+	inspectFlags.IntVar(&res.height, flagHeight, 0, "height of the state to inspect (default latest)")
+	inspectFlags.StringVar(&res.prefix, flagPrefix, "", "dump all keys starting with it, eg. cash: (default list the buckets)")
+	inspectFlags.IntVar(&res.limit, flagLimit, 100, "maximum number of keys to dump, 0 dumps all")
+	inspectFlags.BoolVar(&res.refs, flagRefs, false, "print the models referenced by index entries")
+	err := inspectFlags.Parse(args[1:])
+	return res, err
+}
+
+// InspectCmd reads the state of a stopped node at any retained height
+// and prints its root hash. It lists the buckets with the number of
+// keys in them, or dumps the keys starting with -prefix. Known models
+// are rendered as JSON, index entries can be followed with -refs.
+//
+// The database is opened read-only and never written.
+func InspectCmd(models ModelInspector, logger log.Logger, home string, args []string) error {
+	flags, err := parseInspectArgs(args)
+	if err != nil {
+		return err
+	}
+
+	kv, ver, err := loadStore(flags.dbPath, flags.height, true)
+	if err != nil {
+		return fmt.Errorf("error reading abci data: %s", err)
+	}
+	view, err := kv.ReadOnlyVersion(ver)
+	if err != nil {
+		return fmt.Errorf("error reading abci data: %s", err)
+	}
+	fmt.Printf("Height: %d\n", ver)
+	fmt.Printf("App Hash: %X\n", kv.LatestVersion().Hash)
+
+	if flags.prefix == "" {
+		return listBuckets(os.Stdout, view)
+	}
+	return dumpPrefix(os.Stdout, view, models, flags)
+}
+
+// listBuckets prints every key prefix up to the first colon, with the
+// number of keys starting with it
+func listBuckets(w io.Writer, db weave.ReadOnlyKVStore) error {
+	counts := make(map[string]int)
+	it := db.Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		key := it.Key()
+		bucket := "<other>"
+		if i := bytes.IndexByte(key, ':'); i >= 0 && isPrintable(key[:i]) {
+			bucket = string(key[:i+1])
+		}
+		counts[bucket]++
+	}
+	it.Close()
+	buckets := make([]string, 0, len(counts))
+	for b := range counts {
+		buckets = append(buckets, b)
+	}
+	sort.Strings(buckets)
+	for _, b := range buckets {
+		if _, err := fmt.Fprintf(w, "%-32s %d\n", b, counts[b]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dumpPrefix prints all keys starting with the prefix and their values,
+// followed by the referenced models if requested
+func dumpPrefix(w io.Writer, db weave.ReadOnlyKVStore, models ModelInspector, flags inspectArgs) error {
+	prefix := []byte(flags.prefix)
+	var count int
+	it := db.Iterator(prefix, nil)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		key, value := it.Key(), it.Value()
+		if !bytes.HasPrefix(key, prefix) || (flags.limit > 0 && count == flags.limit) {
+			break
+		}
+		count++
+		if err := printModel(w, models, "", key, value); err != nil {
+			return err
+		}
+		if !flags.refs {
+			continue
+		}
+		refs, err := models.References(key, value)
+		if err != nil {
+			// not an index entry
+			continue
+		}
+		for _, ref := range refs {
+			if err := printModel(w, models, "  -> ", ref, db.Get(ref)); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d keys\n", count)
+	return err
+}
+
+// printModel prints the key and the value, rendered as JSON if possible
+func printModel(w io.Writer, models ModelInspector, indent string, key, value []byte) error {
+	if _, err := fmt.Fprintf(w, "%s%s\n", indent, formatKey(key)); err != nil {
+		return err
+	}
+	// the value is indented below the key
+	indent = strings.Repeat(" ", len(indent)+2)
+	var err error
+	switch raw, rerr := models.RenderJSON(key, value); {
+	case value == nil:
+		_, err = fmt.Fprintf(w, "%s<missing>\n", indent)
+	case rerr == nil:
+		_, err = fmt.Fprintf(w, "%s%s\n", indent, raw)
+	default:
+		_, err = fmt.Fprintf(w, "%s%X\n", indent, value)
+	}
+	return err
+}
+
+// formatKey keeps a readable prefix up to the first colon,
+// and hex encodes the rest
+func formatKey(key []byte) string {
+	if i := bytes.IndexByte(key, ':'); i >= 0 && isPrintable(key[:i]) {
+		return fmt.Sprintf("%s%X", key[:i+1], key[i+1:])
+	}
+	return fmt.Sprintf("%X", key)
+}
+
+func isPrintable(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iov-one/weave/orm"
+	"github.com/iov-one/weave/store"
+)
+
+// inspectStore holds two counters indexed by their owner and some
+// keys of unknown buckets
+func inspectStore(t *testing.T) (store.CacheableKVStore, orm.ModelRegistry) {
+	t.Helper()
+	byOwner := func(obj orm.Object) ([]byte, error) {
+		return obj.Key()[:1], nil
+	}
+	bucket := orm.NewBucket("cnts", orm.NewSimpleObj(nil, new(orm.Counter))).
+		WithIndex("owner", byOwner, false)
+	models := orm.NewModelRegistry()
+	bucket.RegisterModel(models)
+
+	db := store.MemStore()
+	require.NoError(t, bucket.Save(db, orm.NewSimpleObj([]byte("a1"), orm.NewCounter(5))))
+	require.NoError(t, bucket.Save(db, orm.NewSimpleObj([]byte("a2"), orm.NewCounter(7))))
+	db.Set([]byte("conf:x"), []byte("raw"))
+	db.Set([]byte{0xff, ':', 0x01}, []byte("binary"))
+	return db, models
+}
+
+func TestListBuckets(t *testing.T) {
+	db, _ := inspectStore(t)
+	var out bytes.Buffer
+	require.NoError(t, listBuckets(&out, db))
+	want := "" +
+		"<other>                          1\n" +
+		"_i.cnts_owner:                   1\n" +
+		"cnts:                            2\n" +
+		"conf:                            1\n"
+	assert.Equal(t, want, out.String())
+}
+
+func TestDumpPrefix(t *testing.T) {
+	db, models := inspectStore(t)
+
+	cases := map[string]struct {
+		flags inspectArgs
+		want  string
+	}{
+		"models": {
+			flags: inspectArgs{prefix: "cnts:"},
+			want: "cnts:6131\n  {\"count\":5}\n" +
+				"cnts:6132\n  {\"count\":7}\n" +
+				"2 keys\n",
+		},
+		"limit": {
+			flags: inspectArgs{prefix: "cnts:", limit: 1},
+			want:  "cnts:6131\n  {\"count\":5}\n1 keys\n",
+		},
+		"unknown bucket": {
+			flags: inspectArgs{prefix: "conf:"},
+			want:  "conf:78\n  726177\n1 keys\n",
+		},
+		"index references": {
+			flags: inspectArgs{prefix: "_i.", refs: true},
+			want: "_i.cnts_owner:61\n  0A0261310A026132\n" +
+				"  -> cnts:6131\n       {\"count\":5}\n" +
+				"  -> cnts:6132\n       {\"count\":7}\n" +
+				"1 keys\n",
+		},
+		"empty prefix": {
+			flags: inspectArgs{prefix: "zzz"},
+			want:  "0 keys\n",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, dumpPrefix(&out, db, models, tc.flags))
+			assert.Equal(t, tc.want, out.String())
+		})
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io/ioutil"
 
-	"github.com/tendermint/iavl"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/types"
@@ -78,7 +77,7 @@ func RetryCmd(makeApp InlineAppGenerator, logger log.Logger, home string, args [
 	}
 
 	fmt.Println("--> Loading Database")
-	tree, ver, err := readTree(flags.dbPath, 0)
+	kv, ver, err := loadStore(flags.dbPath, 0, false)
 	if err != nil {
 		return fmt.Errorf("error reading abci data: %s", err)
 	}
@@ -88,34 +87,44 @@ func RetryCmd(makeApp InlineAppGenerator, logger log.Logger, home string, args [
 	}
 
 	builder := wrapInlineAppGenerator(makeApp, logger, flags.debug)
-	return retryBlock(builder, tree, block, flags.untilError, flags.maxTries)
+	return retryBlock(builder, kv, block, flags.untilError, flags.maxTries)
 }
 
-func readTree(dir string, version int) (*iavl.MutableTree, int64, error) {
-	db, err := openDb(dir)
+// loadStore opens the store of a stopped node the same way the app
+// does, at the given version or the latest one if zero
+func loadStore(dbPath string, version int, readOnly bool) (iavlstore.Store, int64, error) {
+	open := iavlstore.OpenStore
+	if readOnly {
+		open = iavlstore.OpenReadOnlyStore
+	}
+	kv, err := open(dbPath)
 	if err != nil {
 		return nil, 0, err
 	}
-	tree := iavl.NewMutableTree(db, 10000) // cache size 10000
-	ver, err := tree.LoadVersion(int64(version))
+	if version != 0 {
+		if err := kv.LoadVersion(int64(version)); err != nil {
+			return nil, 0, err
+		}
+	}
+	ver := kv.LatestVersion().Version
 	if ver == 0 {
 		return nil, 0, fmt.Errorf("iavl tree is empty")
 	}
-	return tree, ver, err
+	return kv, ver, nil
 }
 
-func retryBlock(builder appBuilder, tree *iavl.MutableTree, block *types.Block, untilError bool, maxTries int) error {
+func retryBlock(builder appBuilder, kv iavlstore.Store, block *types.Block, untilError bool, maxTries int) error {
 	fmt.Printf("Original Height: %d\n", block.Header.Height)
-	fmt.Printf("Original Hash: %X\n", tree.Hash())
+	fmt.Printf("Original Hash: %X\n", kv.LatestVersion().Hash)
 
-	same, err := rerunBlock(builder, tree, block)
+	same, err := rerunBlock(builder, kv, block)
 	if err != nil {
 		return err
 	}
 
 	for same && untilError && maxTries > 0 {
 		maxTries--
-		same, err = rerunBlock(builder, tree, block)
+		same, err = rerunBlock(builder, kv, block)
 		if err != nil {
 			return err
 		}
@@ -124,18 +133,17 @@ func retryBlock(builder appBuilder, tree *iavl.MutableTree, block *types.Block,
 	return nil
 }
 
-func rerunBlock(builder appBuilder, tree *iavl.MutableTree, block *types.Block) (bool, error) {
-	origHash := tree.Hash()
+func rerunBlock(builder appBuilder, kv iavlstore.Store, block *types.Block) (bool, error) {
+	origHash := kv.LatestVersion().Hash
 	backHeight := block.Header.Height - 1
 
 	fmt.Printf("Rollback to height: %d\n", backHeight)
-	_, err := tree.LoadVersionForOverwriting(backHeight)
+	err := kv.Rollback(backHeight)
 	if err != nil {
 		return false, err
 	}
 
 	// run this block....
-	kv := iavlstore.NewCommitStoreFromTree(tree)
 	app := builder(kv)
 
 	fmt.Println("---> Begin Block")
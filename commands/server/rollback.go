@@ -0,0 +1,59 @@
+package server
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+type rollbackArgs struct {
+	dbPath string
+	height int
+}
+
+func parseRollbackArgs(args []string) (rollbackArgs, error) {
+	if len(args) == 0 {
+		return rollbackArgs{}, fmt.Errorf("Usage: cmd rollback <path to abci.db> -height=H")
+	}
+	res := rollbackArgs{dbPath: args[0]}
+	rollbackFlags := flag.NewFlagSet("rollback", flag.ExitOnError)
+	rollbackFlags.IntVar(&res.height, flagHeight, 0, "height of the state to keep")
+	if err := rollbackFlags.Parse(args[1:]); err != nil {
+		return res, err
+	}
+	if res.height <= 0 {
+		return res, fmt.Errorf("-height must be set to a positive number")
+	}
+	return res, nil
+}
+
+// RollbackCmd deletes all app state of a stopped node above the given
+// -height. On the next start, tendermint replays all later blocks from
+// its block store, so they are executed again by the current binary.
+//
+// The store is opened with the options of the app, a memdb snapshot
+// is written again. This only works if no version above the height
+// was pruned.
+func RollbackCmd(logger log.Logger, home string, args []string) error {
+	flags, err := parseRollbackArgs(args)
+	if err != nil {
+		return err
+	}
+
+	kv, ver, err := loadStore(flags.dbPath, 0, false)
+	if err != nil {
+		return fmt.Errorf("error reading abci data: %s", err)
+	}
+	if int64(flags.height) > ver {
+		return fmt.Errorf("Cannot rollback to height %d, abcistore=%d", flags.height, ver)
+	}
+
+	if err := kv.Rollback(int64(flags.height)); err != nil {
+		return err
+	}
+	id := kv.LatestVersion()
+	fmt.Printf("Rolled back from height %d to %d\n", ver, id.Version)
+	fmt.Printf("App Hash: %X\n", id.Hash)
+	return nil
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	iavlstore "github.com/iov-one/weave/store/iavl"
+)
+
+func TestRollbackMemDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rollback-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	options := `{"backend": "memdb", "snapshot_interval": 1}`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, iavlstore.OptionsFile), []byte(options), 0644))
+	dbPath := filepath.Join(dir, "abci.db")
+
+	kv, err := iavlstore.OpenStore(dbPath)
+	require.NoError(t, err)
+	var ids []int64
+	for _, v := range []string{"one", "two", "three"} {
+		db := kv.CacheWrap()
+		db.Set([]byte("key"), []byte(v))
+		db.Write()
+		ids = append(ids, kv.Commit().Version)
+	}
+	require.Equal(t, []int64{1, 2, 3}, ids)
+
+	require.NoError(t, RollbackCmd(log.NewNopLogger(), dir, []string{dbPath, "-height=1"}))
+
+	// the snapshot holds the state of height 1
+	kv, err = iavlstore.OpenStore(dbPath)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), kv.LatestVersion().Version)
+	assert.Equal(t, []byte("one"), kv.Get([]byte("key")))
+
+	err = RollbackCmd(log.NewNopLogger(), dir, []string{dbPath, "-height=2"})
+	assert.Error(t, err)
+}
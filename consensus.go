@@ -0,0 +1,177 @@
+package weave
+
+import (
+	"github.com/iov-one/weave/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// _wv: is a prefix for weave internal data
+const (
+	validatorKeyPrefix = "_wv:val:"
+	consensusParamsKey = "_wv:consensus"
+)
+
+// GetValidators returns the current validator set, as initialized in
+// InitChain and updated with every diff returned from EndBlock.
+// Validators are ordered by their public key.
+func GetValidators(db ReadOnlyKVStore) ([]abci.ValidatorUpdate, error) {
+	start := []byte(validatorKeyPrefix)
+	end := []byte(validatorKeyPrefix)
+	end[len(end)-1]++
+
+	var vals []abci.ValidatorUpdate
+	it := db.Iterator(start, end)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		var v abci.ValidatorUpdate
+		if err := v.Unmarshal(it.Value()); err != nil {
+			return nil, errors.Wrap(err, "cannot unmarshal validator")
+		}
+		vals = append(vals, v)
+	}
+	return vals, nil
+}
+
+// UpdateValidators applies the diff to the stored validator set.
+// Validators with no power are removed from it.
+func UpdateValidators(db KVStore, diff []abci.ValidatorUpdate) error {
+	for _, d := range diff {
+		if d.Power < 0 {
+			return errors.ErrInvalidInput.Newf("validator power: %d", d.Power)
+		}
+		key := validatorKey(d.PubKey)
+		if d.Power == 0 {
+			db.Delete(key)
+			continue
+		}
+		raw, err := d.Marshal()
+		if err != nil {
+			return errors.Wrap(err, "cannot marshal validator")
+		}
+		db.Set(key, raw)
+	}
+	return nil
+}
+
+// pubKeySizes are the sizes of the public key types known to tendermint
+var pubKeySizes = map[string]int{
+	"ed25519":   32,
+	"secp256k1": 33,
+}
+
+// maxBlockSizeBytes is the largest block size tendermint accepts
+const maxBlockSizeBytes = 104857600
+
+// ValidateValidatorUpdates ensures tendermint accepts the diff,
+// so it can be rejected before it is returned from EndBlock
+func ValidateValidatorUpdates(diff []abci.ValidatorUpdate) error {
+	for i, d := range diff {
+		if d.Power < 0 {
+			return errors.ErrInvalidInput.Newf("validator #%d power: %d", i, d.Power)
+		}
+		size, ok := pubKeySizes[d.PubKey.Type]
+		if !ok {
+			return errors.ErrInvalidInput.Newf("validator #%d pubkey type: %q", i, d.PubKey.Type)
+		}
+		if len(d.PubKey.Data) != size {
+			return errors.ErrInvalidInput.Newf("validator #%d pubkey size: %d", i, len(d.PubKey.Data))
+		}
+	}
+	return nil
+}
+
+// ValidateConsensusParams ensures tendermint accepts all sections set
+// in the update, so it can be rejected before it is returned from EndBlock
+func ValidateConsensusParams(update *abci.ConsensusParams) error {
+	if update == nil {
+		return nil
+	}
+	if b := update.BlockSize; b != nil {
+		if b.MaxBytes <= 0 || b.MaxBytes > maxBlockSizeBytes {
+			return errors.ErrInvalidInput.Newf("block max bytes: %d", b.MaxBytes)
+		}
+		if b.MaxGas < -1 {
+			return errors.ErrInvalidInput.Newf("block max gas: %d", b.MaxGas)
+		}
+	}
+	if e := update.Evidence; e != nil && e.MaxAge <= 0 {
+		return errors.ErrInvalidInput.Newf("evidence max age: %d", e.MaxAge)
+	}
+	if v := update.Validator; v != nil {
+		if len(v.PubKeyTypes) == 0 {
+			return errors.ErrInvalidInput.New("no validator pubkey types")
+		}
+		for _, t := range v.PubKeyTypes {
+			if _, ok := pubKeySizes[t]; !ok {
+				return errors.ErrInvalidInput.Newf("validator pubkey type: %q", t)
+			}
+		}
+	}
+	return nil
+}
+
+func validatorKey(pk abci.PubKey) []byte {
+	key := make([]byte, 0, len(validatorKeyPrefix)+len(pk.Type)+1+len(pk.Data))
+	key = append(key, validatorKeyPrefix...)
+	key = append(key, pk.Type...)
+	key = append(key, ':')
+	return append(key, pk.Data...)
+}
+
+// GetConsensusParams returns the consensus params set in InitChain
+// and updated from EndBlock, or nil if none were ever set
+func GetConsensusParams(db ReadOnlyKVStore) (*abci.ConsensusParams, error) {
+	raw := db.Get([]byte(consensusParamsKey))
+	if raw == nil {
+		return nil, nil
+	}
+	var params abci.ConsensusParams
+	if err := params.Unmarshal(raw); err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal consensus params")
+	}
+	return &params, nil
+}
+
+// UpdateConsensusParams merges the update into the stored consensus params.
+// Only sections set in the update are replaced, like tendermint does.
+func UpdateConsensusParams(db KVStore, update *abci.ConsensusParams) error {
+	if update == nil {
+		return nil
+	}
+	params, err := GetConsensusParams(db)
+	if err != nil {
+		return err
+	}
+	if params == nil {
+		params = &abci.ConsensusParams{}
+	}
+	params = MergeConsensusParams(params, update)
+	raw, err := params.Marshal()
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal consensus params")
+	}
+	db.Set([]byte(consensusParamsKey), raw)
+	return nil
+}
+
+// MergeConsensusParams returns the params with all sections
+// set in the update replaced. Either may be nil.
+func MergeConsensusParams(params, update *abci.ConsensusParams) *abci.ConsensusParams {
+	if update == nil {
+		return params
+	}
+	if params == nil {
+		params = &abci.ConsensusParams{}
+	}
+	merged := *params
+	if update.BlockSize != nil {
+		merged.BlockSize = update.BlockSize
+	}
+	if update.Evidence != nil {
+		merged.Evidence = update.Evidence
+	}
+	if update.Validator != nil {
+		merged.Validator = update.Validator
+	}
+	return &merged
+}
@@ -0,0 +1,129 @@
+package weave_test
+
+import (
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestUpdateValidators(t *testing.T) {
+	db := store.MemStore()
+	vals, err := weave.GetValidators(db)
+	require.NoError(t, err)
+	assert.Empty(t, vals)
+
+	a := abci.ValidatorUpdate{PubKey: abci.PubKey{Type: "ed25519", Data: []byte{1}}, Power: 5}
+	b := abci.ValidatorUpdate{PubKey: abci.PubKey{Type: "ed25519", Data: []byte{2}}, Power: 7}
+	require.NoError(t, weave.UpdateValidators(db, []abci.ValidatorUpdate{b, a}))
+	vals, err = weave.GetValidators(db)
+	require.NoError(t, err)
+	assert.Equal(t, []abci.ValidatorUpdate{a, b}, vals)
+
+	// zero power removes, other values replace the power
+	a.Power = 0
+	b.Power = 9
+	require.NoError(t, weave.UpdateValidators(db, []abci.ValidatorUpdate{a, b}))
+	vals, err = weave.GetValidators(db)
+	require.NoError(t, err)
+	assert.Equal(t, []abci.ValidatorUpdate{b}, vals)
+
+	b.Power = -1
+	assert.Error(t, weave.UpdateValidators(db, []abci.ValidatorUpdate{b}))
+}
+
+func TestUpdateConsensusParams(t *testing.T) {
+	db := store.MemStore()
+	params, err := weave.GetConsensusParams(db)
+	require.NoError(t, err)
+	assert.Nil(t, params)
+
+	require.NoError(t, weave.UpdateConsensusParams(db, &abci.ConsensusParams{
+		BlockSize: &abci.BlockSizeParams{MaxBytes: 100, MaxGas: 10},
+		Evidence:  &abci.EvidenceParams{MaxAge: 20},
+	}))
+	require.NoError(t, weave.UpdateConsensusParams(db, &abci.ConsensusParams{
+		Evidence: &abci.EvidenceParams{MaxAge: 30},
+	}))
+	require.NoError(t, weave.UpdateConsensusParams(db, nil))
+
+	params, err = weave.GetConsensusParams(db)
+	require.NoError(t, err)
+	assert.Equal(t, &abci.BlockSizeParams{MaxBytes: 100, MaxGas: 10}, params.BlockSize)
+	assert.Equal(t, &abci.EvidenceParams{MaxAge: 30}, params.Evidence)
+	assert.Nil(t, params.Validator)
+}
+
+func TestValidateValidatorUpdates(t *testing.T) {
+	ed := abci.PubKey{Type: "ed25519", Data: make([]byte, 32)}
+	cases := map[string]struct {
+		diff    []abci.ValidatorUpdate
+		wantErr bool
+	}{
+		"empty":          {},
+		"valid":          {diff: []abci.ValidatorUpdate{{PubKey: ed, Power: 10}, {PubKey: ed, Power: 0}}},
+		"negative power": {diff: []abci.ValidatorUpdate{{PubKey: ed, Power: -1}}, wantErr: true},
+		"unknown type": {
+			diff:    []abci.ValidatorUpdate{{PubKey: abci.PubKey{Type: "rsa", Data: make([]byte, 32)}, Power: 1}},
+			wantErr: true,
+		},
+		"wrong size": {
+			diff:    []abci.ValidatorUpdate{{PubKey: abci.PubKey{Type: "ed25519", Data: []byte{1}}, Power: 1}},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := weave.ValidateValidatorUpdates(tc.diff)
+			assert.Equal(t, tc.wantErr, err != nil, "%v", err)
+		})
+	}
+}
+
+func TestValidateConsensusParams(t *testing.T) {
+	cases := map[string]struct {
+		params  *abci.ConsensusParams
+		wantErr bool
+	}{
+		"nil":   {},
+		"empty": {params: &abci.ConsensusParams{}},
+		"valid": {params: &abci.ConsensusParams{
+			BlockSize: &abci.BlockSizeParams{MaxBytes: 1000, MaxGas: -1},
+			Evidence:  &abci.EvidenceParams{MaxAge: 100},
+			Validator: &abci.ValidatorParams{PubKeyTypes: []string{"ed25519"}},
+		}},
+		"zero max bytes": {
+			params:  &abci.ConsensusParams{BlockSize: &abci.BlockSizeParams{MaxBytes: 0}},
+			wantErr: true,
+		},
+		"max bytes too big": {
+			params:  &abci.ConsensusParams{BlockSize: &abci.BlockSizeParams{MaxBytes: 1 << 40}},
+			wantErr: true,
+		},
+		"invalid max gas": {
+			params:  &abci.ConsensusParams{BlockSize: &abci.BlockSizeParams{MaxBytes: 1000, MaxGas: -2}},
+			wantErr: true,
+		},
+		"zero evidence age": {
+			params:  &abci.ConsensusParams{Evidence: &abci.EvidenceParams{}},
+			wantErr: true,
+		},
+		"no pubkey types": {
+			params:  &abci.ConsensusParams{Validator: &abci.ValidatorParams{}},
+			wantErr: true,
+		},
+		"unknown pubkey type": {
+			params:  &abci.ConsensusParams{Validator: &abci.ValidatorParams{PubKeyTypes: []string{"rsa"}}},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := weave.ValidateConsensusParams(tc.params)
+			assert.Equal(t, tc.wantErr, err != nil, "%v", err)
+		})
+	}
+}
@@ -38,6 +38,10 @@ const (
 	contextKeyHeight
 	contextKeyChainID
 	contextKeyLogger
+	contextKeyGasMeter
+	contextKeyEventManager
+	contextKeyCommitInfo
+	contextKeyEvidence
 )
 
 var (
@@ -85,6 +89,38 @@ func GetHeight(ctx Context) (int64, bool) {
 	return val, ok
 }
 
+// WithCommitInfo sets the votes of the last commit, as passed
+// to BeginBlock. panics if called with commit info already set
+func WithCommitInfo(ctx Context, info abci.LastCommitInfo) Context {
+	if _, ok := GetCommitInfo(ctx); ok {
+		panic("Commit info already set")
+	}
+	return context.WithValue(ctx, contextKeyCommitInfo, info)
+}
+
+// GetCommitInfo returns the votes of the last commit
+// ok is false if no commit info set in this Context
+func GetCommitInfo(ctx Context) (abci.LastCommitInfo, bool) {
+	val, ok := ctx.Value(contextKeyCommitInfo).(abci.LastCommitInfo)
+	return val, ok
+}
+
+// WithEvidence sets the evidence of byzantine validators, as passed
+// to BeginBlock. panics if called with evidence already set
+func WithEvidence(ctx Context, evidence []abci.Evidence) Context {
+	if _, ok := GetEvidence(ctx); ok {
+		panic("Evidence already set")
+	}
+	return context.WithValue(ctx, contextKeyEvidence, evidence)
+}
+
+// GetEvidence returns the evidence of byzantine validators
+// ok is false if no evidence set in this Context
+func GetEvidence(ctx Context) ([]abci.Evidence, bool) {
+	val, ok := ctx.Value(contextKeyEvidence).([]abci.Evidence)
+	return val, ok
+}
+
 // WithChainID sets the chain id for the Context.
 // panics if called with chain id already set
 func WithChainID(ctx Context, chainID string) Context {
@@ -129,3 +165,29 @@ func WithLogInfo(ctx Context, keyvals ...interface{}) Context {
 	logger := GetLogger(ctx).With(keyvals...)
 	return WithLogger(ctx, logger)
 }
+
+// WithGasMeter sets the gas meter that measures the work done
+// while processing the current transaction
+func WithGasMeter(ctx Context, meter GasMeter) Context {
+	return context.WithValue(ctx, contextKeyGasMeter, meter)
+}
+
+// GetGasMeter returns the gas meter of the current transaction,
+// or false if no gas is metered
+func GetGasMeter(ctx Context) (GasMeter, bool) {
+	val, ok := ctx.Value(contextKeyGasMeter).(GasMeter)
+	return val, ok
+}
+
+// WithEventManager sets the manager collecting the events
+// emitted while processing the current transaction
+func WithEventManager(ctx Context, m *EventManager) Context {
+	return context.WithValue(ctx, contextKeyEventManager, m)
+}
+
+// GetEventManager returns the event manager of the current transaction,
+// or false if events are not collected
+func GetEventManager(ctx Context) (*EventManager, bool) {
+	val, ok := ctx.Value(contextKeyEventManager).(*EventManager)
+	return val, ok
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/iov-one/weave"
 	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -47,6 +48,28 @@ func TestContext(t *testing.T) {
 	// TODO: test header context!
 }
 
+func TestBlockEvidenceContext(t *testing.T) {
+	ctx := context.Background()
+
+	_, ok := weave.GetEvidence(ctx)
+	assert.False(t, ok)
+	evidence := []abci.Evidence{{Type: "duplicate/vote", Height: 4}}
+	ctx = weave.WithEvidence(ctx, evidence)
+	got, ok := weave.GetEvidence(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, evidence, got)
+	assert.Panics(t, func() { weave.WithEvidence(ctx, nil) })
+
+	_, ok = weave.GetCommitInfo(ctx)
+	assert.False(t, ok)
+	info := abci.LastCommitInfo{Votes: []abci.VoteInfo{{SignedLastBlock: true}}}
+	ctx = weave.WithCommitInfo(ctx, info)
+	gotInfo, ok := weave.GetCommitInfo(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, info, gotInfo)
+	assert.Panics(t, func() { weave.WithCommitInfo(ctx, info) })
+}
+
 func TestChainID(t *testing.T) {
 	cases := []struct {
 		chainID string
@@ -70,6 +70,10 @@ var (
 	// because the result value exceeds the type.
 	ErrOverflow = Register(16, "an operation cannot be completed due to value overflow")
 
+	// ErrOutOfGas is returned when a transaction consumed more gas
+	// than it was allowed to
+	ErrOutOfGas = Register(17, "out of gas")
+
 	// ErrPanic is only set when we recover from a panic, so we know to redact potentially sensitive system info
 	ErrPanic = Register(111222, "panic")
 )
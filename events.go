@@ -0,0 +1,99 @@
+package weave
+
+import (
+	"github.com/tendermint/tendermint/libs/common"
+)
+
+// EventTypeTag is the tag key every event is indexed by,
+// with the event type as the value
+const EventTypeTag = "event"
+
+// Event is a structured notification about something that happened
+// while processing a transaction, like a "cash.transfer" with the
+// attributes "from", "to" and "amount".
+//
+// Handlers add events through the context (see EmitEvents),
+// they are turned into tags of the transaction once it succeeded.
+type Event struct {
+	Type       string
+	Attributes []EventAttribute
+}
+
+// EventAttribute is a single key value pair of an event
+type EventAttribute struct {
+	Key   string
+	Value string
+}
+
+// NewEvent creates an event of the given type without attributes
+func NewEvent(typ string) Event {
+	return Event{Type: typ}
+}
+
+// With returns a copy of the event with the attribute added
+func (e Event) With(key, value string) Event {
+	attrs := make([]EventAttribute, len(e.Attributes), len(e.Attributes)+1)
+	copy(attrs, e.Attributes)
+	e.Attributes = append(attrs, EventAttribute{Key: key, Value: value})
+	return e
+}
+
+// Tags converts the event into tendermint tags. The type is
+// tagged as EventTypeTag, and every attribute as <type>.<key>,
+// so an indexer can search eg. for cash.transfer.to='AB12...'
+func (e Event) Tags() []common.KVPair {
+	tags := make([]common.KVPair, 0, len(e.Attributes)+1)
+	tags = append(tags, common.KVPair{
+		Key:   []byte(EventTypeTag),
+		Value: []byte(e.Type),
+	})
+	for _, a := range e.Attributes {
+		tags = append(tags, common.KVPair{
+			Key:   []byte(e.Type + "." + a.Key),
+			Value: []byte(a.Value),
+		})
+	}
+	return tags
+}
+
+// EventManager collects the events emitted while processing
+// a transaction or a block
+type EventManager struct {
+	events []Event
+}
+
+// NewEventManager returns an empty EventManager
+func NewEventManager() *EventManager {
+	return &EventManager{}
+}
+
+// Emit adds the events to the collected ones
+func (m *EventManager) Emit(events ...Event) {
+	m.events = append(m.events, events...)
+}
+
+// Events returns all collected events in the order they were emitted
+func (m *EventManager) Events() []Event {
+	return m.events
+}
+
+// Tags converts all collected events into tendermint tags
+func (m *EventManager) Tags() []common.KVPair {
+	var tags []common.KVPair
+	for _, e := range m.events {
+		tags = append(tags, e.Tags()...)
+	}
+	return tags
+}
+
+// EmitEvents adds the events to the EventManager of the context.
+// Events are dropped if the context has none, eg. on CheckTx,
+// or if there is no context at all, like in many handler tests.
+func EmitEvents(ctx Context, events ...Event) {
+	if ctx == nil {
+		return
+	}
+	if m, ok := GetEventManager(ctx); ok && m != nil {
+		m.Emit(events...)
+	}
+}
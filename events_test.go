@@ -0,0 +1,49 @@
+package weave_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/libs/common"
+)
+
+func TestEventTags(t *testing.T) {
+	base := weave.NewEvent("cash.transfer").With("from", "AB")
+	e1 := base.With("to", "CD")
+	e2 := base.With("to", "EF")
+
+	// attributes are not shared between copies
+	assert.Len(t, base.Attributes, 1)
+	assert.Equal(t, []common.KVPair{
+		{Key: []byte("event"), Value: []byte("cash.transfer")},
+		{Key: []byte("cash.transfer.from"), Value: []byte("AB")},
+		{Key: []byte("cash.transfer.to"), Value: []byte("CD")},
+	}, e1.Tags())
+	assert.Equal(t, []byte("EF"), e2.Tags()[2].Value)
+
+	assert.Equal(t, []common.KVPair{
+		{Key: []byte("event"), Value: []byte("ping")},
+	}, weave.NewEvent("ping").Tags())
+}
+
+func TestEmitEvents(t *testing.T) {
+	a := weave.NewEvent("a").With("k", "v")
+	b := weave.NewEvent("b")
+
+	// no manager, no events, no panic
+	weave.EmitEvents(context.Background(), a)
+	weave.EmitEvents(nil, a)
+	weave.EmitEvents(weave.WithEventManager(context.Background(), nil), a)
+
+	m := weave.NewEventManager()
+	ctx := weave.WithEventManager(context.Background(), m)
+	weave.EmitEvents(ctx, a)
+	weave.EmitEvents(ctx, b)
+
+	got, ok := weave.GetEventManager(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, []weave.Event{a, b}, got.Events())
+	assert.Equal(t, append(a.Tags(), b.Tags()...), m.Tags())
+}
@@ -38,12 +38,16 @@ func CashControl() cash.Controller {
 	return cash.NewController(cash.NewBucket())
 }
 
+// TxGasLimit is the maximum gas a single transaction may consume
+const TxGasLimit = 1000000
+
 // Chain returns a chain of decorators, to handle authentication,
 // fees, logging, and recovery
 func Chain(minFee coin.Coin, authFn x.Authenticator) app.Decorators {
 	return app.ChainDecorators(
 		utils.NewLogging(),
 		utils.NewRecovery(),
+		utils.NewGasLimiter(TxGasLimit),
 		utils.NewKeyTagger(),
 		// on CheckTx, bad tx don't affect state
 		utils.NewSavepoint().OnCheck(),
@@ -98,7 +102,7 @@ func Application(name string, h weave.Handler,
 		return app.BaseApp{}, err
 	}
 	store := app.NewStoreApp(name, kv, QueryRouter(), ctx)
-	base := app.NewBaseApp(store, tx, h, nil, debug)
+	base := app.NewBaseApp(store, tx, h, nil, nil, debug)
 	return base, nil
 }
 
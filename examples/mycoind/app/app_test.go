@@ -148,8 +148,9 @@ func TestApp(t *testing.T) {
 	hash2 := testCommit(t, myApp, 2)
 	assert.NotEqual(t, hash1, hash2)
 
-	// ensure 3 keys with proper values
-	if assert.Equal(t, 3, len(dres.Tags), "%#v", dres.Tags) {
+	// ensure 3 keys with proper values, followed by the transfer event
+	transfer := cash.TransferEvent(addr, addr2, coin.NewCoin(2000, 0, "ETH")).Tags()
+	if assert.Equal(t, 3+len(transfer), len(dres.Tags), "%#v", dres.Tags) {
 		// three keys we expect, in order
 		keys := make([][]byte, 3)
 		vals := [][]byte{[]byte("s"), []byte("s"), []byte("s")}
@@ -168,6 +169,7 @@ func TestApp(t *testing.T) {
 		assert.Equal(t, vals[0], dres.Tags[0].Value)
 		assert.Equal(t, vals[1], dres.Tags[1].Value)
 		assert.Equal(t, vals[2], dres.Tags[2].Value)
+		assert.Equal(t, transfer, dres.Tags[3:])
 	}
 
 	// Query for new balances (same key, new state)
@@ -177,6 +179,19 @@ func TestApp(t *testing.T) {
 	assert.Equal(t, int64(48000), acct2.Coins[0].Whole)
 	assert.Equal(t, int64(1234), acct2.Coins[1].Whole)
 
+	// historical queries still see the old balance
+	qres := myApp.Query(abci.RequestQuery{Path: "/", Data: key, Height: 1})
+	require.Equal(t, uint32(0), qres.Code, qres.Log)
+	assert.Equal(t, int64(1), qres.Height)
+	var acctOld cash.Set
+	require.NoError(t, app.UnmarshalOneResult(qres.Value, &acctOld))
+	require.Equal(t, 2, len(acctOld.Coins))
+	assert.Equal(t, int64(50000), acctOld.Coins[0].Whole)
+
+	// but we cannot query the future
+	qres = myApp.Query(abci.RequestQuery{Path: "/", Data: key, Height: 5})
+	assert.NotEqual(t, uint32(0), qres.Code)
+
 	// make sure money arrived safely
 	var acct3 cash.Set
 	key2 := cash.NewBucket().DBKey(addr2)
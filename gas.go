@@ -0,0 +1,17 @@
+package weave
+
+// GasMeter measures the work done while processing a transaction.
+//
+// Gas is consumed by the store (see store.NewGasStore) for every
+// access to the state, and may be consumed by handlers directly
+// for any other expensive computation.
+type GasMeter interface {
+	// ConsumeGas adds the amount to the consumed gas.
+	// It panics with errors.ErrOutOfGas once the limit is exceeded,
+	// as a KVStore has no other way to abort the transaction.
+	ConsumeGas(amount int64, descriptor string)
+	// GasConsumed returns the gas consumed so far
+	GasConsumed() int64
+	// GasLimit returns the maximum gas that can be consumed
+	GasLimit() int64
+}
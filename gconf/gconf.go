@@ -68,7 +68,7 @@ func Coin(confStore Store, propName string) coin.Coin {
 }
 
 func loadInto(confStore Store, propName string, dest interface{}) {
-	key := []byte("gconf:" + propName)
+	key := []byte(keyPrefix + propName)
 	raw := confStore.Get(key)
 	if raw == nil {
 		panic(fmt.Sprintf("cannot load %q configuration: not found", propName))
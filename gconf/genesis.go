@@ -7,6 +7,9 @@ import (
 	"github.com/iov-one/weave"
 )
 
+// keyPrefix is prepended to the names of all configuration properties
+const keyPrefix = "gconf:"
+
 // Initializer fulfils the InitStater interface to load data from
 // the genesis file
 type Initializer struct{}
@@ -41,7 +44,24 @@ func SetValue(db weave.KVStore, propName string, value interface{}) error {
 	if err != nil {
 		return fmt.Errorf("cannot serialize %s: %s", propName, err)
 	}
-	key := []byte("gconf:" + propName)
+	key := []byte(keyPrefix + propName)
 	db.Set(key, raw)
 	return nil
 }
+
+var _ weave.Exporter = Initializer{}
+
+// ToGenesis exports all configuration properties
+// in the format read by FromGenesis
+func (Initializer) ToGenesis(db weave.ReadOnlyKVStore) (weave.Options, error) {
+	conf := make(map[string]json.RawMessage)
+	// ';' is the byte following ':', ending the prefix range
+	itr := db.Iterator([]byte(keyPrefix), []byte("gconf;"))
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		name := string(itr.Key()[len(keyPrefix):])
+		conf[name] = append(json.RawMessage(nil), itr.Value()...)
+	}
+	opts := weave.Options{}
+	return opts, opts.WriteOptions("gconf", conf)
+}
@@ -36,3 +36,50 @@ func TestGenesisInitializer(t *testing.T) {
 		t.Fatalf("unexpected value: %v", got)
 	}
 }
+
+func TestGenesisExport(t *testing.T) {
+	const genesis = `
+		{
+			"gconf": {
+				"a-string": "hello",
+				"an-int": 321
+			}
+		}
+	`
+
+	var opts weave.Options
+	if err := json.Unmarshal([]byte(genesis), &opts); err != nil {
+		t.Fatalf("cannot unmarshal genesis: %s", err)
+	}
+
+	var ini Initializer
+	db := store.MemStore()
+	if err := ini.FromGenesis(opts, db); err != nil {
+		t.Fatalf("cannot load genesis: %s", err)
+	}
+	// keys sharing the prefix must not be exported
+	db.Set([]byte("gconfx"), []byte("not a property"))
+
+	exported, err := ini.ToGenesis(db)
+	if err != nil {
+		t.Fatalf("cannot export genesis: %s", err)
+	}
+
+	db2 := store.MemStore()
+	if err := ini.FromGenesis(exported, db2); err != nil {
+		t.Fatalf("cannot load exported genesis: %s", err)
+	}
+	if got := String(db2, "a-string"); got != "hello" {
+		t.Fatalf("unexpected value: %v", got)
+	}
+	if got := Int(db2, "an-int"); got != 321 {
+		t.Fatalf("unexpected value: %v", got)
+	}
+	var conf map[string]json.RawMessage
+	if err := exported.ReadOptions("gconf", &conf); err != nil {
+		t.Fatalf("cannot read exported genesis: %s", err)
+	}
+	if len(conf) != 2 {
+		t.Fatalf("want 2 properties, got %d", len(conf))
+	}
+}
@@ -38,6 +38,13 @@ type Ticker interface {
 	Tick(ctx Context, store KVStore) (TickResult, error)
 }
 
+// EndBlocker is a method that is called at the end of every block,
+// after all transactions were delivered. It can be used to process
+// the outcome of the whole block, like settling auctions
+type EndBlocker interface {
+	EndBlock(ctx Context, store KVStore) (EndBlockResult, error)
+}
+
 // Registry is an interface to register your handler,
 // the setup side of a Router
 type Registry interface {
@@ -60,8 +67,26 @@ func (o Options) ReadOptions(key string, obj interface{}) error {
 	return json.Unmarshal(msg, obj)
 }
 
+// WriteOptions serializes obj as json and stores it under
+// the given key, the counterpart of ReadOptions
+func (o Options) WriteOptions(key string, obj interface{}) error {
+	msg, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	o[key] = msg
+	return nil
+}
+
 // Initializer implementations are used to initialize
 // extensions from genesis file contents
 type Initializer interface {
 	FromGenesis(Options, KVStore) error
 }
+
+// Exporter implementations are the counterpart of Initializer,
+// they dump the state of extensions in the same format
+// FromGenesis reads, eg. to restart a chain from its current state
+type Exporter interface {
+	ToGenesis(ReadOnlyKVStore) (Options, error)
+}
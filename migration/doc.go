@@ -0,0 +1,23 @@
+/*
+
+Package migration allows to convert stored data when the schema of a model
+changes.
+
+Every extension that changes the format of its models registers a migration
+function for the affected bucket, together with the schema version it
+converts the data to and the block height it is executed at. Versions of a
+bucket start with 1 and must be registered in order.
+
+A migration runs once, in the first block at or above its height, so all
+nodes migrate the data in the same block, also when the chain is replayed
+from genesis. The schema version of every bucket is stored under a reserved
+"_wv:" key, so the application can refuse to start if the data was written
+by a newer code version, or if a migration height passed without the
+migration being executed.
+
+Buckets start at version 0. If the genesis file is written in a newer
+format, for example when it is exported from a running chain, it must
+declare the schema versions in the "migration" section, see Initializer.
+
+*/
+package migration
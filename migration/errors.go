@@ -0,0 +1,12 @@
+package migration
+
+import (
+	"github.com/iov-one/weave/errors"
+)
+
+// Migration reserves 160~169 error codes
+var (
+	// ErrSchema is returned when the schema version of the stored data
+	// does not match the version expected by the code
+	ErrSchema = errors.Register(160, "schema version mismatch")
+)
@@ -0,0 +1,53 @@
+package migration
+
+import (
+	"sort"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+// Initializer stores the schema versions declared in the genesis file,
+// as a map of bucket names to versions under "migration". The genesis
+// data of those buckets must be written in the declared format, all
+// other buckets start at version 0.
+type Initializer struct{}
+
+var _ weave.Initializer = (*Initializer)(nil)
+
+// FromGenesis stores the declared schema versions
+func (*Initializer) FromGenesis(opts weave.Options, db weave.KVStore) error {
+	versions := make(map[string]uint32)
+	if err := opts.ReadOptions("migration", &versions); err != nil {
+		return errors.Wrap(err, "cannot load schema versions")
+	}
+	buckets := make([]string, 0, len(versions))
+	for bucket := range versions {
+		if bucket == "" {
+			return errors.ErrEmpty.New("bucket name")
+		}
+		buckets = append(buckets, bucket)
+	}
+	// write in a deterministic order
+	sort.Strings(buckets)
+	for _, bucket := range buckets {
+		setVersion(db, bucket, versions[bucket])
+	}
+	return nil
+}
+
+var _ weave.Exporter = (*Initializer)(nil)
+
+// ToGenesis exports the schema versions of all buckets,
+// in the format read by FromGenesis
+func (*Initializer) ToGenesis(db weave.ReadOnlyKVStore) (weave.Options, error) {
+	versions := make(map[string]uint32)
+	itr := db.Iterator([]byte(versionKeyPrefix), prefixEnd(versionKeyPrefix))
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		bucket := string(itr.Key()[len(versionKeyPrefix):])
+		versions[bucket] = decodeVersion(itr.Value())
+	}
+	opts := weave.Options{}
+	return opts, opts.WriteOptions("migration", versions)
+}
@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesis(t *testing.T) {
+	const genesis = `{"migration": {"foo": 2, "bar": 1}}`
+	var opts weave.Options
+	require.NoError(t, json.Unmarshal([]byte(genesis), &opts))
+
+	db := store.MemStore()
+	var ini Initializer
+	require.NoError(t, ini.FromGenesis(opts, db))
+	assert.Equal(t, uint32(2), Version(db, "foo"))
+	assert.Equal(t, uint32(1), Version(db, "bar"))
+	assert.Equal(t, uint32(0), Version(db, "baz"))
+
+	// a declared version skips the migrations up to it
+	reg := NewRegistry()
+	reg.Register("foo", 1, 1, appendTo("foo", 1))
+	reg.Register("foo", 2, 1, appendTo("foo", 2))
+	reg.Register("foo", 3, 1, appendTo("foo", 3))
+	require.NoError(t, reg.Migrate(weave.WithHeight(context.Background(), 1), db))
+	assert.Equal(t, []byte{3}, db.Get([]byte("foo")))
+
+	exported, err := ini.ToGenesis(db)
+	require.NoError(t, err)
+	var versions map[string]uint32
+	require.NoError(t, exported.ReadOptions("migration", &versions))
+	assert.Equal(t, map[string]uint32{"foo": 3, "bar": 1}, versions)
+}
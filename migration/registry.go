@@ -0,0 +1,169 @@
+package migration
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+// _wv: is a prefix for weave internal data
+const versionKeyPrefix = "_wv:schema:"
+
+// Migrator converts all data of a bucket to the next schema version
+type Migrator func(ctx weave.Context, db weave.KVStore) error
+
+// migration is a single registered schema change
+type migration struct {
+	version uint32
+	// height is the block height the migration is executed at
+	height  int64
+	migrate Migrator
+}
+
+// Registry holds the migrations of all buckets
+type Registry struct {
+	buckets map[string][]migration
+}
+
+// NewRegistry returns a registry without any migrations
+func NewRegistry() *Registry {
+	return &Registry{buckets: make(map[string][]migration)}
+}
+
+// Register adds a migration of the bucket to the given schema version,
+// executed in the first block at or above the given height. The height
+// must be agreed on by all validators, so every node, including one
+// replaying the chain from genesis, migrates the data in the same block.
+//
+// Versions of a bucket must be registered in order, starting with 1,
+// and their heights must not decrease.
+// Registration should be done during the application initialization,
+// failed registration results in a panic.
+func (r *Registry) Register(bucket string, version uint32, height int64, fn Migrator) {
+	if fn == nil {
+		panic(fmt.Sprintf("nil migration for %s version %d", bucket, version))
+	}
+	if height < 1 {
+		panic(fmt.Sprintf("invalid height %d for %s version %d", height, bucket, version))
+	}
+	if want := r.Latest(bucket) + 1; version != want {
+		panic(fmt.Sprintf("migration for %s must be version %d, got %d", bucket, want, version))
+	}
+	if prev := r.buckets[bucket]; len(prev) > 0 && prev[len(prev)-1].height > height {
+		panic(fmt.Sprintf("migration for %s version %d must not be before height %d",
+			bucket, version, prev[len(prev)-1].height))
+	}
+	r.buckets[bucket] = append(r.buckets[bucket], migration{
+		version: version,
+		height:  height,
+		migrate: fn,
+	})
+}
+
+// Latest returns the schema version of a bucket expected by the code
+func (r *Registry) Latest(bucket string) uint32 {
+	return uint32(len(r.buckets[bucket]))
+}
+
+// Migrate executes all migrations due at the height of the context,
+// in the order of their versions, and stores the applied versions.
+func (r *Registry) Migrate(ctx weave.Context, db weave.KVStore) error {
+	height, _ := weave.GetHeight(ctx)
+	for _, bucket := range r.bucketNames() {
+		for _, m := range r.pending(db, bucket) {
+			if m.height > height {
+				break
+			}
+			if err := m.migrate(ctx, db); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("migrate %s to version %d", bucket, m.version))
+			}
+			setVersion(db, bucket, m.version)
+			weave.GetLogger(ctx).Info("Schema migrated",
+				"bucket", bucket, "version", m.version)
+		}
+	}
+	return nil
+}
+
+// Verify ensures that the stored data can be handled by the code,
+// given the state was committed at the given height.
+//
+// It fails if a bucket was migrated to a version the code does not
+// know about, or if a migration should have been executed at a height
+// that was already committed.
+func (r *Registry) Verify(db weave.ReadOnlyKVStore, height int64) error {
+	itr := db.Iterator([]byte(versionKeyPrefix), prefixEnd(versionKeyPrefix))
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		bucket := string(itr.Key()[len(versionKeyPrefix):])
+		if v, latest := decodeVersion(itr.Value()), r.Latest(bucket); v > latest {
+			return ErrSchema.Newf("%s data version %d, code version %d", bucket, v, latest)
+		}
+	}
+
+	for _, bucket := range r.bucketNames() {
+		pending := r.pending(db, bucket)
+		if len(pending) == 0 {
+			continue
+		}
+		if m := pending[0]; m.height <= height {
+			return ErrSchema.Newf("%s version %d was due at height %d, state is at %d",
+				bucket, m.version, m.height, height)
+		}
+	}
+	return nil
+}
+
+// Version returns the schema version of the data stored in a bucket
+func Version(db weave.ReadOnlyKVStore, bucket string) uint32 {
+	return decodeVersion(db.Get(versionKey(bucket)))
+}
+
+// pending returns all migrations of the bucket not applied yet
+func (r *Registry) pending(db weave.ReadOnlyKVStore, bucket string) []migration {
+	v := Version(db, bucket)
+	migrations := r.buckets[bucket]
+	if int(v) >= len(migrations) {
+		return nil
+	}
+	return migrations[v:]
+}
+
+// bucketNames returns the names of all buckets with migrations,
+// sorted to execute them in a deterministic order
+func (r *Registry) bucketNames() []string {
+	names := make([]string, 0, len(r.buckets))
+	for name := range r.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func setVersion(db weave.KVStore, bucket string, version uint32) {
+	val := make([]byte, 4)
+	binary.BigEndian.PutUint32(val, version)
+	db.Set(versionKey(bucket), val)
+}
+
+func decodeVersion(val []byte) uint32 {
+	if len(val) != 4 {
+		return 0
+	}
+	return binary.BigEndian.Uint32(val)
+}
+
+func versionKey(bucket string) []byte {
+	return []byte(versionKeyPrefix + bucket)
+}
+
+// prefixEnd returns the first key after all keys with the given prefix,
+// the prefix must not end with 0xff
+func prefixEnd(prefix string) []byte {
+	end := []byte(prefix)
+	end[len(end)-1]++
+	return end
+}
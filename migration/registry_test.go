@@ -0,0 +1,142 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendTo returns a migration appending the version to the value at key
+func appendTo(key string, version byte) Migrator {
+	return func(ctx weave.Context, db weave.KVStore) error {
+		db.Set([]byte(key), append(db.Get([]byte(key)), version))
+		return nil
+	}
+}
+
+func TestRegistryMigrate(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register("foo", 1, 1, appendTo("foo", 1))
+	reg.Register("foo", 2, 10, appendTo("foo", 2))
+	reg.Register("foo", 3, 10, appendTo("foo", 3))
+	reg.Register("bar", 1, 5, appendTo("bar", 1))
+
+	db := store.MemStore()
+	migrate := func(height int64) {
+		ctx := weave.WithHeight(context.Background(), height)
+		require.NoError(t, reg.Migrate(ctx, db))
+	}
+
+	// due migrations run, later ones wait for their height
+	migrate(1)
+	assert.Equal(t, []byte{1}, db.Get([]byte("foo")))
+	assert.Nil(t, db.Get([]byte("bar")))
+	assert.Equal(t, uint32(1), Version(db, "foo"))
+	assert.Equal(t, uint32(0), Version(db, "bar"))
+
+	migrate(5)
+	assert.Equal(t, []byte{1}, db.Get([]byte("foo")))
+	assert.Equal(t, []byte{1}, db.Get([]byte("bar")))
+
+	// missed heights are caught up with, versions due
+	// at the same height run in order
+	migrate(12)
+	assert.Equal(t, []byte{1, 2, 3}, db.Get([]byte("foo")))
+	assert.Equal(t, uint32(3), Version(db, "foo"))
+
+	// migrations run only once
+	migrate(13)
+	assert.Equal(t, []byte{1, 2, 3}, db.Get([]byte("foo")))
+	assert.Equal(t, []byte{1}, db.Get([]byte("bar")))
+}
+
+func TestRegistryMigrateError(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register("foo", 1, 1, func(weave.Context, weave.KVStore) error {
+		return errors.ErrInvalidModel.New("broken")
+	})
+
+	db := store.MemStore()
+	err := reg.Migrate(weave.WithHeight(context.Background(), 1), db)
+	require.Error(t, err)
+	assert.True(t, errors.ErrInvalidModel.Is(err))
+	assert.Equal(t, uint32(0), Version(db, "foo"))
+}
+
+func TestRegistryVerify(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register("foo", 1, 5, appendTo("foo", 1))
+	reg.Register("foo", 2, 10, appendTo("foo", 2))
+
+	cases := map[string]struct {
+		versions map[string]uint32
+		height   int64
+		wantErr  bool
+	}{
+		"fresh data": {
+			height: 1,
+		},
+		"migration pending": {
+			height: 4,
+		},
+		"migration missed": {
+			height:  5,
+			wantErr: true,
+		},
+		"next migration pending": {
+			versions: map[string]uint32{"foo": 1},
+			height:   9,
+		},
+		"next migration missed": {
+			versions: map[string]uint32{"foo": 1},
+			height:   10,
+			wantErr:  true,
+		},
+		"up to date": {
+			versions: map[string]uint32{"foo": 2},
+			height:   20,
+		},
+		"data newer than code": {
+			versions: map[string]uint32{"foo": 3},
+			height:   20,
+			wantErr:  true,
+		},
+		"unknown bucket": {
+			versions: map[string]uint32{"bar": 1},
+			height:   20,
+			wantErr:  true,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			db := store.MemStore()
+			for bucket, v := range tc.versions {
+				setVersion(db, bucket, v)
+			}
+			err := reg.Verify(db, tc.height)
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.True(t, ErrSchema.Is(err))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	reg := NewRegistry()
+	assert.Panics(t, func() { reg.Register("foo", 2, 1, appendTo("foo", 2)) })
+	assert.Panics(t, func() { reg.Register("foo", 1, 0, appendTo("foo", 1)) })
+	reg.Register("foo", 1, 10, appendTo("foo", 1))
+	assert.Panics(t, func() { reg.Register("foo", 1, 10, appendTo("foo", 1)) })
+	assert.Panics(t, func() { reg.Register("foo", 2, 10, nil) })
+	assert.Panics(t, func() { reg.Register("foo", 2, 9, appendTo("foo", 2)) })
+	assert.Equal(t, uint32(1), reg.Latest("foo"))
+}
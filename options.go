@@ -0,0 +1,79 @@
+package weave
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/iov-one/weave/errors"
+)
+
+// OptionSetter validates a new value of a node-local option and
+// applies it. It has no access to the store, as it must never
+// change the consensus state.
+//
+// Setters are called from another goroutine than the transaction
+// processing, so applying the value must be safe for concurrent use.
+type OptionSetter func(value string) error
+
+// OptionRegister is a function that adds some options
+// to this router
+type OptionRegister func(OptionRouter)
+
+// OptionRouter allows extensions to declare node-local settings,
+// which can be changed at runtime via the ABCI SetOption call.
+//
+// Minimal interface modeled after QueryRouter
+type OptionRouter struct {
+	mtx     *sync.Mutex
+	setters map[string]OptionSetter
+}
+
+// NewOptionRouter initializes an OptionRouter with no options
+func NewOptionRouter() OptionRouter {
+	return OptionRouter{
+		mtx:     &sync.Mutex{},
+		setters: make(map[string]OptionSetter, 10),
+	}
+}
+
+// RegisterAll registers a number of OptionRegister at once
+func (r OptionRouter) RegisterAll(or ...OptionRegister) {
+	for _, o := range or {
+		o(r)
+	}
+}
+
+// Register adds a new OptionSetter for the given key.
+// panics if another OptionSetter was already registered
+func (r OptionRouter) Register(key string, s OptionSetter) {
+	if _, ok := r.setters[key]; ok {
+		panic(fmt.Sprintf("Re-registering option: %s", key))
+	}
+	r.setters[key] = s
+}
+
+// Set passes the value to the OptionSetter registered for the key.
+// Calls are serialized, so setters never run concurrently.
+func (r OptionRouter) Set(key, value string) error {
+	s, ok := r.setters[key]
+	if !ok {
+		return errors.ErrNotFound.Newf("option %q", key)
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err := s(value); err != nil {
+		return errors.Wrap(err, key)
+	}
+	return nil
+}
+
+// Keys returns all registered option keys in sorted order
+func (r OptionRouter) Keys() []string {
+	keys := make([]string, 0, len(r.setters))
+	for k := range r.setters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
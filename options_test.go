@@ -0,0 +1,46 @@
+package weave
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/iov-one/weave/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionRouter(t *testing.T) {
+	var limit int
+	r := NewOptionRouter()
+	r.RegisterAll(func(r OptionRouter) {
+		r.Register("limit", func(value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return errors.ErrInvalidInput.New(value)
+			}
+			limit = n
+			return nil
+		})
+		r.Register("noop", func(string) error { return nil })
+	})
+	assert.Equal(t, []string{"limit", "noop"}, r.Keys())
+
+	require.NoError(t, r.Set("limit", "42"))
+	assert.Equal(t, 42, limit)
+
+	// invalid values are not applied
+	err := r.Set("limit", "many")
+	assert.True(t, errors.ErrInvalidInput.Is(err))
+	assert.Equal(t, 42, limit)
+
+	err = r.Set("unknown", "1")
+	assert.True(t, errors.ErrNotFound.Is(err))
+
+	assert.Panics(t, func() {
+		r.Register("limit", func(string) error { return nil })
+	})
+
+	// a router without options knows none
+	err = OptionRouter{}.Set("limit", "1")
+	assert.True(t, errors.ErrNotFound.Is(err))
+}
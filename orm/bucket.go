@@ -116,6 +116,12 @@ func (b Bucket) Query(db weave.ReadOnlyKVStore, mod string,
 	case weave.PrefixQueryMod:
 		prefix := b.DBKey(data)
 		return queryPrefix(db, prefix), nil
+	case weave.RangeQueryMod:
+		q, err := weave.ParseRangeQuery(data)
+		if err != nil {
+			return nil, err
+		}
+		return queryRange(db, b.DBKey(nil), q), nil
 	default:
 		return nil, errors.New("not implemented: " + mod)
 	}
@@ -150,6 +156,21 @@ func (b Bucket) Get(db weave.ReadOnlyKVStore, key []byte) (Object, error) {
 	return b.Parse(key, bz)
 }
 
+// All returns all objects stored in the bucket, ordered by key.
+// It reads the whole bucket into memory, eg. to export the state.
+func (b Bucket) All(db weave.ReadOnlyKVStore) ([]Object, error) {
+	models := queryPrefix(db, b.prefix)
+	objs := make([]Object, len(models))
+	for i, m := range models {
+		obj, err := b.Parse(m.Key[len(b.prefix):], m.Value)
+		if err != nil {
+			return nil, err
+		}
+		objs[i] = obj
+	}
+	return objs, nil
+}
+
 // Parse takes a key and value data (weave.Model) and
 // reconstructs the data this Bucket would return.
 //
@@ -270,6 +291,26 @@ func (b Bucket) GetIndexedLike(db weave.ReadOnlyKVStore, name string, pattern Ob
 	return b.readRefs(db, refs)
 }
 
+// GetIndexedRange queries the named index for all keys inside of the range
+func (b Bucket) GetIndexedRange(db weave.ReadOnlyKVStore, name string, q *weave.RangeQuery) ([]Object, error) {
+	return b.GetIndexedRangeN(db, name, q, 0)
+}
+
+// GetIndexedRangeN is like GetIndexedRange, but loads at most limit
+// objects, the first ones in the index order. The index is not read
+// past the limit. A limit of zero or less loads all of them.
+func (b Bucket) GetIndexedRangeN(db weave.ReadOnlyKVStore, name string, q *weave.RangeQuery, limit int) ([]Object, error) {
+	idx := b.indexes.Get(name)
+	if idx == nil {
+		return nil, ErrInvalidIndex.New(name)
+	}
+	refs, err := idx.GetRangeN(db, q, limit)
+	if err != nil {
+		return nil, err
+	}
+	return b.readRefs(db, refs)
+}
+
 func (b Bucket) readRefs(db weave.ReadOnlyKVStore, refs [][]byte) ([]Object, error) {
 	if len(refs) == 0 {
 		return nil, nil
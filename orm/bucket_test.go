@@ -294,6 +294,74 @@ func TestBucketIndex(t *testing.T) {
 	}
 }
 
+// Make sure index ranges return objects in index order
+func TestBucketIndexRange(t *testing.T) {
+	const mini = "mini"
+	bucket := NewBucket("special", NewSimpleObj(nil, new(Counter))).
+		WithIndex(mini, countByte, false)
+
+	oa := NewSimpleObj([]byte("a"), NewCounter(7))
+	ob := NewSimpleObj([]byte("b"), NewCounter(3))
+	oc := NewSimpleObj([]byte("c"), NewCounter(5))
+	od := NewSimpleObj([]byte("d"), NewCounter(3))
+
+	db := store.MemStore()
+	for _, o := range []Object{oa, ob, oc, od} {
+		require.NoError(t, bucket.Save(db, o))
+	}
+
+	cases := map[string]struct {
+		index   string
+		q       weave.RangeQuery
+		res     []Object
+		isError bool
+	}{
+		"everything": {
+			index: mini,
+			q:     weave.RangeQuery{},
+			res:   []Object{ob, od, oc, oa},
+		},
+		"up to inclusive": {
+			index: mini,
+			q:     weave.RangeQuery{End: bc(5), EndInclusive: true},
+			res:   []Object{ob, od, oc},
+		},
+		"exclusive start": {
+			index: mini,
+			q:     weave.RangeQuery{Start: bc(3), StartExclusive: true},
+			res:   []Object{oc, oa},
+		},
+		"empty": {
+			index: mini,
+			q:     weave.RangeQuery{Start: bc(8)},
+		},
+		"unknown index": {
+			index:   "foo",
+			isError: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			res, err := bucket.GetIndexedRange(db, tc.index, &tc.q)
+			if tc.isError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.EqualValues(t, tc.res, res)
+		})
+	}
+
+	// the limit keeps the index order
+	res, err := bucket.GetIndexedRangeN(db, mini, &weave.RangeQuery{}, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, []Object{ob, od, oc}, res)
+	res, err = bucket.GetIndexedRangeN(db, mini, &weave.RangeQuery{}, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, []Object{ob, od, oc, oa}, res)
+}
+
 func toModel(t *testing.T, bucket Bucket, obj Object) weave.Model {
 	dbkey := bucket.DBKey(obj.Key())
 	val, err := obj.Value().Marshal()
@@ -302,6 +370,27 @@ func toModel(t *testing.T, bucket Bucket, obj Object) weave.Model {
 }
 
 // Check query interface works, also with embedded indexes
+func TestBucketAll(t *testing.T) {
+	bucket := NewBucket("special", NewSimpleObj(nil, new(Counter)))
+	other := NewBucket("spec", NewSimpleObj(nil, new(Counter)))
+
+	oa := NewSimpleObj([]byte("a"), NewCounter(7))
+	ob := NewSimpleObj([]byte("b"), NewCounter(3))
+
+	db := store.MemStore()
+	objs, err := bucket.All(db)
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+
+	require.NoError(t, bucket.Save(db, ob))
+	require.NoError(t, bucket.Save(db, oa))
+	require.NoError(t, other.Save(db, NewSimpleObj([]byte("ial:c"), NewCounter(1))))
+
+	objs, err = bucket.All(db)
+	require.NoError(t, err)
+	assert.Equal(t, []Object{oa, ob}, objs)
+}
+
 func TestBucketQuery(t *testing.T) {
 	// make some buckets for testing
 	const mini = "mini"
@@ -412,6 +501,49 @@ func TestBucketQuery(t *testing.T) {
 		13: {
 			uiPath, "prefix", nil, false, false, []weave.Model{dbc, dba, dbb},
 		},
+		// range query - inclusive start, exclusive end
+		14: {
+			bPath, "range", []byte("[6161:6361)"), false, false,
+			[]weave.Model{dba, dbb},
+		},
+		// range query - exclusive start, open end
+		15: {
+			bPath, "range", weave.RangeQuery{Start: a, StartExclusive: true}.Encode(), false, false,
+			[]weave.Model{dbb, dbc},
+		},
+		// range query - open start, inclusive end
+		16: {
+			bPath, "range", weave.RangeQuery{End: a, EndInclusive: true}.Encode(), false, false,
+			[]weave.Model{dba},
+		},
+		// range query - all
+		17: {
+			bPath, "range", []byte("[:]"), false, false,
+			[]weave.Model{dba, dbb, dbc},
+		},
+		// range query - empty range
+		18: {
+			bPath, "range", weave.RangeQuery{Start: c, End: a}.Encode(), false, false, nil,
+		},
+		// range query - invalid data
+		19: {
+			bPath, "range", []byte("6161:6361"), false, true, nil,
+		},
+		// range index - exclusive end
+		20: {
+			iPath, "range", weave.RangeQuery{Start: e2, End: e5}.Encode(), false, false,
+			[]weave.Model{dbc},
+		},
+		// range index - inclusive end
+		21: {
+			iPath, "range", weave.RangeQuery{Start: e2, End: e5, EndInclusive: true}.Encode(), false, false,
+			[]weave.Model{dbc, dba, dbb},
+		},
+		// range unique index - exclusive start
+		22: {
+			uiPath, "range", weave.RangeQuery{Start: encodeSequence(2), StartExclusive: true}.Encode(), false, false,
+			[]weave.Model{dba, dbb},
+		},
 	}
 
 	for i, tc := range cases {
@@ -176,9 +176,39 @@ func (i Index) GetAt(db weave.ReadOnlyKVStore, index []byte) ([][]byte, error) {
 // begins with a given prefix
 func (i Index) GetPrefix(db weave.ReadOnlyKVStore, prefix []byte) ([][]byte, error) {
 	dbPrefix := i.IndexKey(prefix)
-	itr := db.Iterator(prefixRange(dbPrefix))
-	var data [][]byte
+	return i.consumeRefs(db.Iterator(prefixRange(dbPrefix)))
+}
+
+// GetRange returns all references that have an index inside
+// of the given range
+func (i Index) GetRange(db weave.ReadOnlyKVStore, q *weave.RangeQuery) ([][]byte, error) {
+	return i.GetRangeN(db, q, 0)
+}
+
+// GetRangeN is like GetRange, but stops reading the index once
+// limit references are collected. A limit of zero or less returns
+// all of them.
+func (i Index) GetRangeN(db weave.ReadOnlyKVStore, q *weave.RangeQuery, limit int) ([][]byte, error) {
+	start, end, ok := rangeBounds(i.id, q)
+	if !ok {
+		return nil, nil
+	}
+	return i.consumeRefsN(db.Iterator(start, end), limit)
+}
+
+// consumeRefs returns all references stored in the index entries
+// of the iterator and closes it
+func (i Index) consumeRefs(itr weave.Iterator) ([][]byte, error) {
+	return i.consumeRefsN(itr, 0)
+}
 
+// consumeRefsN returns at most limit references stored in the index
+// entries of the iterator and closes it. Entries past the limit are
+// not read.
+func (i Index) consumeRefsN(itr weave.Iterator, limit int) ([][]byte, error) {
+	defer itr.Close()
+
+	var data [][]byte
 	for ; itr.Valid(); itr.Next() {
 		if i.unique {
 			data = append(data, itr.Value())
@@ -190,6 +220,9 @@ func (i Index) GetPrefix(db weave.ReadOnlyKVStore, prefix []byte) ([][]byte, err
 			}
 			data = append(data, tmp.Refs...)
 		}
+		if limit > 0 && len(data) >= limit {
+			return data[:limit], nil
+		}
 	}
 
 	return data, nil
@@ -212,6 +245,16 @@ func (i Index) Query(db weave.ReadOnlyKVStore, mod string,
 			return nil, err
 		}
 		return i.loadRefs(db, refs), nil
+	case weave.RangeQueryMod:
+		q, err := weave.ParseRangeQuery(data)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := i.GetRange(db, q)
+		if err != nil {
+			return nil, err
+		}
+		return i.loadRefs(db, refs), nil
 	default:
 		return nil, errors.ErrHuman.New("not implemented: " + mod)
 	}
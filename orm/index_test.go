@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -270,6 +271,73 @@ func TestGetLikeWithMultiKeyIndex(t *testing.T) {
 	}
 }
 
+func TestIndexGetRangeN(t *testing.T) {
+	db := store.MemStore()
+	idx := NewIndex("likes", count, false, nil)
+
+	objs := []Object{
+		NewSimpleObj([]byte("a"), NewCounter(1)),
+		NewSimpleObj([]byte("b"), NewCounter(2)),
+		NewSimpleObj([]byte("c"), NewCounter(2)),
+		NewSimpleObj([]byte("d"), NewCounter(3)),
+	}
+	for _, o := range objs {
+		require.NoError(t, idx.Update(db, nil, o))
+	}
+
+	cases := map[string]struct {
+		limit   int
+		expPKs  [][]byte
+		expRead int
+	}{
+		"no limit": {
+			limit:   0,
+			expPKs:  toBytes([]string{"a", "b", "c", "d"}),
+			expRead: 3,
+		},
+		"limit in a single entry": {
+			limit:   2,
+			expPKs:  toBytes([]string{"a", "b"}),
+			expRead: 2,
+		},
+		"limit at the end of an entry": {
+			limit:   3,
+			expPKs:  toBytes([]string{"a", "b", "c"}),
+			expRead: 2,
+		},
+		"limit above the size": {
+			limit:   10,
+			expPKs:  toBytes([]string{"a", "b", "c", "d"}),
+			expRead: 3,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pks, err := idx.GetRangeN(db, &weave.RangeQuery{}, tc.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expPKs, pks)
+
+			// the index entries past the limit are not read
+			start, end, _ := rangeBounds(idx.id, &weave.RangeQuery{})
+			itr := &countingIterator{Iterator: db.Iterator(start, end)}
+			_, err = idx.consumeRefsN(itr, tc.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expRead, itr.read)
+		})
+	}
+}
+
+// countingIterator counts the entries whose value was read
+type countingIterator struct {
+	weave.Iterator
+	read int
+}
+
+func (c *countingIterator) Value() []byte {
+	c.read++
+	return c.Iterator.Value()
+}
+
 func evenOddIndexer(obj Object) ([][]byte, error) {
 	cntr, _ := obj.Value().(*Counter)
 	result := [][]byte{encodeSequence(cntr.Count)}
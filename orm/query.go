@@ -1,6 +1,10 @@
 package orm
 
-import "github.com/iov-one/weave"
+import (
+	"bytes"
+
+	"github.com/iov-one/weave"
+)
 
 // RegisterQuery will register a root query (literal keys)
 // under "/"
@@ -56,3 +60,42 @@ func prefixRange(prefix []byte) ([]byte, []byte) {
 func queryPrefix(db weave.ReadOnlyKVStore, prefix []byte) []weave.Model {
 	return consumeIterator(db.Iterator(prefixRange(prefix)))
 }
+
+// rangeBounds turns a range query inside of the given prefix into
+// (start, end) to create an iterator. Open sides of the range are
+// limited by the prefix. Returns false if the range is empty.
+func rangeBounds(prefix []byte, q *weave.RangeQuery) ([]byte, []byte, bool) {
+	start, end := prefixRange(prefix)
+	if q.Start != nil {
+		start = rangeKey(prefix, q.Start, q.StartExclusive)
+	}
+	if q.End != nil {
+		end = rangeKey(prefix, q.End, q.EndInclusive)
+	}
+	if start != nil && end != nil && bytes.Compare(start, end) >= 0 {
+		return nil, nil, false
+	}
+	return start, end, true
+}
+
+// rangeKey returns the prefixed key, or the key right after it
+// if next is true (appending a zero byte)
+func rangeKey(prefix, key []byte, next bool) []byte {
+	l := len(prefix) + len(key)
+	res := make([]byte, l, l+1)
+	copy(res, prefix)
+	copy(res[len(prefix):], key)
+	if next {
+		res = append(res, 0)
+	}
+	return res
+}
+
+// queryRange returns a range query inside of a prefix as Models
+func queryRange(db weave.ReadOnlyKVStore, prefix []byte, q *weave.RangeQuery) []weave.Model {
+	start, end, ok := rangeBounds(prefix, q)
+	if !ok {
+		return nil
+	}
+	return consumeIterator(db.Iterator(start, end))
+}
@@ -0,0 +1,118 @@
+package orm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+// ModelRegistry maps bucket names to the prototypes of their models,
+// so stored values can be decoded without knowing the bucket
+// they come from. It renders them as JSON for queries.
+//
+// It also knows the indexes of the registered buckets,
+// to resolve the references stored in them.
+type ModelRegistry struct {
+	models  map[string]Cloneable
+	indexes map[string]Index
+}
+
+var _ weave.ModelRenderer = ModelRegistry{}
+
+// NewModelRegistry initializes a ModelRegistry with no models
+func NewModelRegistry() ModelRegistry {
+	return ModelRegistry{
+		models:  make(map[string]Cloneable, 10),
+		indexes: make(map[string]Index, 10),
+	}
+}
+
+// Register adds the model prototype of the bucket.
+// panics if another model was already registered
+func (r ModelRegistry) Register(bucket string, proto Cloneable) {
+	if _, ok := r.models[bucket]; ok {
+		panic(fmt.Sprintf("Re-registering model: %s", bucket))
+	}
+	r.models[bucket] = proto
+}
+
+// RegisterModel adds the model and the indexes of this bucket
+// to the registry.
+// panics if the model or any of the indexes was already registered
+func (b Bucket) RegisterModel(r ModelRegistry) {
+	for _, idx := range b.indexes {
+		if _, ok := r.indexes[idx.name]; ok {
+			panic(fmt.Sprintf("Re-registering index: %s", idx.name))
+		}
+	}
+	r.Register(b.name, b.proto)
+	for _, idx := range b.indexes {
+		r.indexes[idx.name] = idx.Index
+	}
+}
+
+// References returns the keys of all models referenced by the index
+// entry stored under the key, the index is found by the prefix of the key
+func (r ModelRegistry) References(key, value []byte) ([][]byte, error) {
+	if !bytes.HasPrefix(key, indPrefix) {
+		return nil, errors.ErrNotFound.Newf("no index in key %X", key)
+	}
+	name := key[len(indPrefix):]
+	i := bytes.IndexByte(name, ':')
+	if i < 0 {
+		return nil, errors.ErrNotFound.Newf("no index in key %X", key)
+	}
+	idx, ok := r.indexes[string(name[:i])]
+	if !ok {
+		return nil, errors.ErrNotFound.Newf("unknown index %q", name[:i])
+	}
+	refs := [][]byte{value}
+	if !idx.unique {
+		var data MultiRef
+		if err := data.Unmarshal(value); err != nil {
+			return nil, errors.Wrap(err, "cannot decode references")
+		}
+		refs = data.GetRefs()
+	}
+	keys := make([][]byte, len(refs))
+	for i, ref := range refs {
+		keys[i] = idx.refKey(ref)
+	}
+	return keys, nil
+}
+
+// Decode returns the model stored under the key,
+// the bucket is found by the prefix of the key
+func (r ModelRegistry) Decode(key, value []byte) (Object, error) {
+	i := bytes.IndexByte(key, ':')
+	if i < 0 {
+		return nil, errors.ErrNotFound.Newf("no bucket in key %X", key)
+	}
+	proto, ok := r.models[string(key[:i])]
+	if !ok {
+		return nil, errors.ErrNotFound.Newf("no model for bucket %q", key[:i])
+	}
+	obj := proto.Clone()
+	if err := obj.Value().Unmarshal(value); err != nil {
+		return nil, errors.Wrap(err, "cannot decode model")
+	}
+	return obj, nil
+}
+
+// RenderJSON decodes the stored value and renders it as JSON.
+// Fields with a MarshalJSON method, like weave.Address,
+// are rendered with it.
+func (r ModelRegistry) RenderJSON(key, value []byte) (json.RawMessage, error) {
+	obj, err := r.Decode(key, value)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(obj.Value())
+	if err != nil {
+		return nil, errors.ErrInternal.Newf("cannot render JSON: %s", err)
+	}
+	return raw, nil
+}
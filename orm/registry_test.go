@@ -0,0 +1,114 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelRegistry(t *testing.T) {
+	r := NewModelRegistry()
+	NewBucket("cnts", NewSimpleObj(nil, new(Counter))).RegisterModel(r)
+	assert.Panics(t, func() {
+		r.Register("cnts", NewSimpleObj(nil, new(Counter)))
+	})
+
+	value, err := (&Counter{Count: 17}).Marshal()
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		key     string
+		value   []byte
+		want    string
+		wantErr *errors.Error
+	}{
+		"registered bucket": {
+			key:   "cnts:foo",
+			value: value,
+			want:  `{"count":17}`,
+		},
+		"unknown bucket": {
+			key:     "other:foo",
+			value:   value,
+			wantErr: &errors.ErrNotFound,
+		},
+		"no bucket": {
+			key:     "foo",
+			value:   value,
+			wantErr: &errors.ErrNotFound,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			raw, err := r.RenderJSON([]byte(tc.key), tc.value)
+			if tc.wantErr != nil {
+				assert.True(t, tc.wantErr.Is(err), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(raw))
+		})
+	}
+}
+
+func TestModelRegistryReferences(t *testing.T) {
+	r := NewModelRegistry()
+	bucket := NewBucket("cnts", NewSimpleObj(nil, new(Counter))).
+		WithIndex("uniq", count, true).
+		WithIndex("mini", countByte, false)
+	bucket.RegisterModel(r)
+
+	// both indexes are stored under _i.cnts_uniq_a:
+	dup := NewModelRegistry()
+	NewBucket("cnts_uniq", NewSimpleObj(nil, new(Counter))).
+		WithIndex("a", count, true).
+		RegisterModel(dup)
+	assert.Panics(t, func() {
+		NewBucket("cnts", NewSimpleObj(nil, new(Counter))).
+			WithIndex("uniq_a", count, true).
+			RegisterModel(dup)
+	})
+
+	db := store.MemStore()
+	require.NoError(t, bucket.Save(db, NewSimpleObj([]byte("a"), NewCounter(5))))
+	require.NoError(t, bucket.Save(db, NewSimpleObj([]byte("b"), NewCounter(256+5))))
+
+	cases := map[string]struct {
+		key     []byte
+		want    [][]byte
+		wantErr *errors.Error
+	}{
+		"unique index": {
+			key:  append([]byte("_i.cnts_uniq:"), encodeSequence(5)...),
+			want: [][]byte{[]byte("cnts:a")},
+		},
+		"multi index": {
+			key:  []byte("_i.cnts_mini:\x05"),
+			want: [][]byte{[]byte("cnts:a"), []byte("cnts:b")},
+		},
+		"unknown index": {
+			key:     []byte("_i.other_mini:\x05"),
+			wantErr: &errors.ErrNotFound,
+		},
+		"no index": {
+			key:     []byte("cnts:a"),
+			wantErr: &errors.ErrNotFound,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			refs, err := r.References(tc.key, db.Get(tc.key))
+			if tc.wantErr != nil {
+				assert.True(t, tc.wantErr.Is(err), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, refs)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 
 	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
 )
 
 // Sequence maintains a counter, and generates a
@@ -35,6 +36,19 @@ func (s *Sequence) NextInt(db weave.KVStore) int64 {
 	return val
 }
 
+// Reserve moves the sequence forward, so that NextVal only returns
+// values greater than key, which must be a value returned by NextVal.
+// It is used to restore objects with known keys, eg. from genesis.
+func (s *Sequence) Reserve(db weave.KVStore, key []byte) error {
+	if len(key) != 8 {
+		return errors.ErrInvalidInput.Newf("sequence value: %X", key)
+	}
+	if val := decodeSequence(key); val > decodeSequence(db.Get(s.id)) {
+		db.Set(s.id, encodeSequence(val))
+	}
+	return nil
+}
+
 func (s *Sequence) increment(db weave.KVStore, inc int64) (int64, []byte) {
 	raw := db.Get(s.id)
 	val := decodeSequence(raw)
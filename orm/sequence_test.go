@@ -39,3 +39,22 @@ func TestSequenceKeyFormat(t *testing.T) {
 	}
 
 }
+
+func TestSequenceReserve(t *testing.T) {
+	db := store.MemStore()
+	s := NewSequence("bucket", "name")
+
+	if err := s.Reserve(db, encodeSequence(5)); err != nil {
+		t.Fatalf("cannot reserve: %s", err)
+	}
+	// reserving a lower value does not move the sequence back
+	if err := s.Reserve(db, encodeSequence(3)); err != nil {
+		t.Fatalf("cannot reserve: %s", err)
+	}
+	if got := s.NextInt(db); got != 6 {
+		t.Fatalf("want 6, got %d", got)
+	}
+	if err := s.Reserve(db, []byte("short")); err == nil {
+		t.Fatal("invalid value reserved")
+	}
+}
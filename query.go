@@ -1,7 +1,13 @@
 package weave
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iov-one/weave/errors"
 )
 
 const (
@@ -9,11 +15,172 @@ const (
 	KeyQueryMod = ""
 	// PrefixQueryMod means to query for anything with this prefix
 	PrefixQueryMod = "prefix"
-	// RangeQueryMod means to expect complex range query
-	// TODO: implement
+	// RangeQueryMod means to query for anything inside of a key range,
+	// data must be an encoded RangeQuery
 	RangeQueryMod = "range"
+
+	// JSONQueryOption renders the values as JSON (see ModelRenderer).
+	// It is set as an option of the query type, eg. "prefix&json",
+	// or on its own for a key query
+	JSONQueryOption = "json"
 )
 
+// RangeQuery is the decoded data of a RangeQueryMod query.
+//
+// On the wire it is an interval of hex encoded keys, with a square
+// bracket for an inclusive and a parenthesis for an exclusive bound.
+// Either key may be empty to leave that side of the range open.
+//
+//   [0102:0a0b)  from 0102 (inclusive) to 0a0b (exclusive)
+//   (0102:]      everything after 0102
+//   [:]          everything
+type RangeQuery struct {
+	Start          []byte
+	End            []byte
+	StartExclusive bool
+	EndInclusive   bool
+}
+
+// ParseRangeQuery decodes the data of a range query
+func ParseRangeQuery(data []byte) (*RangeQuery, error) {
+	s := string(data)
+	if len(s) < 3 {
+		return nil, errors.ErrInvalidInput.Newf("range query: %q", s)
+	}
+
+	var q RangeQuery
+	switch s[0] {
+	case '[':
+	case '(':
+		q.StartExclusive = true
+	default:
+		return nil, errors.ErrInvalidInput.Newf("range query start: %q", s)
+	}
+	switch s[len(s)-1] {
+	case ')':
+	case ']':
+		q.EndInclusive = true
+	default:
+		return nil, errors.ErrInvalidInput.Newf("range query end: %q", s)
+	}
+
+	var err error
+	if q.Start, q.End, err = parseRangeKeys(s[1 : len(s)-1]); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+func parseRangeKeys(s string) ([]byte, []byte, error) {
+	keys := strings.Split(s, ":")
+	if len(keys) != 2 {
+		return nil, nil, errors.ErrInvalidInput.Newf("range query keys: %q", s)
+	}
+	start, err := decodeRangeKey(keys[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	end, err := decodeRangeKey(keys[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return start, end, nil
+}
+
+// decodeRangeKey returns nil for an open side of the range
+func decodeRangeKey(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	key, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, err.Error())
+	}
+	return key, nil
+}
+
+// Encode serializes the range query into the wire format
+func (q RangeQuery) Encode() []byte {
+	open, close := "[", ")"
+	if q.StartExclusive {
+		open = "("
+	}
+	if q.EndInclusive {
+		close = "]"
+	}
+	return []byte(open + hex.EncodeToString(q.Start) + ":" + hex.EncodeToString(q.End) + close)
+}
+
+// QueryOptions are the optional parts of a query modifier.
+// They follow the query type, separated by "&", for example
+//
+//   prefix&limit=20&offset=40
+//   range&limit=20&cursor=0a0b
+//   prefix&json
+type QueryOptions struct {
+	// Limit is the maximum number of items returned
+	// by an iteration, 0 means no limit
+	Limit int
+	// Offset is the number of items skipped at the beginning
+	// of an iteration
+	Offset int
+	// Cursor is the (inclusive) key to continue an iteration from,
+	// as returned by the previous page of results
+	Cursor []byte
+	// JSON requests the values rendered as JSON
+	JSON bool
+}
+
+// Paginated returns true iff the options limit iterations in any way
+func (o QueryOptions) Paginated() bool {
+	return o.Limit > 0 || o.Offset > 0 || o.Cursor != nil
+}
+
+// ParseQueryMod splits the query modifier into the query type
+// (eg. PrefixQueryMod) and the options following it
+func ParseQueryMod(mod string) (string, QueryOptions, error) {
+	var opts QueryOptions
+	chunks := strings.Split(mod, "&")
+	// "json" alone is a key query
+	if chunks[0] == JSONQueryOption {
+		chunks[0] = KeyQueryMod
+		opts.JSON = true
+	}
+	for _, opt := range chunks[1:] {
+		if opt == JSONQueryOption {
+			opts.JSON = true
+			continue
+		}
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			return "", opts, errors.ErrInvalidInput.Newf("query option: %q", opt)
+		}
+		var err error
+		switch kv[0] {
+		case "limit":
+			opts.Limit, err = parseQueryCount(kv[1])
+		case "offset":
+			opts.Offset, err = parseQueryCount(kv[1])
+		case "cursor":
+			opts.Cursor, err = hex.DecodeString(kv[1])
+		default:
+			return "", opts, errors.ErrInvalidInput.Newf("unknown query option: %q", kv[0])
+		}
+		if err != nil {
+			return "", opts, errors.Wrapf(errors.ErrInvalidInput, "query option %q: %s", kv[0], err)
+		}
+	}
+	return chunks[0], opts, nil
+}
+
+func parseQueryCount(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err == nil && n < 0 {
+		return 0, fmt.Errorf("negative value %d", n)
+	}
+	return n, err
+}
+
 // Model groups together key and value to return
 type Model struct {
 	Key   []byte
@@ -28,7 +195,19 @@ func Pair(key, value []byte) Model {
 	}
 }
 
+// ModelRenderer renders stored values as JSON, so clients do not
+// need to know the type of every model (see orm.ModelRegistry).
+// The key of the value tells which model it is.
+type ModelRenderer interface {
+	RenderJSON(key, value []byte) (json.RawMessage, error)
+}
+
 // QueryHandler is anything that can process ABCI queries
+//
+// The meaning of data depends on mod: the key for KeyQueryMod,
+// the key prefix for PrefixQueryMod and an encoded RangeQuery
+// for RangeQueryMod. Handlers should return an error for any
+// mod they do not support.
 type QueryHandler interface {
 	Query(db ReadOnlyKVStore, mod string, data []byte) ([]Model, error)
 }
@@ -0,0 +1,137 @@
+package weave
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeQuery(t *testing.T) {
+	cases := map[string]struct {
+		data    string
+		isError bool
+		want    RangeQuery
+	}{
+		"closed range": {
+			data: "[0102:0a0b)",
+			want: RangeQuery{Start: []byte{1, 2}, End: []byte{10, 11}},
+		},
+		"exclusive start, inclusive end": {
+			data: "(01:ff]",
+			want: RangeQuery{Start: []byte{1}, End: []byte{255}, StartExclusive: true, EndInclusive: true},
+		},
+		"open start": {
+			data: "[:0a)",
+			want: RangeQuery{End: []byte{10}},
+		},
+		"open end": {
+			data: "(0a:]",
+			want: RangeQuery{Start: []byte{10}, StartExclusive: true, EndInclusive: true},
+		},
+		"everything": {
+			data: "[:)",
+			want: RangeQuery{},
+		},
+		"missing brackets": {
+			data:    "01:02",
+			isError: true,
+		},
+		"missing separator": {
+			data:    "[0102)",
+			isError: true,
+		},
+		"too many separators": {
+			data:    "[01:02:03)",
+			isError: true,
+		},
+		"invalid hex": {
+			data:    "[zz:02)",
+			isError: true,
+		},
+		"empty": {
+			data:    "",
+			isError: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q, err := ParseRangeQuery([]byte(tc.data))
+			if tc.isError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, *q)
+			assert.Equal(t, tc.data, string(q.Encode()))
+		})
+	}
+}
+
+func TestParseQueryMod(t *testing.T) {
+	cases := map[string]struct {
+		mod     string
+		isError bool
+		want    string
+		opts    QueryOptions
+	}{
+		"no options": {
+			mod:  PrefixQueryMod,
+			want: PrefixQueryMod,
+		},
+		"empty": {
+			mod:  "",
+			want: KeyQueryMod,
+		},
+		"all options": {
+			mod:  "prefix&limit=10&offset=5&cursor=0a0b",
+			want: PrefixQueryMod,
+			opts: QueryOptions{Limit: 10, Offset: 5, Cursor: []byte{10, 11}},
+		},
+		"options of a range": {
+			mod:  "range&limit=3",
+			want: RangeQueryMod,
+			opts: QueryOptions{Limit: 3},
+		},
+		"json key query": {
+			mod:  "json",
+			want: KeyQueryMod,
+			opts: QueryOptions{JSON: true},
+		},
+		"json with other options": {
+			mod:  "prefix&json&limit=2",
+			want: PrefixQueryMod,
+			opts: QueryOptions{JSON: true, Limit: 2},
+		},
+		"negative limit": {
+			mod:     "prefix&limit=-1",
+			isError: true,
+		},
+		"invalid cursor": {
+			mod:     "prefix&cursor=zz",
+			isError: true,
+		},
+		"unknown option": {
+			mod:     "prefix&sort=desc",
+			isError: true,
+		},
+		"missing value": {
+			mod:     "prefix&limit",
+			isError: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mod, opts, err := ParseQueryMod(tc.mod)
+			if tc.isError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, mod)
+			assert.Equal(t, tc.opts, opts)
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package weave
 
+import (
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
 //////////////////////////////////////////////////////////
 // Defines all public interfaces for interacting with stores
 //
@@ -141,16 +145,25 @@ type CommitKVStore interface {
 	// returns nil iff key doesn't exist. Panics on nil key.
 	Get(key []byte) []byte
 
-	// TODO: Get with proof, also historical queries
-	// GetVersionedWithProof(key []byte, version int64) (value []byte)
+	// GetVersionedWithProof returns the value stored under the key at the
+	// given version, along with a merkle proof of its existence
+	// (or absence if the value is nil). The proof operations are
+	// chained, each one computes the argument of the next one.
+	GetVersionedWithProof(key []byte, version int64) ([]byte, []merkle.ProofOp, error)
+
+	// GetVersionedRangeWithProof returns all models in the given range
+	// at the given version, along with a merkle proof that no other key
+	// exists in this range. End is exclusive.
+	GetVersionedRangeWithProof(start, end []byte, version int64) ([]Model, []merkle.ProofOp, error)
 
-	// func (b *Bonsai) GetWithProof(key []byte) ([]byte, iavl.KeyProof, error) {
-	//   return b.Tree.GetWithProof(key)
-	// }
+	// VersionExists returns true iff the given version was committed
+	// and is still kept in the history (not pruned)
+	VersionExists(version int64) bool
 
-	// func (b *Bonsai) GetVersionedWithProof(key []byte, version int64) ([]byte, iavl.KeyProof, error) {
-	//   return b.Tree.GetVersionedWithProof(key, uint64(version))
-	// }
+	// ReadOnlyVersion returns a read-only view of the state as it
+	// was committed at the given version.
+	// Returns an error if this version was pruned or never existed.
+	ReadOnlyVersion(version int64) (ReadOnlyKVStore, error)
 
 	// Get a CacheWrap to perform actions
 	// TODO: add Batch to atomic writes and efficiency
@@ -168,12 +181,11 @@ type CommitKVStore interface {
 	// LatestVersion returns info on the latest version saved to disk
 	LatestVersion() CommitID
 
-	// ?????
 	// LoadVersion loads a specific persisted version.  When you load an old version, or
 	// when the last commit attempt didn't complete, the next commit after
 	// loading must be idempotent (return the same commit id).  Otherwise the
 	// behavior is undefined.
-	// LoadVersion(ver int64) error
+	LoadVersion(ver int64) error
 }
 
 // CommitID contains the tree version number and its merkle root.
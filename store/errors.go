@@ -0,0 +1,16 @@
+package store
+
+import (
+	"github.com/iov-one/weave/errors"
+)
+
+// Store reserves 150~159 error codes
+var (
+	// ErrPruned is returned when requesting a version that is no
+	// longer kept in the history of the store
+	ErrPruned = errors.Register(150, "version pruned")
+
+	// ErrInvalidProof is returned when a merkle proof does not match
+	// the data or root hash it should prove
+	ErrInvalidProof = errors.Register(151, "invalid proof")
+)
@@ -0,0 +1,213 @@
+package store
+
+import (
+	"math"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+// GasConfig defines how much gas is consumed by every store operation
+type GasConfig struct {
+	HasCost          int64
+	DeleteCost       int64
+	ReadCostFlat     int64
+	ReadCostPerByte  int64
+	WriteCostFlat    int64
+	WriteCostPerByte int64
+	IterNextCostFlat int64
+}
+
+// DefaultGasConfig returns the costs used if nothing else is configured,
+// they are in line with the fixed costs charged by the handlers
+func DefaultGasConfig() GasConfig {
+	return GasConfig{
+		HasCost:          10,
+		DeleteCost:       10,
+		ReadCostFlat:     10,
+		ReadCostPerByte:  1,
+		WriteCostFlat:    20,
+		WriteCostPerByte: 2,
+		IterNextCostFlat: 5,
+	}
+}
+
+// gasMeter is a simple GasMeter with a fixed limit
+type gasMeter struct {
+	limit    int64
+	consumed int64
+}
+
+var _ weave.GasMeter = (*gasMeter)(nil)
+
+// NewGasMeter returns a GasMeter allowing to consume up to limit gas
+func NewGasMeter(limit int64) weave.GasMeter {
+	return &gasMeter{limit: limit}
+}
+
+// ConsumeGas implements weave.GasMeter
+func (g *gasMeter) ConsumeGas(amount int64, descriptor string) {
+	if amount > math.MaxInt64-g.consumed {
+		g.consumed = math.MaxInt64
+	} else {
+		g.consumed += amount
+	}
+	if g.consumed > g.limit {
+		panic(errors.ErrOutOfGas.Newf("%s: consumed %d, limit %d", descriptor, g.consumed, g.limit))
+	}
+}
+
+// GasConsumed implements weave.GasMeter
+func (g *gasMeter) GasConsumed() int64 {
+	return g.consumed
+}
+
+// GasLimit implements weave.GasMeter
+func (g *gasMeter) GasLimit() int64 {
+	return g.limit
+}
+
+// NewGasStore wraps the store, so every operation consumes gas
+// from the meter, using cached alternative if possible.
+//
+// Reads of a CacheWrap that hit the cache are free, writes are
+// charged when they are written to this store.
+func NewGasStore(db KVStore, meter weave.GasMeter, config GasConfig) KVStore {
+	gs := gasStore{
+		KVStore: db,
+		meter:   meter,
+		config:  config,
+	}
+	if _, ok := db.(CacheableKVStore); ok {
+		return &cacheableGasStore{gasStore: gs}
+	}
+	return &gs
+}
+
+//------- non-cached gas store
+
+// gasStore wraps a normal KVStore and consumes gas for every operation
+type gasStore struct {
+	KVStore
+	meter  weave.GasMeter
+	config GasConfig
+}
+
+var _ KVStore = (*gasStore)(nil)
+
+// Get consumes gas for the read and the size of the value
+func (g *gasStore) Get(key []byte) []byte {
+	g.meter.ConsumeGas(g.config.ReadCostFlat, "read")
+	value := g.KVStore.Get(key)
+	g.meter.ConsumeGas(g.config.ReadCostPerByte*int64(len(value)), "read bytes")
+	return value
+}
+
+// Has consumes gas for the check
+func (g *gasStore) Has(key []byte) bool {
+	g.meter.ConsumeGas(g.config.HasCost, "has")
+	return g.KVStore.Has(key)
+}
+
+// Set consumes gas for the write and the size of key and value
+func (g *gasStore) Set(key, value []byte) {
+	g.consumeWrite(key, value)
+	g.KVStore.Set(key, value)
+}
+
+// Delete consumes gas for the delete
+func (g *gasStore) Delete(key []byte) {
+	g.meter.ConsumeGas(g.config.DeleteCost, "delete")
+	g.KVStore.Delete(key)
+}
+
+// Iterator returns an iterator consuming gas for every step
+func (g *gasStore) Iterator(start, end []byte) Iterator {
+	return g.newIterator(g.KVStore.Iterator(start, end))
+}
+
+// ReverseIterator returns an iterator consuming gas for every step
+func (g *gasStore) ReverseIterator(start, end []byte) Iterator {
+	return g.newIterator(g.KVStore.ReverseIterator(start, end))
+}
+
+// NewBatch makes sure all writes go through this one
+func (g *gasStore) NewBatch() Batch {
+	return &gasBatch{
+		gasStore: g,
+		b:        g.KVStore.NewBatch(),
+	}
+}
+
+func (g *gasStore) consumeWrite(key, value []byte) {
+	g.meter.ConsumeGas(g.config.WriteCostFlat, "write")
+	g.meter.ConsumeGas(g.config.WriteCostPerByte*int64(len(key)+len(value)), "write bytes")
+}
+
+func (g *gasStore) newIterator(itr Iterator) Iterator {
+	gi := &gasIterator{Iterator: itr, gasStore: g}
+	gi.consumeValue()
+	return gi
+}
+
+//------- cached gas store
+
+// cacheableGasStore wraps a CacheableKVStore
+// and consumes gas for every operation
+type cacheableGasStore struct {
+	gasStore
+}
+
+var _ CacheableKVStore = (*cacheableGasStore)(nil)
+
+// CacheWrap makes sure all cache misses and writes go through this store
+func (g *cacheableGasStore) CacheWrap() KVCacheWrap {
+	return NewBTreeCacheWrap(g, g.NewBatch(), nil)
+}
+
+//----- gas iterator, consumes gas on every step
+
+type gasIterator struct {
+	Iterator
+	gasStore *gasStore
+}
+
+// Next consumes gas for the step and the next value read
+func (g *gasIterator) Next() {
+	g.Iterator.Next()
+	g.consumeValue()
+}
+
+func (g *gasIterator) consumeValue() {
+	if !g.Valid() {
+		return
+	}
+	meter, config := g.gasStore.meter, g.gasStore.config
+	meter.ConsumeGas(config.IterNextCostFlat, "iterator step")
+	meter.ConsumeGas(config.ReadCostPerByte*int64(len(g.Key())+len(g.Value())), "iterator bytes")
+}
+
+//----- gas batch, consumes gas on every write
+
+type gasBatch struct {
+	gasStore *gasStore
+	b        Batch
+}
+
+var _ Batch = (*gasBatch)(nil)
+
+// Set consumes gas for the write and the size of key and value
+func (g *gasBatch) Set(key, value []byte) {
+	g.gasStore.consumeWrite(key, value)
+	g.b.Set(key, value)
+}
+
+// Delete consumes gas for the delete
+func (g *gasBatch) Delete(key []byte) {
+	g.gasStore.meter.ConsumeGas(g.gasStore.config.DeleteCost, "delete")
+	g.b.Delete(key)
+}
+
+func (g *gasBatch) Write() {
+	g.b.Write()
+}
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iov-one/weave/errors"
+)
+
+func TestGasStore(t *testing.T) {
+	config := GasConfig{
+		HasCost:          1,
+		DeleteCost:       2,
+		ReadCostFlat:     10,
+		ReadCostPerByte:  1,
+		WriteCostFlat:    100,
+		WriteCostPerByte: 10,
+		IterNextCostFlat: 1000,
+	}
+
+	cases := map[string]struct {
+		op   func(db KVStore)
+		want int64
+	}{
+		"get missing": {
+			op:   func(db KVStore) { db.Get([]byte("missing")) },
+			want: 10,
+		},
+		"get existing": {
+			op:   func(db KVStore) { db.Get([]byte("foo")) },
+			want: 10 + 3,
+		},
+		"has": {
+			op:   func(db KVStore) { db.Has([]byte("foo")) },
+			want: 1,
+		},
+		"set": {
+			op:   func(db KVStore) { db.Set([]byte("ab"), []byte("cde")) },
+			want: 100 + 50,
+		},
+		"delete": {
+			op:   func(db KVStore) { db.Delete([]byte("foo")) },
+			want: 2,
+		},
+		"batch": {
+			op: func(db KVStore) {
+				b := db.NewBatch()
+				b.Set([]byte("ab"), []byte("cde"))
+				b.Delete([]byte("foo"))
+				b.Write()
+			},
+			want: 100 + 50 + 2,
+		},
+		"iterate": {
+			op: func(db KVStore) {
+				itr := db.Iterator(nil, nil)
+				for ; itr.Valid(); itr.Next() {
+				}
+				itr.Close()
+			},
+			want: 2*1000 + (3 + 3) + (4 + 4),
+		},
+		"cache wrap": {
+			op: func(db KVStore) {
+				cache := db.(CacheableKVStore).CacheWrap()
+				cache.Get([]byte("foo"))
+				cache.Set([]byte("ab"), []byte("cde"))
+				cache.Write()
+			},
+			want: 10 + 3 + 100 + 50,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := MemStore()
+			db.Set([]byte("foo"), []byte("bar"))
+			db.Set([]byte("long"), []byte("data"))
+
+			meter := NewGasMeter(1000000)
+			tc.op(NewGasStore(db, meter, config))
+			assert.Equal(t, tc.want, meter.GasConsumed())
+		})
+	}
+}
+
+func TestGasMeterLimit(t *testing.T) {
+	meter := NewGasMeter(100)
+	meter.ConsumeGas(60, "first")
+	assert.Equal(t, int64(60), meter.GasConsumed())
+	assert.Equal(t, int64(100), meter.GasLimit())
+
+	defer func() {
+		err, ok := recover().(error)
+		require.True(t, ok)
+		assert.True(t, errors.ErrOutOfGas.Is(err))
+	}()
+	meter.ConsumeGas(60, "second")
+	t.Fatal("must panic when out of gas")
+}
@@ -1,52 +1,85 @@
 package iavl
 
 import (
+	"bytes"
+
 	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	dbm "github.com/tendermint/tendermint/libs/db"
 
+	"github.com/iov-one/weave/errors"
 	"github.com/iov-one/weave/store"
 )
 
-// TODO: make these configurable?
+// Defaults used by NewCommitStore, see Options to change them
 const (
-	DefaultCacheSize int   = 10000
-	DefaultHistory   int64 = 20
+	DefaultCacheSize      int   = 10000
+	DefaultValueCacheSize int   = 10000
+	DefaultHistory        int64 = 20
 )
 
 // CommitStore manages a iavl committed state
 type CommitStore struct {
-	tree       *iavl.MutableTree
-	numHistory int64
+	tree    *iavl.MutableTree
+	pruning Pruning
+	// snapshot is set for a memdb backend written to disk
+	snapshot *snapshot
+	// values caches reads of the latest version, it may be nil
+	values *store.ValueCache
 }
 
 var _ store.CommitKVStore = CommitStore{}
 
-// NewCommitStore creates a new store with disk backing
+// NewCommitStore creates a new store with disk backing,
+// using the DefaultOptions
 func NewCommitStore(path, name string) CommitStore {
-	// Create the underlying leveldb datastore which will
-	// persist the Merkle tree inner & leaf nodes.
-	db, err := dbm.NewGoLevelDB(name, path)
+	commit, err := NewCommitStoreWithOptions(path, name, DefaultOptions())
 	if err != nil {
 		panic(err)
 	}
-
-	tree := iavl.NewMutableTree(db, DefaultCacheSize)
-	commit := CommitStore{tree, DefaultHistory}
-	commit.LoadLatestVersion()
 	return commit
 }
 
+// NewCommitStoreWithOptions creates a new store in the database
+// described by the options and loads its latest version
+func NewCommitStoreWithOptions(path, name string, opts Options) (CommitStore, error) {
+	if err := opts.Validate(); err != nil {
+		return CommitStore{}, err
+	}
+	if len(opts.Mounts) > 0 {
+		return CommitStore{}, errors.ErrInvalidInput.New("mounts require a multi store")
+	}
+	// Create the underlying datastore which will
+	// persist the Merkle tree inner & leaf nodes.
+	db, snap, err := openDB(path, name, opts)
+	if err != nil {
+		return CommitStore{}, errors.Wrap(err, "cannot open database")
+	}
+
+	tree := iavl.NewMutableTree(db, opts.CacheSize)
+	commit := CommitStore{
+		tree:     tree,
+		pruning:  opts.Pruning(),
+		snapshot: snap,
+		values:   store.NewValueCache(opts.ValueCacheSize),
+	}
+	if err := commit.LoadLatestVersion(); err != nil {
+		return CommitStore{}, errors.Wrap(err, "cannot load latest version")
+	}
+	return commit, nil
+}
+
 // NewCommitStoreFromTree accepts a preloaded MutableTree and wraps it
 // Mainly designed for test code... or devs who want full control
 func NewCommitStoreFromTree(tree *iavl.MutableTree) CommitStore {
-	return CommitStore{tree, DefaultHistory}
+	return CommitStore{tree: tree, pruning: KeepRecent(DefaultHistory)}
 }
 
 // MockCommitStore creates a new in-memory store for testing
 func MockCommitStore() CommitStore {
 	var db dbm.DB = dbm.NewMemDB()
 	tree := iavl.NewMutableTree(db, DefaultCacheSize)
-	return CommitStore{tree, DefaultHistory}
+	return CommitStore{tree: tree, pruning: KeepRecent(DefaultHistory)}
 }
 
 // Get returns the value at last committed state
@@ -64,10 +97,16 @@ func (s CommitStore) Commit() store.CommitID {
 		panic(err)
 	}
 
-	// Potentially release an old version of history
-	if s.numHistory > 0 && (s.numHistory < version) {
-		toRelease := version - s.numHistory
-		s.tree.DeleteVersion(toRelease)
+	// Potentially release an old version of history. It may already
+	// be gone, if this version was committed before a LoadVersion.
+	if old, ok := s.pruning.expired(version); ok {
+		s.tree.DeleteVersion(old)
+	}
+
+	if s.snapshot != nil {
+		if err := s.snapshot.maybeWrite(version); err != nil {
+			panic(err)
+		}
 	}
 
 	return store.CommitID{
@@ -80,10 +119,52 @@ func (s CommitStore) Commit() store.CommitID {
 // If there was a crash during the last commit, it is guaranteed
 // to return a stable state, even if older.
 func (s CommitStore) LoadLatestVersion() error {
+	s.values.Purge()
 	_, err := s.tree.Load()
 	return err
 }
 
+// LoadVersion loads a specific persisted version. All later versions
+// are kept, so committing them again must produce the same hash,
+// otherwise Commit panics. Use Rollback to replace them instead.
+func (s CommitStore) LoadVersion(version int64) error {
+	if err := s.checkPersisted(version); err != nil {
+		return err
+	}
+	s.values.Purge()
+	if _, err := s.tree.LoadVersion(version); err != nil {
+		return errors.Wrap(err, "cannot load version")
+	}
+	return nil
+}
+
+// Rollback loads a specific persisted version and deletes all later
+// versions, so the following blocks can be executed again with a
+// different result. It fails if any of the later versions was pruned.
+func (s CommitStore) Rollback(version int64) error {
+	if err := s.checkPersisted(version); err != nil {
+		return err
+	}
+	s.values.Purge()
+	latest, err := s.tree.Load()
+	if err != nil {
+		return errors.Wrap(err, "cannot load latest version")
+	}
+	// iavl can only delete a continuous range of versions
+	for v := version + 1; v <= latest; v++ {
+		if !s.tree.VersionExists(v) {
+			return store.ErrPruned.Newf("cannot rollback over version %d", v)
+		}
+	}
+	if _, err := s.tree.LoadVersionForOverwriting(version); err != nil {
+		return errors.Wrap(err, "cannot rollback")
+	}
+	if s.snapshot != nil {
+		return s.snapshot.write()
+	}
+	return nil
+}
+
 // LatestVersion returns info on the latest version saved to disk
 func (s CommitStore) LatestVersion() store.CommitID {
 	return store.CommitID{
@@ -92,6 +173,104 @@ func (s CommitStore) LatestVersion() store.CommitID {
 	}
 }
 
+// VersionExists returns true iff the given version was committed
+// and is still kept in the history (not pruned)
+func (s CommitStore) VersionExists(version int64) bool {
+	return s.tree.VersionExists(version)
+}
+
+// ReadOnlyVersion returns a read-only view of the tree as it was
+// committed at the given version.
+//
+// Returns ErrPruned if the version is no longer kept in the history.
+func (s CommitStore) ReadOnlyVersion(version int64) (store.ReadOnlyKVStore, error) {
+	if err := s.checkVersion(version); err != nil {
+		return nil, err
+	}
+	tree, err := s.tree.GetImmutable(version)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load version")
+	}
+	return readOnlyAdapter{tree}, nil
+}
+
+// GetVersionedWithProof returns the value stored under the key at the
+// given version, along with a merkle proof of its existence
+// (or absence if the value is nil)
+func (s CommitStore) GetVersionedWithProof(key []byte, version int64) ([]byte, []merkle.ProofOp, error) {
+	if err := s.checkVersion(version); err != nil {
+		return nil, nil, err
+	}
+	value, proof, err := s.tree.GetVersionedWithProof(key, version)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot build proof")
+	}
+	if value == nil {
+		return nil, []merkle.ProofOp{iavl.NewIAVLAbsenceOp(key, proof).ProofOp()}, nil
+	}
+	return value, []merkle.ProofOp{iavl.NewIAVLValueOp(key, proof).ProofOp()}, nil
+}
+
+// GetVersionedRangeWithProof returns all models in the given range
+// at the given version, along with a merkle proof that no other key
+// exists in this range. End is exclusive.
+func (s CommitStore) GetVersionedRangeWithProof(start, end []byte, version int64) ([]store.Model, []merkle.ProofOp, error) {
+	if err := s.checkVersion(version); err != nil {
+		return nil, nil, err
+	}
+	if start != nil && end != nil && bytes.Compare(start, end) >= 0 {
+		return nil, nil, errors.ErrInvalidInput.New("range start must be before end")
+	}
+	tree, err := s.tree.GetImmutable(version)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot load version")
+	}
+	models, proofs, err := rangeProof(tree, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	return models, []merkle.ProofOp{NewRangeOp(start, end, proofs).ProofOp()}, nil
+}
+
+// hashAt returns the root hash of the given version
+func (s CommitStore) hashAt(version int64) ([]byte, error) {
+	if version == s.tree.Version() {
+		return s.tree.Hash(), nil
+	}
+	tree, err := s.tree.GetImmutable(version)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load version")
+	}
+	return tree.Hash(), nil
+}
+
+// checkVersion returns an error if the given version is not
+// available for reading
+func (s CommitStore) checkVersion(version int64) error {
+	if version <= 0 || version > s.tree.Version() {
+		return errors.ErrInvalidInput.Newf("unknown version: %d", version)
+	}
+	if !s.tree.VersionExists(version) {
+		return store.ErrPruned.Newf("version: %d", version)
+	}
+	return nil
+}
+
+// checkPersisted returns an error if the given version
+// cannot be loaded from disk
+func (s CommitStore) checkPersisted(version int64) error {
+	switch {
+	case version <= 0:
+		return errors.ErrInvalidInput.Newf("unknown version: %d", version)
+	case s.tree.VersionExists(version):
+		return nil
+	case version < s.tree.Version():
+		return store.ErrPruned.Newf("version: %d", version)
+	default:
+		return errors.ErrInvalidInput.Newf("unknown version: %d", version)
+	}
+}
+
 // Adapter returns a wrapped version of the tree.
 //
 // Data written here is stored in the tip of the version tree,
@@ -99,8 +278,12 @@ func (s CommitStore) LatestVersion() store.CommitID {
 // to rollback writes here, without throwing away the CommitStore
 // and re-loading from disk.
 func (s CommitStore) Adapter() store.CacheableKVStore {
-	var kv store.KVStore = adapter{s.tree}
-	return store.BTreeCacheable{kv}
+	return store.BTreeCacheable{KVStore: s.values.Wrap(adapter{s.tree})}
+}
+
+// CacheStats returns the hits and misses of the value cache
+func (s CommitStore) CacheStats() store.CacheStats {
+	return s.values.Stats()
 }
 
 // CacheWrap wraps the Adapter with a cache, so it may be written
@@ -109,10 +292,6 @@ func (s CommitStore) CacheWrap() store.KVCacheWrap {
 	return s.Adapter().CacheWrap()
 }
 
-// func (b *Bonsai) GetVersionedWithProof(key []byte, version int64) ([]byte, iavl.KeyProof, error) {
-//   return b.Tree.GetVersionedWithProof(key, uint64(version))
-// }
-
 // TODO: create batch and reader and wrap the rest in btree...
 
 // adapter converts the working iavl.Tree to match these interfaces
@@ -152,26 +331,139 @@ func (a adapter) NewBatch() store.Batch {
 // Start must be less than end, or the Iterator is invalid.
 // CONTRACT: No writes may happen within a domain while an iterator exists over it.
 func (a adapter) Iterator(start, end []byte) store.Iterator {
-	var res []store.Model
-	add := func(key []byte, value []byte) bool {
-		m := store.Model{Key: key, Value: value}
-		res = append(res, m)
-		return false
-	}
-	a.tree.IterateRange(start, end, true, add)
-	return store.NewSliceIterator(res)
+	return iterateRange(a.tree.ImmutableTree, start, end, true)
 }
 
 // ReverseIterator over a domain of keys in descending order. End is exclusive.
 // Start must be greater than end, or the Iterator is invalid.
 // CONTRACT: No writes may happen within a domain while an iterator exists over it.
 func (a adapter) ReverseIterator(start, end []byte) store.Iterator {
-	var res []store.Model
-	add := func(key []byte, value []byte) bool {
-		m := store.Model{Key: key, Value: value}
-		res = append(res, m)
-		return false
-	}
-	a.tree.IterateRange(start, end, false, add)
-	return store.NewSliceIterator(res)
+	return iterateRange(a.tree.ImmutableTree, start, end, false)
+}
+
+// readOnlyAdapter exposes a historical version of the tree
+// as a ReadOnlyKVStore
+type readOnlyAdapter struct {
+	tree *iavl.ImmutableTree
+}
+
+var _ store.ReadOnlyKVStore = readOnlyAdapter{}
+
+// Get returns nil iff key doesn't exist. Panics on nil key.
+func (a readOnlyAdapter) Get(key []byte) []byte {
+	_, val := a.tree.Get(key)
+	return val
+}
+
+// Has checks if a key exists. Panics on nil key.
+func (a readOnlyAdapter) Has(key []byte) bool {
+	return a.tree.Has(key)
+}
+
+// Iterator over a domain of keys in ascending order. End is exclusive.
+func (a readOnlyAdapter) Iterator(start, end []byte) store.Iterator {
+	return iterateRange(a.tree, start, end, true)
+}
+
+// ReverseIterator over a domain of keys in descending order. End is exclusive.
+func (a readOnlyAdapter) ReverseIterator(start, end []byte) store.Iterator {
+	return iterateRange(a.tree, start, end, false)
+}
+
+// Chunk sizes of a treeIterator: the first chunk is small, so a query
+// reading a few models does not load the whole range
+const (
+	firstChunk = 16
+	maxChunk   = 1024
+)
+
+// iterateRange returns an Iterator loading the models of the range
+// from the tree in chunks, as they are read
+func iterateRange(tree *iavl.ImmutableTree, start, end []byte, ascending bool) store.Iterator {
+	it := &treeIterator{
+		tree:      tree,
+		start:     start,
+		end:       end,
+		ascending: ascending,
+		chunk:     firstChunk,
+	}
+	it.load()
+	return it
+}
+
+// treeIterator iterates over the models of a tree range in chunks,
+// every chunk doubles in size up to maxChunk
+type treeIterator struct {
+	tree *iavl.ImmutableTree
+	// start and end is the range not loaded yet
+	start, end []byte
+	ascending  bool
+	chunk      int
+	// done is set once the whole range is loaded
+	done   bool
+	models []store.Model
+	idx    int
+}
+
+var _ store.Iterator = (*treeIterator)(nil)
+
+// load reads the next chunk of the range
+func (t *treeIterator) load() {
+	t.models, t.idx = t.models[:0], 0
+	t.tree.IterateRange(t.start, t.end, t.ascending, func(key, value []byte) bool {
+		t.models = append(t.models, store.Model{Key: key, Value: value})
+		return len(t.models) == t.chunk
+	})
+	if len(t.models) < t.chunk {
+		t.done = true
+		return
+	}
+	last := t.models[len(t.models)-1].Key
+	if t.ascending {
+		t.start = append(copyKey(last), 0)
+	} else {
+		t.end = last
+	}
+	if t.chunk < maxChunk {
+		t.chunk *= 2
+	}
+}
+
+// Valid implements Iterator and returns true iff it can be read
+func (t *treeIterator) Valid() bool {
+	return t.idx < len(t.models)
+}
+
+// Next moves the iterator to the next model of the range.
+//
+// If Valid returns false, this method will panic.
+func (t *treeIterator) Next() {
+	t.assertValid()
+	t.idx++
+	if t.idx == len(t.models) && !t.done {
+		t.load()
+	}
+}
+
+func (t *treeIterator) assertValid() {
+	if !t.Valid() {
+		panic("Passed end of range")
+	}
+}
+
+// Key returns the key of the cursor.
+func (t *treeIterator) Key() (key []byte) {
+	t.assertValid()
+	return t.models[t.idx].Key
+}
+
+// Value returns the value of the cursor.
+func (t *treeIterator) Value() (value []byte) {
+	t.assertValid()
+	return t.models[t.idx].Value
+}
+
+// Close releases the Iterator.
+func (t *treeIterator) Close() {
+	t.models, t.idx, t.done = nil, 0, true
 }
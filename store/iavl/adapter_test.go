@@ -187,7 +187,7 @@ func TestCommitOverwrite(t *testing.T) {
 	for i, tc := range cases {
 		commit, close := makeCommitStore()
 		// only one to trigger a cleanup
-		commit.numHistory = 1
+		commit.pruning = KeepRecent(1)
 
 		id := commit.LatestVersion()
 		assert.Equal(t, int64(0), id.Version)
@@ -243,6 +243,50 @@ func TestCommitOverwrite(t *testing.T) {
 	}
 }
 
+// TestReadOnlyVersion checks that we can read historical versions
+// until they are pruned from the history
+func TestReadOnlyVersion(t *testing.T) {
+	commit, close := makeCommitStore()
+	defer close()
+	commit.pruning = KeepRecent(2)
+
+	k, k2 := []byte("team"), []byte("city")
+	v1, v2, v3 := []byte("Dodgers"), []byte("Angels"), []byte("Lakers")
+
+	// version 1 and 2 overwrite the same key, version 3 adds another
+	for _, op := range []Op{store.SetOp(k, v1), store.SetOp(k, v2), store.SetOp(k2, v3)} {
+		cache := commit.CacheWrap()
+		op.Apply(cache)
+		cache.Write()
+		commit.Commit()
+	}
+
+	_, err := commit.ReadOnlyVersion(4)
+	assert.Error(t, err)
+
+	// first version was pruned
+	assert.False(t, commit.VersionExists(1))
+	_, err = commit.ReadOnlyVersion(1)
+	assert.True(t, store.ErrPruned.Is(err), "%+v", err)
+
+	// second version only holds the overwritten key
+	assert.True(t, commit.VersionExists(2))
+	db, err := commit.ReadOnlyVersion(2)
+	require.NoError(t, err)
+	assert.Equal(t, v2, db.Get(k))
+	assert.False(t, db.Has(k2))
+	verifyIterator(t, []Model{store.Pair(k, v2)}, db.Iterator(nil, nil), "version 2")
+
+	// third version holds both
+	db, err = commit.ReadOnlyVersion(3)
+	require.NoError(t, err)
+	assert.Equal(t, v2, db.Get(k))
+	assert.Equal(t, v3, db.Get(k2))
+	expected := []Model{store.Pair(k2, v3), store.Pair(k, v2)}
+	verifyIterator(t, expected, db.Iterator(nil, nil), "version 3")
+	verifyIterator(t, reverse(expected), db.ReverseIterator(nil, nil), "version 3 reverse")
+}
+
 // TestFuzzCacheIterator makes sure the basic iterator
 // works. Includes random deletes, but not nested iterators.
 func TestFuzzCacheIterator(t *testing.T) {
@@ -533,3 +577,28 @@ func makeDelOps(ms ...Model) []Op {
 	}
 	return res
 }
+
+// TestChunkedIterator reads ranges spanning several chunks
+func TestChunkedIterator(t *testing.T) {
+	commit := MockCommitStore()
+	db := commit.Adapter()
+	var data []Model
+	for i := 0; i < 3*firstChunk+5; i++ {
+		m := store.Pair([]byte(fmt.Sprintf("key%04d", i)), []byte(fmt.Sprintf("value%d", i)))
+		data = append(data, m)
+		db.Set(m.Key, m.Value)
+	}
+	commit.Commit()
+	db = commit.Adapter()
+
+	verifyIterator(t, data, db.Iterator(nil, nil), "all")
+	verifyIterator(t, reverse(data), db.ReverseIterator(nil, nil), "all reverse")
+	verifyIterator(t, data[10:40], db.Iterator(data[10].Key, data[40].Key), "range")
+	verifyIterator(t, reverse(data[10:40]), db.ReverseIterator(data[10].Key, data[40].Key), "range reverse")
+	verifyIterator(t, data[:firstChunk], db.Iterator(nil, data[firstChunk].Key), "single chunk")
+
+	// only the first chunk is read before iterating
+	it := iterateRange(commit.tree.ImmutableTree, nil, nil, true).(*treeIterator)
+	assert.Len(t, it.models, firstChunk)
+	assert.False(t, it.done)
+}
@@ -0,0 +1,548 @@
+package iavl
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+)
+
+// MainStore is the name of the substore holding all keys
+// that are not assigned to a mounted substore
+const MainStore = "main"
+
+// Mount assigns all keys starting with any of the prefixes to the
+// named substore. To mount an extension, list the prefixes of all its
+// buckets ("<bucket>:") and indexes ("_i.<bucket>_").
+type Mount struct {
+	Name     string
+	Prefixes []string
+}
+
+// MultiStore is a CommitKVStore keeping the data of every mount in its
+// own iavl tree. All trees are committed together and the app hash is
+// the merkle root of their hashes, so proofs chain through both levels.
+//
+// Keys are routed to their substore, so it can be used like any
+// other store. Substores must be mounted from genesis on.
+type MultiStore struct {
+	// the main store is first
+	stores []substore
+	router Router
+	// snapshot is set for a memdb backend written to disk
+	snapshot *snapshot
+	// values caches reads of the latest version of all substores,
+	// it may be nil
+	values *store.ValueCache
+}
+
+type substore struct {
+	name   string
+	commit CommitStore
+}
+
+var _ store.CommitKVStore = MultiStore{}
+
+// NewMultiStore creates the main store and one substore per mount of
+// the options in the database they describe, and loads their latest
+// version
+func NewMultiStore(path, name string, opts Options) (MultiStore, error) {
+	if err := opts.Validate(); err != nil {
+		return MultiStore{}, err
+	}
+	db, snap, err := openDB(path, name, opts)
+	if err != nil {
+		return MultiStore{}, errors.Wrap(err, "cannot open database")
+	}
+
+	newStore := func(name string) substore {
+		subDB := dbm.NewPrefixDB(db, []byte("s/"+name+"/"))
+		tree := iavl.NewMutableTree(subDB, opts.CacheSize)
+		return substore{
+			name:   name,
+			commit: CommitStore{tree: tree, pruning: opts.Pruning()},
+		}
+	}
+	multi := MultiStore{
+		router:   NewRouter(opts.Mounts...),
+		snapshot: snap,
+		values:   store.NewValueCache(opts.ValueCacheSize),
+	}
+	multi.stores = append(multi.stores, newStore(MainStore))
+	for _, m := range opts.Mounts {
+		multi.stores = append(multi.stores, newStore(m.Name))
+	}
+	if err := multi.LoadLatestVersion(); err != nil {
+		return MultiStore{}, errors.Wrap(err, "cannot load latest version")
+	}
+	return multi, nil
+}
+
+// validateMounts ensures every key belongs to a single substore
+func validateMounts(mounts []Mount) error {
+	names := map[string]bool{MainStore: true}
+	var prefixes []string
+	for _, m := range mounts {
+		if m.Name == "" || names[m.Name] {
+			return errors.ErrInvalidInput.Newf("substore name: %q", m.Name)
+		}
+		names[m.Name] = true
+		if len(m.Prefixes) == 0 {
+			return errors.ErrInvalidInput.Newf("substore %s has no prefix", m.Name)
+		}
+		for _, p := range m.Prefixes {
+			if p == "" {
+				return errors.ErrInvalidInput.Newf("substore %s has an empty prefix", m.Name)
+			}
+			prefixes = append(prefixes, p)
+		}
+	}
+	// a prefix sorts directly before all prefixes it is a prefix of
+	sort.Strings(prefixes)
+	for i := 1; i < len(prefixes); i++ {
+		if bytes.HasPrefix([]byte(prefixes[i]), []byte(prefixes[i-1])) {
+			return errors.ErrInvalidInput.Newf("prefix %q overlaps %q", prefixes[i], prefixes[i-1])
+		}
+	}
+	return nil
+}
+
+// route returns the index of the substore holding the key
+func (m MultiStore) route(key []byte) int {
+	return m.router.index(key)
+}
+
+// routeRange returns the index of the substore holding all keys
+// of the range, or an error if it spans several substores
+func (m MultiStore) routeRange(start, end []byte) (int, error) {
+	return m.router.indexRange(start, end)
+}
+
+// Router assigns every key to the substore of a MultiStore holding it.
+// Proofs of a MultiStore are verified with the Router of its mounts.
+type Router struct {
+	// the main store is first
+	names    []string
+	prefixes [][][]byte
+}
+
+// NewRouter creates a Router for the given mounts
+func NewRouter(mounts ...Mount) Router {
+	r := Router{
+		names:    []string{MainStore},
+		prefixes: [][][]byte{nil},
+	}
+	for _, m := range mounts {
+		var prefixes [][]byte
+		for _, p := range m.Prefixes {
+			prefixes = append(prefixes, []byte(p))
+		}
+		r.names = append(r.names, m.Name)
+		r.prefixes = append(r.prefixes, prefixes)
+	}
+	return r
+}
+
+// Route returns the name of the substore holding the key
+func (r Router) Route(key []byte) string {
+	return r.names[r.index(key)]
+}
+
+// RouteRange returns the name of the substore holding all keys
+// of the range, or an error if it spans several substores
+func (r Router) RouteRange(start, end []byte) (string, error) {
+	i, err := r.indexRange(start, end)
+	if err != nil {
+		return "", err
+	}
+	return r.names[i], nil
+}
+
+func (r Router) index(key []byte) int {
+	for i, prefixes := range r.prefixes {
+		for _, p := range prefixes {
+			if bytes.HasPrefix(key, p) {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+func (r Router) indexRange(start, end []byte) (int, error) {
+	for i, prefixes := range r.prefixes {
+		for _, p := range prefixes {
+			pEnd := incrKey(p)
+			within := start != nil && bytes.Compare(p, start) <= 0 &&
+				(pEnd == nil || (end != nil && bytes.Compare(end, pEnd) <= 0))
+			if within {
+				return i, nil
+			}
+			overlaps := (end == nil || bytes.Compare(p, end) < 0) &&
+				(pEnd == nil || start == nil || bytes.Compare(start, pEnd) < 0)
+			if overlaps {
+				return 0, errors.ErrInvalidInput.Newf("range spans substore %s", r.names[i])
+			}
+		}
+	}
+	return 0, nil
+}
+
+// Get returns the value at last committed state
+// returns nil iff key doesn't exist. Panics on nil key.
+func (m MultiStore) Get(key []byte) []byte {
+	return m.stores[m.route(key)].commit.Get(key)
+}
+
+// Commit all substores to disk, and returns info
+func (m MultiStore) Commit() store.CommitID {
+	hashes := make(map[string][]byte, len(m.stores))
+	var version int64
+	for i, s := range m.stores {
+		id := s.commit.Commit()
+		if i > 0 && id.Version != version {
+			panic(errors.ErrInvalidState.Newf("substore %s committed version %d, not %d", s.name, id.Version, version))
+		}
+		version = id.Version
+		hashes[s.name] = id.Hash
+	}
+
+	if m.snapshot != nil {
+		if err := m.snapshot.maybeWrite(version); err != nil {
+			panic(err)
+		}
+	}
+
+	return store.CommitID{
+		Version: version,
+		Hash:    merkle.SimpleHashFromMap(hashes),
+	}
+}
+
+// LoadLatestVersion loads the latest version persisted by all substores.
+// If there was a crash during the last commit, some substores are ahead,
+// and they must commit the same version again.
+func (m MultiStore) LoadLatestVersion() error {
+	m.values.Purge()
+	latest := make([]int64, len(m.stores))
+	for i, s := range m.stores {
+		if err := s.commit.LoadLatestVersion(); err != nil {
+			return errors.Wrapf(err, "substore %s", s.name)
+		}
+		latest[i] = s.commit.tree.Version()
+	}
+	common := latest[0]
+	for _, v := range latest {
+		if v < common {
+			common = v
+		}
+	}
+	for i, s := range m.stores {
+		if latest[i] == common {
+			continue
+		}
+		if err := s.commit.LoadVersion(common); err != nil {
+			return errors.Wrapf(err, "substore %s", s.name)
+		}
+	}
+	return nil
+}
+
+// LoadVersion loads a specific persisted version of all substores,
+// see CommitStore.LoadVersion
+func (m MultiStore) LoadVersion(version int64) error {
+	m.values.Purge()
+	for _, s := range m.stores {
+		if err := s.commit.LoadVersion(version); err != nil {
+			return errors.Wrapf(err, "substore %s", s.name)
+		}
+	}
+	return nil
+}
+
+// Rollback loads a specific persisted version of all substores and
+// deletes all later versions, see CommitStore.Rollback
+func (m MultiStore) Rollback(version int64) error {
+	// check all substores first, so a failure leaves them untouched
+	for _, s := range m.stores {
+		if err := s.commit.checkPersisted(version); err != nil {
+			return errors.Wrapf(err, "substore %s", s.name)
+		}
+	}
+	m.values.Purge()
+	for _, s := range m.stores {
+		if err := s.commit.Rollback(version); err != nil {
+			return errors.Wrapf(err, "substore %s", s.name)
+		}
+	}
+	if m.snapshot != nil {
+		return m.snapshot.write()
+	}
+	return nil
+}
+
+// LatestVersion returns info on the latest version saved to disk
+func (m MultiStore) LatestVersion() store.CommitID {
+	version := m.stores[0].commit.LatestVersion().Version
+	if version == 0 {
+		return store.CommitID{}
+	}
+	hashes := make(map[string][]byte, len(m.stores))
+	for _, s := range m.stores {
+		hashes[s.name] = s.commit.LatestVersion().Hash
+	}
+	return store.CommitID{
+		Version: version,
+		Hash:    merkle.SimpleHashFromMap(hashes),
+	}
+}
+
+// VersionExists returns true iff the given version was committed
+// and is still kept in the history (not pruned) of all substores
+func (m MultiStore) VersionExists(version int64) bool {
+	for _, s := range m.stores {
+		if !s.commit.VersionExists(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadOnlyVersion returns a read-only view of all substores as they
+// were committed at the given version.
+//
+// Returns ErrPruned if the version is no longer kept in the history.
+func (m MultiStore) ReadOnlyVersion(version int64) (store.ReadOnlyKVStore, error) {
+	kvs := make([]store.ReadOnlyKVStore, len(m.stores))
+	for i, s := range m.stores {
+		kv, err := s.commit.ReadOnlyVersion(version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "substore %s", s.name)
+		}
+		kvs[i] = kv
+	}
+	return multiReader{m: m, kvs: kvs}, nil
+}
+
+// GetVersionedWithProof returns the value stored under the key at the
+// given version, along with a proof in the substore followed by
+// a proof of the substore root in the app hash
+func (m MultiStore) GetVersionedWithProof(key []byte, version int64) ([]byte, []merkle.ProofOp, error) {
+	s := m.stores[m.route(key)]
+	value, ops, err := s.commit.GetVersionedWithProof(key, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	op, err := m.rootProof(s.name, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, append(ops, op), nil
+}
+
+// GetVersionedRangeWithProof returns all models in the given range
+// at the given version, along with a proof in the substore followed by
+// a proof of the substore root in the app hash.
+// The range must be held by a single substore.
+func (m MultiStore) GetVersionedRangeWithProof(start, end []byte, version int64) ([]store.Model, []merkle.ProofOp, error) {
+	i, err := m.routeRange(start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := m.stores[i]
+	models, ops, err := s.commit.GetVersionedRangeWithProof(start, end, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	op, err := m.rootProof(s.name, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return models, append(ops, op), nil
+}
+
+// rootProof proves the hash of the named substore
+// in the app hash of the given version
+func (m MultiStore) rootProof(name string, version int64) (merkle.ProofOp, error) {
+	hashes := make(map[string][]byte, len(m.stores))
+	for _, s := range m.stores {
+		hash, err := s.commit.hashAt(version)
+		if err != nil {
+			return merkle.ProofOp{}, errors.Wrapf(err, "substore %s", s.name)
+		}
+		hashes[s.name] = hash
+	}
+	_, proofs, _ := merkle.SimpleProofsFromMap(hashes)
+	return merkle.NewSimpleValueOp([]byte(name), proofs[name]).ProofOp(), nil
+}
+
+// Adapter returns a wrapped version of all working trees,
+// routing every key to its substore
+func (m MultiStore) Adapter() store.CacheableKVStore {
+	kvs := make([]store.KVStore, len(m.stores))
+	readers := make([]store.ReadOnlyKVStore, len(m.stores))
+	for i, s := range m.stores {
+		kvs[i] = adapter{s.commit.tree}
+		readers[i] = kvs[i]
+	}
+	var kv store.KVStore = multiAdapter{multiReader: multiReader{m: m, kvs: readers}, kvs: kvs}
+	return store.BTreeCacheable{KVStore: m.values.Wrap(kv)}
+}
+
+// CacheStats returns the hits and misses of the value cache
+func (m MultiStore) CacheStats() store.CacheStats {
+	return m.values.Stats()
+}
+
+// CacheWrap wraps the Adapter with a cache, so it may be written
+// or discarded as needed.
+func (m MultiStore) CacheWrap() store.KVCacheWrap {
+	return m.Adapter().CacheWrap()
+}
+
+// multiReader routes reads to the substores
+type multiReader struct {
+	m   MultiStore
+	kvs []store.ReadOnlyKVStore
+}
+
+var _ store.ReadOnlyKVStore = multiReader{}
+
+// Get returns nil iff key doesn't exist. Panics on nil key.
+func (r multiReader) Get(key []byte) []byte {
+	return r.kvs[r.m.route(key)].Get(key)
+}
+
+// Has checks if a key exists. Panics on nil key.
+func (r multiReader) Has(key []byte) bool {
+	return r.kvs[r.m.route(key)].Has(key)
+}
+
+// Iterator over a domain of keys in ascending order. End is exclusive.
+func (r multiReader) Iterator(start, end []byte) store.Iterator {
+	if i, err := r.m.routeRange(start, end); err == nil {
+		return r.kvs[i].Iterator(start, end)
+	}
+	its := make([]store.Iterator, len(r.kvs))
+	for i, kv := range r.kvs {
+		its[i] = kv.Iterator(start, end)
+	}
+	return mergeIterators(its, true)
+}
+
+// ReverseIterator over a domain of keys in descending order. End is exclusive.
+func (r multiReader) ReverseIterator(start, end []byte) store.Iterator {
+	if i, err := r.m.routeRange(start, end); err == nil {
+		return r.kvs[i].ReverseIterator(start, end)
+	}
+	its := make([]store.Iterator, len(r.kvs))
+	for i, kv := range r.kvs {
+		its[i] = kv.ReverseIterator(start, end)
+	}
+	return mergeIterators(its, false)
+}
+
+// multiAdapter routes reads and writes to the working trees,
+// the reader holds the same trees
+type multiAdapter struct {
+	multiReader
+	kvs []store.KVStore
+}
+
+var _ store.KVStore = multiAdapter{}
+
+// Set adds a new value
+func (a multiAdapter) Set(key, value []byte) {
+	a.kvs[a.m.route(key)].Set(key, value)
+}
+
+// Delete removes from the tree
+func (a multiAdapter) Delete(key []byte) {
+	a.kvs[a.m.route(key)].Delete(key)
+}
+
+// NewBatch returns a batch that can write multiple ops atomically
+func (a multiAdapter) NewBatch() store.Batch {
+	return store.NewNonAtomicBatch(a)
+}
+
+// mergedIterator iterates over several iterators holding distinct
+// keys, always reading the next key from the one it comes first in
+type mergedIterator struct {
+	its       []store.Iterator
+	ascending bool
+	// current is the index of the iterator at the next key,
+	// or -1 once all are done
+	current int
+}
+
+var _ store.Iterator = (*mergedIterator)(nil)
+
+func mergeIterators(its []store.Iterator, ascending bool) store.Iterator {
+	m := &mergedIterator{its: its, ascending: ascending}
+	m.pick()
+	return m
+}
+
+// pick selects the iterator at the next key
+func (m *mergedIterator) pick() {
+	m.current = -1
+	for i, it := range m.its {
+		if !it.Valid() {
+			continue
+		}
+		if m.current < 0 {
+			m.current = i
+			continue
+		}
+		cmp := bytes.Compare(it.Key(), m.its[m.current].Key())
+		if (m.ascending && cmp < 0) || (!m.ascending && cmp > 0) {
+			m.current = i
+		}
+	}
+}
+
+// Valid implements Iterator and returns true iff it can be read
+func (m *mergedIterator) Valid() bool {
+	return m.current >= 0
+}
+
+// Next moves the iterator to the next key of all iterators.
+//
+// If Valid returns false, this method will panic.
+func (m *mergedIterator) Next() {
+	m.assertValid()
+	m.its[m.current].Next()
+	m.pick()
+}
+
+func (m *mergedIterator) assertValid() {
+	if m.current < 0 {
+		panic("Passed end of iterators")
+	}
+}
+
+// Key returns the key of the cursor.
+func (m *mergedIterator) Key() (key []byte) {
+	m.assertValid()
+	return m.its[m.current].Key()
+}
+
+// Value returns the value of the cursor.
+func (m *mergedIterator) Value() (value []byte) {
+	m.assertValid()
+	return m.its[m.current].Value()
+}
+
+// Close releases all iterators.
+func (m *mergedIterator) Close() {
+	for _, it := range m.its {
+		it.Close()
+	}
+	m.current = -1
+}
@@ -0,0 +1,187 @@
+package iavl
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+)
+
+var testMounts = []Mount{
+	{Name: "cash", Prefixes: []string{"cash:", "_i.cash_"}},
+	{Name: "escrow", Prefixes: []string{"esc:"}},
+}
+
+// testOptions mount the testMounts and persist every version,
+// without locking the database so it can be reloaded
+func testOptions() Options {
+	opts := DefaultOptions()
+	opts.Backend = MemDBBackend
+	opts.SnapshotInterval = 1
+	opts.Mounts = testMounts
+	return opts
+}
+
+func makeMultiStore(t *testing.T) (string, MultiStore, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "iavl-multi-")
+	require.NoError(t, err)
+	multi, err := NewMultiStore(dir, "multi", testOptions())
+	require.NoError(t, err)
+	return dir, multi, func() { os.RemoveAll(dir) }
+}
+
+func TestMultiStoreRouting(t *testing.T) {
+	dir, multi, close := makeMultiStore(t)
+	defer close()
+
+	db := multi.CacheWrap()
+	data := []Model{
+		store.Pair([]byte("_i.cash_owner:1"), []byte("index")),
+		store.Pair([]byte("cash:1"), []byte("coins")),
+		store.Pair([]byte("cron:1"), []byte("task")),
+		store.Pair([]byte("esc:1"), []byte("escrow")),
+	}
+	for _, m := range data {
+		db.Set(m.Key, m.Value)
+	}
+	db.Write()
+	id := multi.Commit()
+	assert.Equal(t, int64(1), id.Version)
+	assert.Equal(t, id, multi.LatestVersion())
+
+	// every key is in its own tree
+	main, cash, escrow := multi.stores[0].commit, multi.stores[1].commit, multi.stores[2].commit
+	assert.Equal(t, []byte("task"), main.Get([]byte("cron:1")))
+	assert.Nil(t, main.Get([]byte("cash:1")))
+	assert.Equal(t, []byte("coins"), cash.Get([]byte("cash:1")))
+	assert.Equal(t, []byte("index"), cash.Get([]byte("_i.cash_owner:1")))
+	assert.Equal(t, []byte("escrow"), escrow.Get([]byte("esc:1")))
+
+	// the app hash is the root of all tree hashes
+	expected := merkle.SimpleHashFromMap(map[string][]byte{
+		MainStore: main.LatestVersion().Hash,
+		"cash":    cash.LatestVersion().Hash,
+		"escrow":  escrow.LatestVersion().Hash,
+	})
+	assert.Equal(t, expected, id.Hash)
+
+	// reads and iterations combine all trees
+	assert.Equal(t, []byte("coins"), multi.Get([]byte("cash:1")))
+	verifyIterator(t, data, multi.Adapter().Iterator(nil, nil), "working")
+	verifyIterator(t, reverse(data), multi.Adapter().ReverseIterator(nil, nil), "working reverse")
+	view, err := multi.ReadOnlyVersion(1)
+	require.NoError(t, err)
+	assert.True(t, view.Has([]byte("esc:1")))
+	verifyIterator(t, data[1:3], view.Iterator([]byte("cash:"), []byte("d")), "version 1")
+	verifyIterator(t, reverse(data[:3]), view.ReverseIterator(nil, []byte("d")), "version 1 reverse")
+	// a range of a single substore
+	verifyIterator(t, data[1:2], multi.Adapter().Iterator([]byte("cash:"), []byte("cash;")), "substore")
+
+	// data is reloaded from disk
+	reloaded, err := NewMultiStore(dir, "multi", testOptions())
+	require.NoError(t, err)
+	assert.Equal(t, id, reloaded.LatestVersion())
+	assert.Equal(t, []byte("escrow"), reloaded.Get([]byte("esc:1")))
+}
+
+func TestMultiStoreProof(t *testing.T) {
+	_, multi, close := makeMultiStore(t)
+	defer close()
+
+	multi.Adapter().Set([]byte("cash:1"), []byte("coins"))
+	multi.Adapter().Set([]byte("cron:1"), []byte("task"))
+	id := multi.Commit()
+
+	prt := NewProofRuntime()
+	value, ops, err := multi.GetVersionedWithProof([]byte("cash:1"), id.Version)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	keyPath := merkle.KeyPath{}.AppendKey([]byte("cash"), merkle.KeyEncodingURL).
+		AppendKey([]byte("cash:1"), merkle.KeyEncodingURL)
+	err = prt.VerifyValue(&merkle.Proof{Ops: ops}, id.Hash, keyPath.String(), value)
+	assert.NoError(t, err)
+
+	// a range of the main store
+	models, ops, err := multi.GetVersionedRangeWithProof([]byte("cron:"), []byte("cron;"), id.Version)
+	require.NoError(t, err)
+	assert.Equal(t, []Model{store.Pair([]byte("cron:1"), []byte("task"))}, models)
+	require.Len(t, ops, 2)
+	assert.Equal(t, []byte(MainStore), ops[1].Key)
+
+	// a range of several stores cannot be proven
+	_, _, err = multi.GetVersionedRangeWithProof([]byte("a"), []byte("d"), id.Version)
+	assert.True(t, errors.ErrInvalidInput.Is(err), "%+v", err)
+	_, _, err = multi.GetVersionedRangeWithProof(nil, nil, id.Version)
+	assert.True(t, errors.ErrInvalidInput.Is(err), "%+v", err)
+}
+
+func TestMultiStoreRecovery(t *testing.T) {
+	dir, multi, close := makeMultiStore(t)
+	defer close()
+
+	multi.Adapter().Set([]byte("cash:1"), []byte("one"))
+	first := multi.Commit()
+	multi.Adapter().Set([]byte("cash:1"), []byte("two"))
+	multi.Adapter().Set([]byte("esc:1"), []byte("two"))
+	second := multi.Commit()
+
+	// simulate a crash during the commit of the third version
+	cash := multi.stores[1].commit
+	cash.Adapter().Set([]byte("cash:1"), []byte("three"))
+	cash.Commit()
+	require.NoError(t, multi.snapshot.write())
+
+	reloaded, err := NewMultiStore(dir, "multi", testOptions())
+	require.NoError(t, err)
+	assert.Equal(t, second, reloaded.LatestVersion())
+
+	// rollback applies to all substores
+	require.NoError(t, reloaded.Rollback(1))
+	assert.Equal(t, first, reloaded.LatestVersion())
+	assert.Nil(t, reloaded.Get([]byte("esc:1")))
+	assert.False(t, reloaded.VersionExists(2))
+}
+
+func TestValidateMounts(t *testing.T) {
+	cases := map[string]struct {
+		mounts  []Mount
+		wantErr bool
+	}{
+		"valid": {
+			mounts: testMounts,
+		},
+		"main store": {
+			mounts:  []Mount{{Name: MainStore, Prefixes: []string{"a"}}},
+			wantErr: true,
+		},
+		"duplicate name": {
+			mounts:  []Mount{{Name: "a", Prefixes: []string{"a"}}, {Name: "a", Prefixes: []string{"b"}}},
+			wantErr: true,
+		},
+		"no prefix": {
+			mounts:  []Mount{{Name: "a"}},
+			wantErr: true,
+		},
+		"overlapping prefixes": {
+			mounts:  []Mount{{Name: "a", Prefixes: []string{"cash"}}, {Name: "b", Prefixes: []string{"cash:"}}},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateMounts(tc.mounts)
+			if tc.wantErr {
+				assert.True(t, errors.ErrInvalidInput.Is(err), "%+v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
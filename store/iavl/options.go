@@ -0,0 +1,316 @@
+package iavl
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+)
+
+// Supported database backends
+const (
+	GoLevelDBBackend = string(dbm.GoLevelDBBackend)
+	// CLevelDBBackend requires a build with the gcc tag
+	CLevelDBBackend = string(dbm.CLevelDBBackend)
+	FSDBBackend     = string(dbm.FSDBBackend)
+	// MemDBBackend keeps all data in memory, it is only
+	// persisted if a snapshot interval is set
+	MemDBBackend = string(dbm.MemDBBackend)
+)
+
+// OptionsFile is the name of the file applications read
+// the Options from, next to their database
+const OptionsFile = "store.json"
+
+// Options configure how a CommitStore persists its data
+type Options struct {
+	// Backend is the database type, one of the supported backends
+	Backend string `json:"backend"`
+	// CacheSize is the number of tree nodes cached in memory
+	CacheSize int `json:"cache_size"`
+	// ValueCacheSize is the number of values of the latest version
+	// cached in memory, zero disables the cache
+	ValueCacheSize int `json:"value_cache_size"`
+	// History is the number of versions kept, zero keeps all versions
+	History int64 `json:"history"`
+	// KeepEvery keeps every version that is a multiple of it,
+	// even when it is older than the history
+	KeepEvery int64 `json:"keep_every"`
+	// Sync flushes every commit to disk before it returns
+	Sync bool `json:"sync"`
+	// SnapshotInterval is the number of versions after which the memdb
+	// backend writes all data to disk, zero never writes it
+	SnapshotInterval int64 `json:"snapshot_interval"`
+	// Mounts keep the keys with their prefixes in their own trees of
+	// a MultiStore. They must be set from genesis on.
+	Mounts []Mount `json:"mounts"`
+	// ReadOnly opens the database without ever writing to it,
+	// the store cannot be committed. It is set by OpenReadOnlyStore.
+	ReadOnly bool `json:"-"`
+}
+
+// DefaultOptions returns the options used by NewCommitStore
+func DefaultOptions() Options {
+	return Options{
+		Backend:        GoLevelDBBackend,
+		CacheSize:      DefaultCacheSize,
+		ValueCacheSize: DefaultValueCacheSize,
+		History:        DefaultHistory,
+	}
+}
+
+// ReadOptions loads the options from a json file, every value not
+// set in the file keeps its default. A missing file gives the defaults.
+func ReadOptions(file string) (Options, error) {
+	opts := DefaultOptions()
+	raw, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return opts, nil
+	} else if err != nil {
+		return opts, errors.Wrap(err, "cannot read store options")
+	}
+	if err := json.Unmarshal(raw, &opts); err != nil {
+		return opts, errors.ErrInvalidInput.Newf("store options %s: %s", file, err)
+	}
+	return opts, opts.Validate()
+}
+
+// Validate ensures the options can be used
+func (o Options) Validate() error {
+	switch o.Backend {
+	case GoLevelDBBackend, CLevelDBBackend, FSDBBackend, MemDBBackend:
+	default:
+		return errors.ErrInvalidInput.Newf("unknown backend: %q", o.Backend)
+	}
+	if o.CacheSize <= 0 {
+		return errors.ErrInvalidInput.Newf("cache size: %d", o.CacheSize)
+	}
+	if o.ValueCacheSize < 0 {
+		return errors.ErrInvalidInput.Newf("value cache size: %d", o.ValueCacheSize)
+	}
+	if err := o.Pruning().Validate(); err != nil {
+		return err
+	}
+	if o.SnapshotInterval < 0 {
+		return errors.ErrInvalidInput.Newf("snapshot interval: %d", o.SnapshotInterval)
+	}
+	if o.SnapshotInterval > 0 && o.Backend != MemDBBackend {
+		return errors.ErrInvalidInput.Newf("snapshots require the %s backend", MemDBBackend)
+	}
+	if o.ReadOnly && o.Backend != GoLevelDBBackend && o.Backend != MemDBBackend {
+		return errors.ErrInvalidInput.Newf("%s cannot be opened read-only", o.Backend)
+	}
+	return validateMounts(o.Mounts)
+}
+
+// Store is implemented by the CommitStore and the MultiStore
+type Store interface {
+	store.CommitKVStore
+	// Rollback loads a persisted version and deletes all later ones
+	Rollback(version int64) error
+}
+
+var (
+	_ Store = CommitStore{}
+	_ Store = MultiStore{}
+)
+
+// NewStore creates a MultiStore if the options mount any substore,
+// and a CommitStore otherwise
+func NewStore(path, name string, opts Options) (Store, error) {
+	if len(opts.Mounts) > 0 {
+		return NewMultiStore(path, name, opts)
+	}
+	return NewCommitStoreWithOptions(path, name, opts)
+}
+
+// OpenStore opens the store of an application at dbPath (eg. <home>/abci.db),
+// configured by the OptionsFile in the same directory, if there is one.
+// Nodes and all offline commands must open their store this way.
+func OpenStore(dbPath string) (Store, error) {
+	return openStore(dbPath, false)
+}
+
+// OpenReadOnlyStore opens the store like OpenStore, but never writes
+// to the database. A goleveldb database can be read this way while
+// another process holds it open read-only.
+func OpenReadOnlyStore(dbPath string) (Store, error) {
+	return openStore(dbPath, true)
+}
+
+func openStore(dbPath string, readOnly bool) (Store, error) {
+	// Expand the path fully
+	path, err := filepath.Abs(dbPath)
+	if err != nil {
+		return nil, errors.ErrInvalidInput.Newf("database name: %s", dbPath)
+	}
+	// Some external calls accidently add a ".db", which is now removed
+	path = strings.TrimSuffix(path, filepath.Ext(path))
+
+	dir, name := filepath.Dir(path), filepath.Base(path)
+	opts, err := ReadOptions(filepath.Join(dir, OptionsFile))
+	if err != nil {
+		return nil, err
+	}
+	opts.ReadOnly = readOnly
+	return NewStore(dir, name, opts)
+}
+
+// Pruning returns the strategy deleting old versions
+func (o Options) Pruning() Pruning {
+	return KeepSnapshots(o.History, o.KeepEvery)
+}
+
+// openDB creates the database described by the options, loading the
+// last snapshot for the memdb backend
+func openDB(path, name string, opts Options) (db dbm.DB, snap *snapshot, err error) {
+	// unsupported backends panic
+	defer errors.Recover(&err)
+
+	switch {
+	case opts.Backend == MemDBBackend:
+		mem := dbm.NewMemDB()
+		if opts.SnapshotInterval > 0 {
+			snap = &snapshot{
+				db:       mem,
+				file:     filepath.Join(path, name+".snapshot"),
+				interval: opts.SnapshotInterval,
+			}
+			if err := snap.load(); err != nil {
+				return nil, nil, err
+			}
+		}
+		if opts.ReadOnly {
+			// the snapshot is never written again
+			snap = nil
+		}
+		db = mem
+	case opts.ReadOnly:
+		db, err = dbm.NewGoLevelDBWithOpts(name, path, &opt.Options{ReadOnly: true})
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		db = dbm.NewDB(name, dbm.DBBackendType(opts.Backend), path)
+	}
+	if opts.Sync {
+		db = syncDB{db}
+	}
+	return db, snap, nil
+}
+
+// syncDB flushes every batch to disk before the write returns
+type syncDB struct {
+	dbm.DB
+}
+
+func (d syncDB) NewBatch() dbm.Batch {
+	return syncBatch{d.DB.NewBatch()}
+}
+
+type syncBatch struct {
+	dbm.Batch
+}
+
+func (b syncBatch) Write() {
+	b.Batch.WriteSync()
+}
+
+// snapshot writes the whole content of a memdb to a file,
+// as a sequence of length prefixed keys and values
+type snapshot struct {
+	db       dbm.DB
+	file     string
+	interval int64
+}
+
+// load reads the snapshot file into the database, if it exists
+func (s *snapshot) load() error {
+	f, err := os.Open(s.file)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "cannot open snapshot")
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		key, err := readChunk(r)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "cannot read snapshot")
+		}
+		value, err := readChunk(r)
+		if err != nil {
+			return errors.Wrap(err, "cannot read snapshot")
+		}
+		s.db.Set(key, value)
+	}
+}
+
+// maybeWrite writes the snapshot if the version is due
+func (s *snapshot) maybeWrite(version int64) error {
+	if version%s.interval != 0 {
+		return nil
+	}
+	return s.write()
+}
+
+// write replaces the snapshot file atomically
+func (s *snapshot) write() error {
+	tmp := s.file + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return errors.Wrap(err, "cannot create snapshot")
+	}
+	w := bufio.NewWriter(f)
+	it := s.db.Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		writeChunk(w, it.Key())
+		writeChunk(w, it.Value())
+	}
+	it.Close()
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return errors.Wrap(err, "cannot write snapshot")
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.Wrap(err, "cannot write snapshot")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "cannot write snapshot")
+	}
+	return os.Rename(tmp, s.file)
+}
+
+func writeChunk(w *bufio.Writer, b []byte) {
+	var size [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(size[:], uint64(len(b)))
+	w.Write(size[:n])
+	w.Write(b)
+}
+
+func readChunk(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
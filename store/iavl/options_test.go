@@ -0,0 +1,178 @@
+package iavl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/iov-one/weave/errors"
+)
+
+func TestReadOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iavl-options-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cases := map[string]struct {
+		content string
+		want    Options
+		wantErr *errors.Error
+	}{
+		"missing file": {
+			want: DefaultOptions(),
+		},
+		"archive node": {
+			content: `{"history": 0, "cache_size": 100000, "value_cache_size": 0}`,
+			want:    Options{Backend: GoLevelDBBackend, CacheSize: 100000},
+		},
+		"memdb with snapshots": {
+			content: `{"backend": "memdb", "snapshot_interval": 10, "sync": true}`,
+			want: Options{
+				Backend:          MemDBBackend,
+				CacheSize:        DefaultCacheSize,
+				ValueCacheSize:   DefaultValueCacheSize,
+				History:          DefaultHistory,
+				Sync:             true,
+				SnapshotInterval: 10,
+			},
+		},
+		"mounts": {
+			content: `{"mounts": [{"name": "cash", "prefixes": ["cash:"]}]}`,
+			want: Options{
+				Backend:        GoLevelDBBackend,
+				CacheSize:      DefaultCacheSize,
+				ValueCacheSize: DefaultValueCacheSize,
+				History:        DefaultHistory,
+				Mounts:         []Mount{{Name: "cash", Prefixes: []string{"cash:"}}},
+			},
+		},
+		"overlapping mounts": {
+			content: `{"mounts": [{"name": "a", "prefixes": ["ca"]}, {"name": "b", "prefixes": ["cash:"]}]}`,
+			wantErr: &errors.ErrInvalidInput,
+		},
+		"unknown backend": {
+			content: `{"backend": "rocksdb"}`,
+			wantErr: &errors.ErrInvalidInput,
+		},
+		"snapshot of a leveldb": {
+			content: `{"snapshot_interval": 10}`,
+			wantErr: &errors.ErrInvalidInput,
+		},
+		"negative value cache": {
+			content: `{"value_cache_size": -1}`,
+			wantErr: &errors.ErrInvalidInput,
+		},
+		"invalid json": {
+			content: `{"history": "all"}`,
+			wantErr: &errors.ErrInvalidInput,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(dir, "missing.json")
+			if tc.content != "" {
+				file = filepath.Join(dir, name+".json")
+				require.NoError(t, ioutil.WriteFile(file, []byte(tc.content), 0644))
+			}
+			opts, err := ReadOptions(file)
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, tc.wantErr.Is(err), "%+v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, opts)
+		})
+	}
+}
+
+func TestMemDBSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iavl-snapshot-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := DefaultOptions()
+	opts.Backend = MemDBBackend
+	opts.SnapshotInterval = 2
+	commit, err := NewCommitStoreWithOptions(dir, "snap", opts)
+	require.NoError(t, err)
+
+	k := []byte("key")
+	commit.Adapter().Set(k, []byte("one"))
+	commit.Commit()
+	commit.Adapter().Set(k, []byte("two"))
+	second := commit.Commit()
+	// not written, as the third version is not due
+	commit.Adapter().Set(k, []byte("three"))
+	commit.Commit()
+
+	reloaded, err := NewCommitStoreWithOptions(dir, "snap", opts)
+	require.NoError(t, err)
+	assert.Equal(t, second, reloaded.LatestVersion())
+	assert.Equal(t, []byte("two"), reloaded.Get(k))
+}
+
+func TestUnlimitedHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iavl-history-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := DefaultOptions()
+	opts.History = 0
+	opts.Sync = true
+	commit, err := NewCommitStoreWithOptions(dir, "archive", opts)
+	require.NoError(t, err)
+	for i := 0; i < int(DefaultHistory)+5; i++ {
+		commit.Adapter().Set([]byte("key"), []byte{byte(i)})
+		commit.Commit()
+	}
+	assert.True(t, commit.VersionExists(1))
+}
+
+func TestOpenStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iavl-open-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	dbPath := filepath.Join(dir, "abci.db")
+
+	kv, err := OpenStore(dbPath)
+	require.NoError(t, err)
+	assert.IsType(t, CommitStore{}, kv)
+
+	options := `{"backend": "memdb", "mounts": [{"name": "cash", "prefixes": ["cash:"]}]}`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, OptionsFile), []byte(options), 0644))
+	kv, err = OpenStore(dbPath)
+	require.NoError(t, err)
+	assert.IsType(t, MultiStore{}, kv)
+}
+
+func TestOpenReadOnlyStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iavl-readonly-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// write a version and release the database lock
+	db, err := dbm.NewGoLevelDB("abci", dir)
+	require.NoError(t, err)
+	tree := iavl.NewMutableTree(db, DefaultCacheSize)
+	tree.Set([]byte("key"), []byte("value"))
+	_, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+	db.Close()
+
+	dbPath := filepath.Join(dir, "abci.db")
+	kv, err := OpenReadOnlyStore(dbPath)
+	require.NoError(t, err)
+	assert.Equal(t, version, kv.LatestVersion().Version)
+	assert.Equal(t, []byte("value"), kv.Get([]byte("key")))
+	// other readers share the database
+	_, err = OpenReadOnlyStore(dbPath)
+	require.NoError(t, err)
+	assert.Panics(t, func() { kv.Commit() })
+}
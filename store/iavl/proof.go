@@ -0,0 +1,272 @@
+package iavl
+
+import (
+	"bytes"
+
+	amino "github.com/tendermint/go-amino"
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+)
+
+// ProofOpRange is the type of a RangeOp when serialized as merkle.ProofOp
+const ProofOpRange = "weave:range"
+
+var cdc = amino.NewCodec()
+
+// RangeOp proves the content of all keys in the [Start, End) range.
+//
+// Unlike iavl value and absence operations, it takes all
+// key-value pairs of the range as arguments and fails if
+// the proof contains any other key in this range.
+//
+// The proof is split into segments of consecutive leaves, as iavl
+// cannot prove a key directly followed by a longer key with the
+// same prefix in one range proof.
+type RangeOp struct {
+	Start []byte `json:"start"`
+	End   []byte `json:"end"`
+	// Proofs is empty for an empty tree
+	Proofs []*iavl.RangeProof `json:"proofs"`
+}
+
+var _ merkle.ProofOperator = RangeOp{}
+
+// NewRangeOp creates a proof operation for the given range
+func NewRangeOp(start, end []byte, proofs []*iavl.RangeProof) RangeOp {
+	return RangeOp{
+		Start:  start,
+		End:    end,
+		Proofs: proofs,
+	}
+}
+
+// RangeOpDecoder decodes a serialized RangeOp
+func RangeOpDecoder(pop merkle.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpRange {
+		return nil, errors.ErrInvalidType.Newf("proof op %s", pop.Type)
+	}
+	var op RangeOp
+	if err := cdc.UnmarshalBinaryLengthPrefixed(pop.Data, &op); err != nil {
+		return nil, errors.Wrap(err, "cannot decode range op")
+	}
+	return op, nil
+}
+
+// NewProofRuntime returns a runtime able to decode all proof operations
+// returned by the CommitStore and the MultiStore
+func NewProofRuntime() *merkle.ProofRuntime {
+	prt := merkle.NewProofRuntime()
+	prt.RegisterOpDecoder(merkle.ProofOpSimpleValue, merkle.SimpleValueOpDecoder)
+	prt.RegisterOpDecoder(iavl.ProofOpIAVLValue, iavl.IAVLValueOpDecoder)
+	prt.RegisterOpDecoder(iavl.ProofOpIAVLAbsence, iavl.IAVLAbsenceOpDecoder)
+	prt.RegisterOpDecoder(ProofOpRange, RangeOpDecoder)
+	return prt
+}
+
+// ProofOp serializes the operation
+func (op RangeOp) ProofOp() merkle.ProofOp {
+	return merkle.ProofOp{
+		Type: ProofOpRange,
+		Key:  op.Start,
+		Data: cdc.MustMarshalBinaryLengthPrefixed(op),
+	}
+}
+
+// GetKey returns the start of the range
+func (op RangeOp) GetKey() []byte {
+	return op.Start
+}
+
+// Contains returns true iff the key is inside of the proven range
+func (op RangeOp) Contains(key []byte) bool {
+	return (op.Start == nil || bytes.Compare(op.Start, key) <= 0) &&
+		(op.End == nil || bytes.Compare(key, op.End) < 0)
+}
+
+// Run expects all keys and values of the range, flattened as
+// key, value, key, value... in ascending order.
+// It returns the root hash computed from the proof.
+func (op RangeOp) Run(args [][]byte) ([][]byte, error) {
+	if len(args)%2 != 0 {
+		return nil, store.ErrInvalidProof.New("range op expects key-value pairs")
+	}
+	// empty tree has no proof and cannot hold any value
+	if len(op.Proofs) == 0 {
+		if len(args) != 0 {
+			return nil, store.ErrInvalidProof.New("values in an empty tree")
+		}
+		return [][]byte{nil}, nil
+	}
+
+	root := op.ComputeRootHash()
+	leaves, err := op.verifySegments(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := op.verifyBounds(leaves); err != nil {
+		return nil, err
+	}
+
+	// all leaves inside of the range must match the arguments
+	var i int
+	for _, leaf := range leaves {
+		if !op.Contains(leaf.key) {
+			continue
+		}
+		if i >= len(args) {
+			return nil, store.ErrInvalidProof.Newf("missing key %X", leaf.key)
+		}
+		if !bytes.Equal(leaf.key, args[i]) {
+			return nil, store.ErrInvalidProof.Newf("unexpected key %X", args[i])
+		}
+		if !bytes.Equal(leaf.valueHash, tmhash.Sum(args[i+1])) {
+			return nil, store.ErrInvalidProof.Newf("invalid value for key %X", args[i])
+		}
+		i += 2
+	}
+	if i != len(args) {
+		return nil, store.ErrInvalidProof.Newf("unexpected key %X", args[i])
+	}
+	return [][]byte{root}, nil
+}
+
+// ComputeRootHash returns the root hash claimed by the proof,
+// Run verifies it
+func (op RangeOp) ComputeRootHash() []byte {
+	if len(op.Proofs) == 0 {
+		return nil
+	}
+	return op.Proofs[0].ComputeRootHash()
+}
+
+// proofLeaf is a leaf of a verified segment
+type proofLeaf struct {
+	key       []byte
+	valueHash []byte
+}
+
+// verifySegments ensures all segments belong to the tree with the given
+// root and follow each other without a gap. It returns all their leaves.
+func (op RangeOp) verifySegments(root []byte) ([]proofLeaf, error) {
+	var leaves []proofLeaf
+	next := op.Proofs[0].LeftIndex()
+	for _, proof := range op.Proofs {
+		if proof == nil {
+			return nil, store.ErrInvalidProof.New("missing segment")
+		}
+		if err := proof.Verify(root); err != nil {
+			return nil, errors.Wrap(store.ErrInvalidProof, err.Error())
+		}
+		// the size of every node is part of its hash
+		if index := proof.LeftIndex(); index < 0 || index != next {
+			return nil, store.ErrInvalidProof.Newf("segment at leaf %d, expected %d", index, next)
+		}
+		for _, l := range proof.Leaves {
+			leaves = append(leaves, proofLeaf{key: l.Key, valueHash: l.ValueHash})
+		}
+		next += int64(len(proof.Leaves))
+	}
+	return leaves, nil
+}
+
+// verifyBounds ensures that the proof leaves cover the whole range,
+// so no key could have been left out at the edges.
+// The segments must be verified before calling this.
+func (op RangeOp) verifyBounds(leaves []proofLeaf) error {
+	first, last := leaves[0].key, leaves[len(leaves)-1].key
+
+	// left side must start before the range or at the first leaf of the tree
+	leftmost := op.Proofs[0].LeftIndex() == 0
+	if !leftmost && (op.Start == nil || bytes.Compare(first, op.Start) > 0) {
+		return store.ErrInvalidProof.New("range start not covered")
+	}
+
+	// right side must reach the first key after the range
+	// or the last leaf of the tree
+	if op.End != nil && bytes.Compare(last, op.End) >= 0 {
+		return nil
+	}
+	tail := op.Proofs[len(op.Proofs)-1]
+	if err := tail.VerifyAbsence(append(copyKey(last), 0)); err != nil {
+		return store.ErrInvalidProof.New("range end not covered")
+	}
+	return nil
+}
+
+// rangeProof returns all models of the range, along with proof segments
+// of the consecutive leaves from the key before the range (unless it
+// starts at a key) to the key after it (unless it ends with the tree).
+//
+// iavl continues a range proof after a leaf at the leaf incremented by
+// one (see incrKey), skipping all longer keys with the same prefix.
+// Such a leaf ends its segment.
+func rangeProof(tree *iavl.ImmutableTree, start, end []byte) ([]store.Model, []*iavl.RangeProof, error) {
+	var models []store.Model
+	tree.IterateRange(start, end, true, func(key, value []byte) bool {
+		models = append(models, store.Pair(key, value))
+		return false
+	})
+
+	var keys [][]byte
+	if start != nil && (len(models) == 0 || !bytes.Equal(models[0].Key, start)) {
+		tree.IterateRange(nil, start, false, func(key, value []byte) bool {
+			keys = append(keys, key)
+			return true
+		})
+	}
+	for _, m := range models {
+		keys = append(keys, m.Key)
+	}
+	if end != nil {
+		tree.IterateRange(end, nil, true, func(key, value []byte) bool {
+			keys = append(keys, key)
+			return true
+		})
+	}
+
+	var proofs []*iavl.RangeProof
+	for i := 0; i < len(keys); {
+		n := 1
+		for j := i + n; j < len(keys) && !skipsNext(keys[j-1], keys[j]); j++ {
+			n++
+		}
+		_, _, proof, err := tree.GetRangeWithProof(keys[i], nil, n)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "cannot build proof")
+		}
+		proofs = append(proofs, proof)
+		i += n
+	}
+	return models, proofs, nil
+}
+
+// skipsNext returns true if a range proof reaching the key would not
+// continue with the next one
+func skipsNext(key, next []byte) bool {
+	limit := incrKey(key)
+	return limit == nil || bytes.Compare(next, limit) < 0
+}
+
+// incrKey returns the key with the last byte incremented,
+// the same way iavl limits a range proof
+func incrKey(key []byte) []byte {
+	res := copyKey(key)
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i]++
+		if res[i] != 0 {
+			return res
+		}
+	}
+	// overflow, there is nothing bigger
+	return nil
+}
+
+func copyKey(key []byte) []byte {
+	res := make([]byte, len(key))
+	copy(res, key)
+	return res
+}
@@ -0,0 +1,124 @@
+package iavl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/iov-one/weave/store"
+)
+
+// flatten returns the models as RangeOp arguments
+func flatten(models []Model) [][]byte {
+	var args [][]byte
+	for _, m := range models {
+		args = append(args, m.Key, m.Value)
+	}
+	return args
+}
+
+func runRangeOp(t *testing.T, pop merkle.ProofOp, models []Model) ([]byte, error) {
+	t.Helper()
+	op, err := RangeOpDecoder(pop)
+	require.NoError(t, err)
+	res, err := op.Run(flatten(models))
+	if err != nil {
+		return nil, err
+	}
+	return res[0], nil
+}
+
+func TestRangeProof(t *testing.T) {
+	commit := MockCommitStore()
+	kv := commit.CacheWrap()
+	for _, k := range []string{"a", "alice", "alice2", "alice3", "b", "bob", "bobby", "carl"} {
+		kv.Set([]byte(k), []byte("value of "+k))
+	}
+	kv.Write()
+	id := commit.Commit()
+
+	cases := map[string]struct {
+		start, end string
+		keys       []string
+	}{
+		"prefix is a key":       {start: "alice", end: "alicf", keys: []string{"alice", "alice2", "alice3"}},
+		"keys extending others": {start: "a", end: "b", keys: []string{"a", "alice", "alice2", "alice3"}},
+		"end is a key":          {start: "alice2", end: "bob", keys: []string{"alice2", "alice3", "b"}},
+		"up to the tree end":    {start: "bob", keys: []string{"bob", "bobby", "carl"}},
+		"whole tree":            {keys: []string{"a", "alice", "alice2", "alice3", "b", "bob", "bobby", "carl"}},
+		"empty range":           {start: "alice4", end: "alice9"},
+		"empty range at end":    {start: "d"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var start, end []byte
+			if tc.start != "" {
+				start = []byte(tc.start)
+			}
+			if tc.end != "" {
+				end = []byte(tc.end)
+			}
+			models, pops, err := commit.GetVersionedRangeWithProof(start, end, id.Version)
+			require.NoError(t, err)
+			var keys []string
+			for _, m := range models {
+				keys = append(keys, string(m.Key))
+			}
+			require.Equal(t, tc.keys, keys)
+			require.Len(t, pops, 1)
+
+			root, err := runRangeOp(t, pops[0], models)
+			require.NoError(t, err)
+			assert.Equal(t, id.Hash, root)
+
+			if len(models) > 0 {
+				_, err = runRangeOp(t, pops[0], models[:len(models)-1])
+				assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+				_, err = runRangeOp(t, pops[0], nil)
+				assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+			}
+		})
+	}
+}
+
+func TestTruncatedRangeProof(t *testing.T) {
+	commit := MockCommitStore()
+	kv := commit.CacheWrap()
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		kv.Set([]byte(k), []byte("value of "+k))
+	}
+	kv.Write()
+	id := commit.Commit()
+	tree, err := commit.tree.GetImmutable(id.Version)
+	require.NoError(t, err)
+
+	models, proofs, err := rangeProof(tree, []byte("b"), []byte("e"))
+	require.NoError(t, err)
+	require.Len(t, models, 3)
+	_, err = NewRangeOp([]byte("b"), []byte("e"), proofs).Run(flatten(models))
+	require.NoError(t, err)
+
+	// proofs of a valid prefix of the range
+	_, _, short, err := tree.GetRangeWithProof([]byte("b"), nil, 2)
+	require.NoError(t, err)
+	_, err = NewRangeOp([]byte("b"), []byte("e"), []*iavl.RangeProof{short}).Run(flatten(models[:2]))
+	assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+
+	// the key before the range is missing
+	_, _, late, err := tree.GetRangeWithProof([]byte("c"), nil, 3)
+	require.NoError(t, err)
+	_, err = NewRangeOp([]byte("bb"), []byte("e"), []*iavl.RangeProof{late}).Run(flatten(models[1:]))
+	assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+
+	// segments with a gap
+	_, _, first, err := tree.GetRangeWithProof([]byte("b"), nil, 1)
+	require.NoError(t, err)
+	_, _, third, err := tree.GetRangeWithProof([]byte("d"), nil, 2)
+	require.NoError(t, err)
+	gap := []*iavl.RangeProof{first, third}
+	_, err = NewRangeOp([]byte("b"), []byte("e"), gap).Run(flatten([]Model{models[0], models[2]}))
+	assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+}
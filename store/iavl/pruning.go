@@ -0,0 +1,56 @@
+package iavl
+
+import (
+	"github.com/iov-one/weave/errors"
+)
+
+// Pruning decides which versions of the history a CommitStore
+// deletes on every commit
+type Pruning struct {
+	// KeepRecent is the number of latest versions kept,
+	// zero keeps all versions
+	KeepRecent int64
+	// KeepEvery keeps every version that is a multiple of it,
+	// even when it is older than the recent ones
+	KeepEvery int64
+}
+
+// PruneNothing keeps all versions, as needed by an archive node
+func PruneNothing() Pruning {
+	return Pruning{}
+}
+
+// KeepRecent only keeps the last n versions
+func KeepRecent(n int64) Pruning {
+	return Pruning{KeepRecent: n}
+}
+
+// KeepSnapshots keeps the last n versions, and every version
+// that is a multiple of every
+func KeepSnapshots(n, every int64) Pruning {
+	return Pruning{KeepRecent: n, KeepEvery: every}
+}
+
+// Validate ensures the strategy can be used
+func (p Pruning) Validate() error {
+	if p.KeepRecent < 0 {
+		return errors.ErrInvalidInput.Newf("keep recent: %d", p.KeepRecent)
+	}
+	if p.KeepEvery < 0 {
+		return errors.ErrInvalidInput.Newf("keep every: %d", p.KeepEvery)
+	}
+	return nil
+}
+
+// expired returns the version that is no longer kept once the
+// given version is committed, if any
+func (p Pruning) expired(version int64) (int64, bool) {
+	if p.KeepRecent == 0 || version <= p.KeepRecent {
+		return 0, false
+	}
+	old := version - p.KeepRecent
+	if p.KeepEvery > 0 && old%p.KeepEvery == 0 {
+		return 0, false
+	}
+	return old, true
+}
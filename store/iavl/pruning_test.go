@@ -0,0 +1,108 @@
+package iavl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+)
+
+// commitVersions commits n versions, each setting the key to the version
+func commitVersions(t *testing.T, commit CommitStore, n int) []store.CommitID {
+	t.Helper()
+	ids := make([]store.CommitID, n)
+	for i := range ids {
+		commit.Adapter().Set([]byte("version"), []byte{byte(i + 1)})
+		ids[i] = commit.Commit()
+	}
+	return ids
+}
+
+func TestPruning(t *testing.T) {
+	cases := map[string]struct {
+		pruning Pruning
+		kept    []int64
+	}{
+		"prune nothing": {
+			pruning: PruneNothing(),
+			kept:    []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		"keep recent": {
+			pruning: KeepRecent(3),
+			kept:    []int64{8, 9, 10},
+		},
+		"keep snapshots": {
+			pruning: KeepSnapshots(2, 4),
+			kept:    []int64{4, 8, 9, 10},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			commit, close := makeCommitStore()
+			defer close()
+			commit.pruning = tc.pruning
+
+			commitVersions(t, commit, 10)
+			var kept []int64
+			for v := int64(1); v <= 10; v++ {
+				if commit.VersionExists(v) {
+					kept = append(kept, v)
+				}
+			}
+			assert.Equal(t, tc.kept, kept)
+		})
+	}
+}
+
+func TestLoadVersion(t *testing.T) {
+	commit, close := makeCommitStore()
+	defer close()
+	ids := commitVersions(t, commit, 4)
+
+	require.NoError(t, commit.LoadVersion(2))
+	assert.Equal(t, ids[1], commit.LatestVersion())
+	assert.Equal(t, []byte{2}, commit.Get([]byte("version")))
+
+	// committing the same data again is idempotent
+	commit.Adapter().Set([]byte("version"), []byte{3})
+	assert.Equal(t, ids[2], commit.Commit())
+
+	// while different data cannot replace a persisted version
+	commit.Adapter().Set([]byte("version"), []byte{7})
+	assert.Panics(t, func() { commit.Commit() })
+
+	err := commit.LoadVersion(5)
+	assert.True(t, errors.ErrInvalidInput.Is(err), "%+v", err)
+}
+
+func TestRollback(t *testing.T) {
+	commit, close := makeCommitStore()
+	defer close()
+	commit.pruning = KeepSnapshots(2, 3)
+	ids := commitVersions(t, commit, 8)
+	// fill the value cache, it must not survive the rollback
+	assert.Equal(t, []byte{8}, commit.CacheWrap().Get([]byte("version")))
+	assert.Equal(t, []byte{8}, commit.CacheWrap().Get([]byte("version")))
+	assert.Equal(t, uint64(1), commit.CacheStats().Hits)
+
+	// versions 4 and 5 are pruned
+	err := commit.Rollback(3)
+	assert.True(t, store.ErrPruned.Is(err), "%+v", err)
+	err = commit.Rollback(4)
+	assert.True(t, store.ErrPruned.Is(err), "%+v", err)
+
+	require.NoError(t, commit.Rollback(6))
+	assert.Equal(t, ids[5], commit.LatestVersion())
+	assert.False(t, commit.VersionExists(7))
+	assert.Equal(t, []byte{6}, commit.CacheWrap().Get([]byte("version")))
+
+	// the next version can be replaced
+	commit.Adapter().Set([]byte("version"), []byte{42})
+	id := commit.Commit()
+	assert.Equal(t, int64(7), id.Version)
+	assert.NotEqual(t, ids[6].Hash, id.Hash)
+	assert.Equal(t, []byte{42}, commit.Get([]byte("version")))
+}
@@ -0,0 +1,226 @@
+/*
+Package proofs verifies the merkle proofs returned by
+app.StoreApp.Query when the Prove flag is set.
+
+A proof contains one independent operation for every key and range
+read while answering the query. Every operation must compute the
+app hash of the queried height (found in the header of the next block).
+If the app uses a multi store, every operation computes the root of a
+substore instead, and is followed by an operation proving this root
+in the app hash. Those proofs are only accepted along with the mounts
+of the app, so the verifier knows which substore holds a key.
+Every returned model must be proven either by a value operation or
+by a range operation. A range operation that contains any of the
+returned models must contain all of them, so no value can be left
+out of a prefix query. A range operation without any returned model
+proves that the range is empty. Queries returning other values than
+the ones they read, like index lookups, cannot be verified.
+*/
+package proofs
+
+import (
+	"bytes"
+	"sort"
+
+	tmiavl "github.com/tendermint/iavl"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/app"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/store/iavl"
+)
+
+// VerifyResponse decodes the result sets of a query response and
+// verifies them against the attached proof and the given app hash.
+// Mounts must be given if the app uses an iavl.MultiStore.
+func VerifyResponse(res abci.ResponseQuery, appHash []byte, mounts ...iavl.Mount) error {
+	var keys, values app.ResultSet
+	if err := keys.Unmarshal(res.Key); err != nil {
+		return errors.Wrap(err, "keys")
+	}
+	if err := values.Unmarshal(res.Value); err != nil {
+		return errors.Wrap(err, "values")
+	}
+	models, err := app.JoinResults(&keys, &values)
+	if err != nil {
+		return err
+	}
+	return Verify(res.Proof, appHash, models, mounts...)
+}
+
+// Verify checks that all operations of the proof compute the app hash
+// and that all models are proven by them.
+// Mounts must be given if the app uses an iavl.MultiStore.
+func Verify(proof *merkle.Proof, appHash []byte, models []weave.Model, mounts ...iavl.Mount) error {
+	links, err := decode(proof, appHash, mounts)
+	if err != nil {
+		return err
+	}
+
+	proven := make([]bool, len(models))
+	for _, l := range links {
+		switch op := l.op.(type) {
+		case tmiavl.IAVLValueOp:
+			if err := op.Proof.Verify(l.root); err != nil {
+				return errors.Wrap(store.ErrInvalidProof, err.Error())
+			}
+			for i, m := range models {
+				if !bytes.Equal(m.Key, op.GetKey()) {
+					continue
+				}
+				if err := op.Proof.VerifyItem(m.Key, m.Value); err != nil {
+					return errors.Wrap(store.ErrInvalidProof, err.Error())
+				}
+				proven[i] = true
+			}
+		case tmiavl.IAVLAbsenceOp:
+			if err := runOp(op, nil, l.root); err != nil {
+				return err
+			}
+			for _, m := range models {
+				if bytes.Equal(m.Key, op.GetKey()) {
+					return store.ErrInvalidProof.Newf("key %X proven absent", m.Key)
+				}
+			}
+		case iavl.RangeOp:
+			var inRange []int
+			for i, m := range models {
+				if op.Contains(m.Key) {
+					inRange = append(inRange, i)
+				}
+			}
+			sort.Slice(inRange, func(i, j int) bool {
+				return bytes.Compare(models[inRange[i]].Key, models[inRange[j]].Key) < 0
+			})
+			// nothing returned from this range (eg. an index)
+			// proves the whole range is empty
+			args := make([][]byte, 0, 2*len(inRange))
+			for _, i := range inRange {
+				args = append(args, models[i].Key, models[i].Value)
+				proven[i] = true
+			}
+			if err := runOp(op, args, l.root); err != nil {
+				return err
+			}
+		default:
+			return store.ErrInvalidProof.Newf("unsupported operation %T", op)
+		}
+	}
+
+	for i, ok := range proven {
+		if !ok {
+			return store.ErrInvalidProof.Newf("key %X not proven", models[i].Key)
+		}
+	}
+	return nil
+}
+
+// VerifyAbsence checks that the proof holds a valid proof
+// that the key does not exist.
+// Mounts must be given if the app uses an iavl.MultiStore.
+func VerifyAbsence(proof *merkle.Proof, appHash []byte, key []byte, mounts ...iavl.Mount) error {
+	links, err := decode(proof, appHash, mounts)
+	if err != nil {
+		return err
+	}
+	for _, l := range links {
+		if op, ok := l.op.(tmiavl.IAVLAbsenceOp); ok && bytes.Equal(op.GetKey(), key) {
+			return runOp(op, nil, l.root)
+		}
+	}
+	return store.ErrInvalidProof.Newf("no absence proof for key %X", key)
+}
+
+// link is an operation along with the root hash it must compute
+type link struct {
+	op   merkle.ProofOperator
+	root []byte
+}
+
+// decode returns all operations of the proof along with the root they
+// must compute. An operation followed by a simple value operation
+// computes the root of a substore, and the second one must prove
+// this root in the app hash. The substore must be the one the mounts
+// route the key or range of the operation to.
+func decode(proof *merkle.Proof, appHash []byte, mounts []iavl.Mount) ([]link, error) {
+	if proof == nil || len(proof.Ops) == 0 {
+		return nil, store.ErrInvalidProof.New("missing proof")
+	}
+	ops, err := iavl.NewProofRuntime().DecodeProof(proof)
+	if err != nil {
+		return nil, errors.Wrap(store.ErrInvalidProof, err.Error())
+	}
+
+	links := make([]link, 0, len(ops))
+	for i := 0; i < len(ops); i++ {
+		if _, ok := ops[i].(merkle.SimpleValueOp); ok {
+			return nil, store.ErrInvalidProof.New("substore root without a substore proof")
+		}
+		l := link{op: ops[i], root: appHash}
+		if i+1 < len(ops) {
+			if sub, ok := ops[i+1].(merkle.SimpleValueOp); ok {
+				if len(mounts) == 0 {
+					return nil, store.ErrInvalidProof.New("substore proof without mounts")
+				}
+				if err := checkRoute(l.op, string(sub.GetKey()), iavl.NewRouter(mounts...)); err != nil {
+					return nil, err
+				}
+				l.root = computeRoot(l.op)
+				if err := runOp(sub, [][]byte{l.root}, appHash); err != nil {
+					return nil, err
+				}
+				i++
+			}
+		}
+		links = append(links, l)
+	}
+	return links, nil
+}
+
+// checkRoute ensures the operation proves keys of the named substore,
+// so a key cannot be proven absent from a substore not holding it
+func checkRoute(op merkle.ProofOperator, name string, router iavl.Router) error {
+	var want string
+	switch op := op.(type) {
+	case iavl.RangeOp:
+		var err error
+		if want, err = router.RouteRange(op.Start, op.End); err != nil {
+			return errors.Wrap(store.ErrInvalidProof, err.Error())
+		}
+	default:
+		want = router.Route(op.GetKey())
+	}
+	if name != want {
+		return store.ErrInvalidProof.Newf("key %X proven in substore %s, not %s", op.GetKey(), name, want)
+	}
+	return nil
+}
+
+// computeRoot returns the root hash claimed by the operation,
+// it must be verified separately
+func computeRoot(op merkle.ProofOperator) []byte {
+	switch op := op.(type) {
+	case tmiavl.IAVLValueOp:
+		return op.Proof.ComputeRootHash()
+	case tmiavl.IAVLAbsenceOp:
+		return op.Proof.ComputeRootHash()
+	case iavl.RangeOp:
+		return op.ComputeRootHash()
+	}
+	return nil
+}
+
+// runOp executes the operation and ensures it computes the app hash
+func runOp(op merkle.ProofOperator, args [][]byte, appHash []byte) error {
+	res, err := op.Run(args)
+	if err != nil {
+		return errors.Wrap(store.ErrInvalidProof, err.Error())
+	}
+	if len(res) != 1 || !bytes.Equal(res[0], appHash) {
+		return store.ErrInvalidProof.New("app hash does not match")
+	}
+	return nil
+}
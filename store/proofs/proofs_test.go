@@ -0,0 +1,180 @@
+package proofs
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/app"
+	"github.com/iov-one/weave/orm"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/store/iavl"
+)
+
+func TestVerifyQueries(t *testing.T) {
+	qr := weave.NewQueryRouter()
+	orm.RegisterQuery(qr)
+	myApp := app.NewStoreApp("proofs", iavl.MockCommitStore(), qr, context.Background())
+
+	db := myApp.DeliverStore()
+	db.Set([]byte("abc"), []byte("first"))
+	db.Set([]byte("abd"), []byte("second"))
+	db.Set([]byte("bcd"), []byte("third"))
+	appHash := myApp.Commit().Data
+
+	// existing key
+	res := myApp.Query(abci.RequestQuery{Path: "/", Data: []byte("abd"), Prove: true})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	require.NoError(t, VerifyResponse(res, appHash))
+
+	// missing key
+	res = myApp.Query(abci.RequestQuery{Path: "/", Data: []byte("abe"), Prove: true})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	require.NoError(t, VerifyResponse(res, appHash))
+	require.NoError(t, VerifyAbsence(res.Proof, appHash, []byte("abe")))
+	assert.Error(t, VerifyAbsence(res.Proof, appHash, []byte("abd")))
+
+	// prefix query
+	res = myApp.Query(abci.RequestQuery{Path: "/?prefix", Data: []byte("ab"), Prove: true})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	require.NoError(t, VerifyResponse(res, appHash))
+
+	// empty prefix query
+	res = myApp.Query(abci.RequestQuery{Path: "/?prefix", Data: []byte("abz"), Prove: true})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	require.NoError(t, VerifyResponse(res, appHash))
+
+	// a page of a prefix query
+	res = myApp.Query(abci.RequestQuery{Path: "/?prefix&limit=1&offset=1", Data: nil, Prove: true})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	require.NotEmpty(t, res.Info)
+	require.NoError(t, VerifyResponse(res, appHash))
+
+	// other app hash must fail
+	err := VerifyResponse(res, []byte("some other hash"))
+	assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+
+	// no proof requested
+	res = myApp.Query(abci.RequestQuery{Path: "/", Data: []byte("abd")})
+	assert.Error(t, VerifyResponse(res, appHash))
+}
+
+func TestVerifyModifiedResults(t *testing.T) {
+	qr := weave.NewQueryRouter()
+	orm.RegisterQuery(qr)
+	myApp := app.NewStoreApp("proofs", iavl.MockCommitStore(), qr, context.Background())
+
+	db := myApp.DeliverStore()
+	db.Set([]byte("abc"), []byte("first"))
+	db.Set([]byte("abd"), []byte("second"))
+	db.Set([]byte("abe"), []byte("third"))
+	appHash := myApp.Commit().Data
+
+	res := myApp.Query(abci.RequestQuery{Path: "/?prefix", Data: []byte("ab"), Prove: true})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+	valid := []weave.Model{
+		weave.Pair([]byte("abc"), []byte("first")),
+		weave.Pair([]byte("abd"), []byte("second")),
+		weave.Pair([]byte("abe"), []byte("third")),
+	}
+	require.NoError(t, Verify(res.Proof, appHash, valid))
+
+	cases := map[string][]weave.Model{
+		"missing model": valid[1:],
+		"all models omitted": nil,
+		"modified value": {
+			valid[0],
+			weave.Pair([]byte("abd"), []byte("changed")),
+			valid[2],
+		},
+		"additional model": append(valid, weave.Pair([]byte("abf"), []byte("fourth"))),
+		"model outside of range": {
+			weave.Pair([]byte("bcd"), []byte("other")),
+		},
+	}
+	for name, models := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Verify(res.Proof, appHash, models)
+			assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+		})
+	}
+}
+
+func TestVerifyMultiStoreQueries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "proofs-multi-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	opts := iavl.DefaultOptions()
+	opts.Backend = iavl.MemDBBackend
+	mount := iavl.Mount{Name: "ab", Prefixes: []string{"ab"}}
+	opts.Mounts = []iavl.Mount{mount}
+	kv, err := iavl.NewMultiStore(dir, "multi", opts)
+	require.NoError(t, err)
+
+	qr := weave.NewQueryRouter()
+	orm.RegisterQuery(qr)
+	myApp := app.NewStoreApp("proofs", kv, qr, context.Background())
+
+	db := myApp.DeliverStore()
+	db.Set([]byte("abc"), []byte("first"))
+	db.Set([]byte("abd"), []byte("second"))
+	db.Set([]byte("bcd"), []byte("third"))
+	appHash := myApp.Commit().Data
+
+	queries := map[string]abci.RequestQuery{
+		"substore key":      {Path: "/", Data: []byte("abd"), Prove: true},
+		"main store key":    {Path: "/", Data: []byte("bcd"), Prove: true},
+		"missing key":       {Path: "/", Data: []byte("abe"), Prove: true},
+		"substore prefix":   {Path: "/?prefix", Data: []byte("ab"), Prove: true},
+		"main store prefix": {Path: "/?prefix", Data: []byte("b"), Prove: true},
+	}
+	for name, q := range queries {
+		t.Run(name, func(t *testing.T) {
+			res := myApp.Query(q)
+			require.Equal(t, uint32(0), res.Code, res.Log)
+			require.NoError(t, VerifyResponse(res, appHash, mount))
+			err := VerifyResponse(res, []byte("some other hash"), mount)
+			assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+			// the substore of the key is unknown
+			err = VerifyResponse(res, appHash)
+			assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+		})
+	}
+
+	res := myApp.Query(abci.RequestQuery{Path: "/", Data: []byte("abe"), Prove: true})
+	require.NoError(t, VerifyAbsence(res.Proof, appHash, []byte("abe"), mount))
+
+	// a proof of the main store root cannot be used alone
+	res = myApp.Query(abci.RequestQuery{Path: "/", Data: []byte("bcd"), Prove: true})
+	res.Proof.Ops = res.Proof.Ops[:1]
+	err = VerifyResponse(res, appHash, mount)
+	assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+
+	// a store with the content of the main substore proves that
+	// keys of the mounted substore are missing in the main one
+	mainStore := iavl.MockCommitStore()
+	mainKV := mainStore.CacheWrap()
+	mainKV.Set([]byte("bcd"), []byte("third"))
+	mainKV.Write()
+	mainStore.Commit()
+	_, absence, err := mainStore.GetVersionedWithProof([]byte("abd"), 1)
+	require.NoError(t, err)
+	_, emptyRange, err := mainStore.GetVersionedRangeWithProof([]byte("ab"), []byte("ac"), 1)
+	require.NoError(t, err)
+	res = myApp.Query(abci.RequestQuery{Path: "/", Data: []byte("bcd"), Prove: true})
+	mainRoot := res.Proof.Ops[1]
+
+	forged := &merkle.Proof{Ops: append(absence, mainRoot)}
+	err = VerifyAbsence(forged, appHash, []byte("abd"), mount)
+	assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+	forged = &merkle.Proof{Ops: append(emptyRange, mainRoot)}
+	err = Verify(forged, appHash, nil, mount)
+	assert.True(t, store.ErrInvalidProof.Is(err), "%+v", err)
+}
@@ -0,0 +1,174 @@
+package store
+
+import (
+	"container/list"
+	"sync"
+)
+
+// ValueCache is a size bounded LRU cache of the values read from a
+// committed store, including the absence of a key. It sits between the
+// store and the cache wraps, so values read in one block are served
+// from memory in the next blocks.
+//
+// All writes must go through a store returned by Wrap, so their keys
+// are invalidated. Loading another version requires a Purge.
+//
+// A nil ValueCache is valid and caches nothing.
+type ValueCache struct {
+	mtx     sync.Mutex
+	size    int
+	entries *list.List
+	keys    map[string]*list.Element
+	// generation changes with every write, so a value read before
+	// the write is not cached after it
+	generation uint64
+	stats      CacheStats
+}
+
+// CacheStats counts the lookups of a ValueCache
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Size is the number of cached keys
+	Size int
+}
+
+type cacheEntry struct {
+	key   string
+	value []byte
+}
+
+// NewValueCache creates a cache holding up to size keys.
+// It returns nil if size is zero.
+func NewValueCache(size int) *ValueCache {
+	if size <= 0 {
+		return nil
+	}
+	return &ValueCache{
+		size:    size,
+		entries: list.New(),
+		keys:    make(map[string]*list.Element, size),
+	}
+}
+
+// Wrap returns a store reading through the cache and
+// invalidating all keys written to it
+func (c *ValueCache) Wrap(kv KVStore) KVStore {
+	if c == nil {
+		return kv
+	}
+	return cachedStore{KVStore: kv, cache: c}
+}
+
+// Stats returns the counters since the cache was created
+func (c *ValueCache) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	stats := c.stats
+	stats.Size = c.entries.Len()
+	return stats
+}
+
+// Purge removes all cached values
+func (c *ValueCache) Purge() {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.entries.Init()
+	c.keys = make(map[string]*list.Element, c.size)
+	c.generation++
+}
+
+// get returns the cached value and the generation of the cache,
+// to pass to add after a miss
+func (c *ValueCache) get(key []byte) (value []byte, ok bool, generation uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if el, ok := c.keys[string(key)]; ok {
+		c.stats.Hits++
+		c.entries.MoveToFront(el)
+		return el.Value.(*cacheEntry).value, true, c.generation
+	}
+	c.stats.Misses++
+	return nil, false, c.generation
+}
+
+// add caches a value read from the store, unless there was a write
+// since the given generation
+func (c *ValueCache) add(key, value []byte, generation uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if el, ok := c.keys[string(key)]; ok {
+		c.entries.MoveToFront(el)
+		return
+	}
+	entry := &cacheEntry{key: string(key), value: value}
+	c.keys[entry.key] = c.entries.PushFront(entry)
+	if c.entries.Len() > c.size {
+		last := c.entries.Back()
+		c.entries.Remove(last)
+		delete(c.keys, last.Value.(*cacheEntry).key)
+		c.stats.Evictions++
+	}
+}
+
+// invalidate removes the key after a write
+func (c *ValueCache) invalidate(key []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.generation++
+	if el, ok := c.keys[string(key)]; ok {
+		c.entries.Remove(el)
+		delete(c.keys, string(key))
+	}
+}
+
+// cachedStore reads through a ValueCache
+type cachedStore struct {
+	KVStore
+	cache *ValueCache
+}
+
+var _ KVStore = cachedStore{}
+
+// Get returns nil iff key doesn't exist. Panics on nil key.
+func (s cachedStore) Get(key []byte) []byte {
+	value, ok, generation := s.cache.get(key)
+	if ok {
+		return value
+	}
+	value = s.KVStore.Get(key)
+	s.cache.add(key, value, generation)
+	return value
+}
+
+// Has checks if a key exists. Panics on nil key.
+func (s cachedStore) Has(key []byte) bool {
+	return s.Get(key) != nil
+}
+
+// Set writes the value and invalidates the key
+func (s cachedStore) Set(key, value []byte) {
+	s.KVStore.Set(key, value)
+	s.cache.invalidate(key)
+}
+
+// Delete removes the value and invalidates the key
+func (s cachedStore) Delete(key []byte) {
+	s.KVStore.Delete(key)
+	s.cache.invalidate(key)
+}
+
+// NewBatch returns a batch writing through this store
+func (s cachedStore) NewBatch() Batch {
+	return NewNonAtomicBatch(s)
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueCache(t *testing.T) {
+	base := MemStore()
+	base.Set([]byte("a"), []byte("1"))
+	base.Set([]byte("b"), []byte("2"))
+
+	cache := NewValueCache(2)
+	kv := cache.Wrap(base)
+
+	assert.Equal(t, []byte("1"), kv.Get([]byte("a")))
+	assert.Equal(t, []byte("1"), kv.Get([]byte("a")))
+	// missing keys are cached as well
+	assert.False(t, kv.Has([]byte("c")))
+	assert.False(t, kv.Has([]byte("c")))
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 2, Size: 2}, cache.Stats())
+
+	// the least recently used key is evicted
+	assert.Equal(t, []byte("2"), kv.Get([]byte("b")))
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 3, Evictions: 1, Size: 2}, cache.Stats())
+	assert.Equal(t, []byte("1"), kv.Get([]byte("a")))
+	assert.Equal(t, uint64(4), cache.Stats().Misses)
+
+	// writes, also in a batch, invalidate the key
+	kv.Set([]byte("a"), []byte("3"))
+	assert.Equal(t, []byte("3"), kv.Get([]byte("a")))
+	batch := kv.NewBatch()
+	batch.Delete([]byte("a"))
+	batch.Write()
+	assert.Nil(t, kv.Get([]byte("a")))
+
+	// writes below the cache are only visible after a purge
+	base.Set([]byte("b"), []byte("4"))
+	assert.Equal(t, []byte("2"), kv.Get([]byte("b")))
+	cache.Purge()
+	assert.Equal(t, []byte("4"), kv.Get([]byte("b")))
+	assert.Equal(t, 1, cache.Stats().Size)
+
+	// a nil cache reads from the store
+	var none *ValueCache
+	assert.Equal(t, base, none.Wrap(base))
+	assert.Equal(t, CacheStats{}, none.Stats())
+	none.Purge()
+}
+
+func TestValueCacheConcurrentWrite(t *testing.T) {
+	base := MemStore()
+	cache := NewValueCache(10)
+	kv := cache.Wrap(base)
+
+	// a value read before a write is not cached
+	_, _, generation := cache.get([]byte("a"))
+	stale := base.Get([]byte("a"))
+	kv.Set([]byte("a"), []byte("1"))
+	cache.add([]byte("a"), stale, generation)
+	assert.Equal(t, []byte("1"), kv.Get([]byte("a")))
+}
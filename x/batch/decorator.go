@@ -75,17 +75,23 @@ func (d Decorator) Check(ctx weave.Context, store weave.KVStore, tx weave.Tx,
 
 // combines all data bytes as protobuf.
 // joins all log messages with \n
+// The priority is the highest of all messages, the fee decorators
+// add the priority of the fee paid by the whole transaction.
 func (*Decorator) combineChecks(checks []weave.CheckResult) (weave.CheckResult, error) {
 	datas := make([][]byte, len(checks))
 	logs := make([]string, len(checks))
-	var allocated, payments int64
+	var allocated, used, payments, priority int64
 	var required coin.Coin
 	var err error
 	for i, r := range checks {
 		datas[i] = r.Data
 		logs[i] = r.Log
 		allocated += r.GasAllocated
+		used += r.GasUsed
 		payments += r.GasPayment
+		if r.Priority > priority {
+			priority = r.Priority
+		}
 		if required.IsZero() {
 			required = r.RequiredFee
 		} else if !r.RequiredFee.IsZero() {
@@ -102,7 +108,9 @@ func (*Decorator) combineChecks(checks []weave.CheckResult) (weave.CheckResult,
 		Data:         data,
 		Log:          strings.Join(logs, "\n"),
 		GasAllocated: allocated,
+		GasUsed:      used,
 		GasPayment:   payments,
+		Priority:     priority,
 		RequiredFee:  required,
 	}, nil
 }
@@ -144,18 +152,22 @@ func (d Decorator) Deliver(ctx weave.Context, store weave.KVStore, tx weave.Tx,
 func (*Decorator) combineDelivers(delivers []weave.DeliverResult) (weave.DeliverResult, error) {
 	datas := make([][]byte, len(delivers))
 	logs := make([]string, len(delivers))
-	var payments int64
+	var allocated, payments int64
 	var diffs []types.ValidatorUpdate
+	var params *types.ConsensusParams
 	var tags []common.KVPair
 	var required coin.Coin
 	var err error
 	for i, r := range delivers {
 		datas[i] = r.Data
 		logs[i] = r.Log
+		allocated += r.GasAllocated
 		payments += r.GasUsed
 		if len(r.Diff) > 0 {
 			diffs = append(diffs, r.Diff...)
 		}
+		// later messages override the params of earlier ones
+		params = weave.MergeConsensusParams(params, r.ConsensusParams)
 		if len(r.Tags) > 0 {
 			tags = append(tags, r.Tags...)
 		}
@@ -173,10 +185,12 @@ func (*Decorator) combineDelivers(delivers []weave.DeliverResult) (weave.Deliver
 	log := strings.Join(logs, "\n")
 
 	return weave.DeliverResult{
-		Data:    data,
-		Log:     log,
-		GasUsed: payments,
-		Diff:    diffs,
+		Data:            data,
+		Log:             log,
+		GasAllocated:    allocated,
+		GasUsed:         payments,
+		Diff:            diffs,
+		ConsensusParams: params,
 		// https://github.com/iov-one/weave/pull/188#discussion_r234531097
 		// but I couldn't find a place where, so need to figure it out
 		Tags:        tags,
@@ -109,6 +109,7 @@ func TestDecorator(t *testing.T) {
 				Log:          logVal,
 				GasAllocated: gas,
 				GasPayment:   gas,
+				Priority:     gas,
 				RequiredFee:  fee,
 			}, nil).Times(int(num))
 
@@ -120,6 +121,7 @@ func TestDecorator(t *testing.T) {
 				Log:          mockLog(num, logVal),
 				GasAllocated: gas * num,
 				GasPayment:   gas * num,
+				Priority:     gas,
 				RequiredFee:  combinedFee,
 			})
 
@@ -146,6 +148,21 @@ func TestDecorator(t *testing.T) {
 			msg.AssertExpectations(t)
 		})
 
+		Convey("Priority is the highest of all messages", func() {
+			msg.On("Validate").Return(nil).Times(1)
+			msg.On("MsgList").Return(make([]weave.Msg, 3), nil).Times(1)
+			helper.On("GetMsg").Return(msg, nil).Times(1)
+			for _, p := range []int64{3, 7, 5} {
+				helper.On("Check", nil, nil, mock.Anything).Return(weave.CheckResult{Priority: p}, nil).Once()
+			}
+
+			checkRes, err := decorator.Check(nil, nil, helper, helper)
+			So(err, ShouldBeNil)
+			So(checkRes.Priority, ShouldEqual, 7)
+			helper.AssertExpectations(t)
+			msg.AssertExpectations(t)
+		})
+
 		Convey("Combine required fees with none", func() {
 			// 4 elements, 1 and 3 with fees, 2 and 4 without
 			num := int64(4)
@@ -190,6 +207,33 @@ func TestDecorator(t *testing.T) {
 			})
 		})
 
+		Convey("Later consensus params override earlier ones", func() {
+			msg.On("Validate").Return(nil).Times(1)
+			msg.On("MsgList").Return(make([]weave.Msg, 3), nil).Times(1)
+			helper.On("GetMsg").Return(msg, nil).Times(1)
+
+			blockSize := &types.BlockSizeParams{MaxBytes: 1000, MaxGas: 100}
+			evidence := &types.EvidenceParams{MaxAge: 10}
+			override := &types.BlockSizeParams{MaxBytes: 2000, MaxGas: 200}
+			results := []*types.ConsensusParams{
+				{BlockSize: blockSize, Evidence: evidence},
+				nil,
+				{BlockSize: override},
+			}
+			for _, p := range results {
+				helper.On("Deliver", nil, nil, mock.Anything).Return(weave.DeliverResult{ConsensusParams: p}, nil).Once()
+			}
+
+			deliverRes, err := decorator.Deliver(nil, nil, helper, helper)
+			So(err, ShouldBeNil)
+			So(deliverRes.ConsensusParams, ShouldResemble, &types.ConsensusParams{
+				BlockSize: override,
+				Evidence:  evidence,
+			})
+			helper.AssertExpectations(t)
+			msg.AssertExpectations(t)
+		})
+
 		Convey("Wrong tx type", func() {
 			helper.On("GetMsg").Return(wrongWeaveMsg{}, nil).Times(2)
 			helper.On("Deliver", nil, nil, mock.Anything).Return(weave.DeliverResult{}, nil).Times(1)
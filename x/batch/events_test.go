@@ -0,0 +1,58 @@
+package batch_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/x/batch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listMsg is a batch of the given messages
+type listMsg struct {
+	weavetest.Msg
+	msgs []weave.Msg
+}
+
+func (m *listMsg) MsgList() ([]weave.Msg, error) {
+	return m.msgs, nil
+}
+
+func (m *listMsg) Validate() error {
+	return batch.Validate(m)
+}
+
+// emitHandler emits an event with the path of every message
+type emitHandler struct {
+	weavetest.Handler
+}
+
+func (h *emitHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	msg, err := tx.GetMsg()
+	if err != nil {
+		return weave.DeliverResult{}, err
+	}
+	weave.EmitEvents(ctx, weave.NewEvent("test").With("path", msg.Path()))
+	return weave.DeliverResult{}, nil
+}
+
+func TestDecoratorEvents(t *testing.T) {
+	msg := &listMsg{msgs: []weave.Msg{
+		&weavetest.Msg{RoutePath: "a"},
+		&weavetest.Msg{RoutePath: "b"},
+	}}
+
+	events := weave.NewEventManager()
+	ctx := weave.WithEventManager(context.Background(), events)
+	_, err := batch.NewDecorator().Deliver(ctx, store.MemStore(), &weavetest.Tx{Msg: msg}, &emitHandler{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []weave.Event{
+		weave.NewEvent("test").With("path", "a"),
+		weave.NewEvent("test").With("path", "b"),
+	}, events.Events())
+}
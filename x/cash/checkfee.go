@@ -0,0 +1,76 @@
+package cash
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/iov-one/weave"
+	coin "github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+)
+
+// CheckMinFee is a node-local minimal fee, only enforced on CheckTx.
+// A node can use it to keep cheap transactions out of its mempool,
+// while the consensus minimal fee is configured via gconf.
+//
+// It can be changed at runtime with the "cash.check_min_fee" option.
+type CheckMinFee struct {
+	mtx sync.RWMutex
+	fee coin.Coin
+}
+
+// NewCheckMinFee returns a CheckMinFee not requiring any fee
+func NewCheckMinFee() *CheckMinFee {
+	return &CheckMinFee{}
+}
+
+// Get returns the current minimal fee
+func (m *CheckMinFee) Get() coin.Coin {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.fee
+}
+
+// Set accepts the minimal fee as a JSON encoded coin,
+// an empty value removes it
+func (m *CheckMinFee) Set(value string) error {
+	var fee coin.Coin
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), &fee); err != nil {
+			return errors.ErrInvalidInput.Newf("cannot decode coin: %s", err)
+		}
+		if err := fee.Validate(); err != nil {
+			return err
+		}
+		if !fee.IsZero() && fee.Ticker == "" {
+			return errors.Wrap(coin.ErrInvalidCurrency, "no ticker")
+		}
+	}
+	m.mtx.Lock()
+	m.fee = fee
+	m.mtx.Unlock()
+	return nil
+}
+
+// RegisterOptions adds the "cash.check_min_fee" option
+func (m *CheckMinFee) RegisterOptions(r weave.OptionRouter) {
+	r.Register("cash.check_min_fee", m.Set)
+}
+
+// Verify ensures the fee is at least the minimal fee
+func (m *CheckMinFee) Verify(fee *coin.Coin) error {
+	min := m.Get()
+	if min.IsZero() {
+		return nil
+	}
+	if coin.IsEmpty(fee) {
+		return errors.ErrInsufficientAmount.Newf("node requires a fee of at least %v", min)
+	}
+	if !fee.SameType(min) {
+		return coin.ErrInvalidCurrency.Newf("node min fee is %s and tx fee is %s", min.Ticker, fee.Ticker)
+	}
+	if !fee.IsGTE(min) {
+		return errors.ErrInsufficientAmount.Newf("node requires a fee of at least %v", min)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package cash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iov-one/weave"
+	coin "github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+)
+
+func TestCheckMinFee(t *testing.T) {
+	cases := map[string]struct {
+		value   string
+		setErr  *errors.Error
+		fee     *coin.Coin
+		wantErr *errors.Error
+	}{
+		"not set": {
+			value: "",
+			fee:   nil,
+		},
+		"enough fee": {
+			value: `{"whole": 1, "ticker": "IOV"}`,
+			fee:   coin.NewCoinp(1, 0, "IOV"),
+		},
+		"too low fee": {
+			value:   `{"whole": 1, "ticker": "IOV"}`,
+			fee:     coin.NewCoinp(0, 999, "IOV"),
+			wantErr: &errors.ErrInsufficientAmount,
+		},
+		"no fee": {
+			value:   `{"fractional": 10, "ticker": "IOV"}`,
+			fee:     nil,
+			wantErr: &errors.ErrInsufficientAmount,
+		},
+		"wrong currency": {
+			value:   `{"whole": 1, "ticker": "IOV"}`,
+			fee:     coin.NewCoinp(5, 0, "ETH"),
+			wantErr: &coin.ErrInvalidCurrency,
+		},
+		"invalid json": {
+			value:  `1 IOV`,
+			setErr: &errors.ErrInvalidInput,
+		},
+		"missing ticker": {
+			value:  `{"whole": 1}`,
+			setErr: &coin.ErrInvalidCurrency,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			m := NewCheckMinFee()
+			options := weave.NewOptionRouter()
+			m.RegisterOptions(options)
+
+			err := options.Set("cash.check_min_fee", tc.value)
+			if tc.setErr != nil {
+				assert.True(t, tc.setErr.Is(err), "got %v", err)
+				assert.True(t, m.Get().IsZero())
+				return
+			}
+			require.NoError(t, err)
+
+			err = m.Verify(tc.fee)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tc.wantErr.Is(err), "got %v", err)
+			}
+		})
+	}
+}
@@ -42,8 +42,10 @@ import (
 )
 
 type DynamicFeeDecorator struct {
-	auth x.Authenticator
-	ctrl CoinMover
+	auth     x.Authenticator
+	ctrl     CoinMover
+	priority *PriorityConfig
+	minFee   *CheckMinFee
 }
 
 var _ weave.Decorator = DynamicFeeDecorator{}
@@ -57,17 +59,40 @@ func NewDynamicFeeDecorator(auth x.Authenticator, ctrl Controller) DynamicFeeDec
 	}
 }
 
+// WithPriority returns a DynamicFeeDecorator setting the priority
+// of checked transactions, computed from the fee they paid
+func (d DynamicFeeDecorator) WithPriority(c PriorityConfig) DynamicFeeDecorator {
+	d.priority = &c
+	return d
+}
+
+// WithCheckMinFee returns a DynamicFeeDecorator rejecting transactions
+// paying less than the node-local minimal fee on CheckTx
+func (d DynamicFeeDecorator) WithCheckMinFee(m *CheckMinFee) DynamicFeeDecorator {
+	d.minFee = m
+	return d
+}
+
 // Check verifies and deducts fees before calling down the stack
 func (d DynamicFeeDecorator) Check(ctx weave.Context, store weave.KVStore, tx weave.Tx, next weave.Checker) (cres weave.CheckResult, cerr error) {
 	fee, payer, cache, err := d.prepare(ctx, store, tx)
 	if err != nil {
 		return weave.CheckResult{}, errors.Wrap(err, "cannot prepare")
 	}
+	if d.minFee != nil {
+		if err := d.minFee.Verify(&fee); err != nil {
+			cache.Discard()
+			return weave.CheckResult{}, err
+		}
+	}
 
 	defer func() {
 		if cerr == nil {
 			cache.Write()
 			cres.GasPayment += toPayment(fee)
+			if d.priority != nil {
+				cres.Priority += d.priority.Priority(ctx, tx, fee, cres)
+			}
 		} else {
 			cache.Discard()
 			_ = d.chargeMinimalFee(store, payer)
@@ -96,6 +121,12 @@ func (d DynamicFeeDecorator) Deliver(ctx weave.Context, store weave.KVStore, tx
 	}
 
 	defer func() {
+		// a panic, like running out of gas, must not write any changes
+		// of the handler, and there is no gas left to charge a fee
+		if r := recover(); r != nil {
+			cache.Discard()
+			panic(r)
+		}
 		if derr == nil {
 			cache.Write()
 		} else {
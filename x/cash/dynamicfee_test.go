@@ -1,6 +1,7 @@
 package cash
 
 import (
+	"context"
 	"testing"
 
 	"github.com/iov-one/weave"
@@ -221,6 +222,81 @@ func TestDynamicFeeDecorator(t *testing.T) {
 	}
 }
 
+func TestDynamicFeeDecoratorPriority(t *testing.T) {
+	payer := weave.NewCondition("sigs", "ed25519", []byte{1, 2, 3})
+	collector := weave.NewCondition("custom", "type", []byte{0xAB})
+
+	db := store.MemStore()
+	gconf.SetValue(db, GconfCollectorAddress, collector.Address())
+	gconf.SetValue(db, GconfMinimalFee, coin.NewCoin(0, 23, "IOV"))
+	wallet, err := WalletWith(payer.Address(), &coin.Coin{Whole: 1, Ticker: "IOV"})
+	if err != nil {
+		t.Fatalf("cannot create a wallet: %s", err)
+	}
+	ensureWallets(t, db, []orm.Object{wallet})
+
+	auth := &weavetest.Auth{Signer: payer}
+	fee := coin.NewCoin(0, 400, "IOV")
+	tx := &txMock{info: &FeeInfo{Fees: &fee}}
+	handler := &handlerMock{checkRes: weave.CheckResult{GasAllocated: 100}}
+	ctx := context.Background()
+
+	d := NewDynamicFeeDecorator(auth, NewController(NewBucket()))
+	res, err := d.Check(ctx, db.CacheWrap(), tx, handler)
+	if err != nil {
+		t.Fatalf("cannot check: %s", err)
+	}
+	if res.Priority != 0 {
+		t.Fatalf("priority set without configuration: %d", res.Priority)
+	}
+
+	d = d.WithPriority(PriorityConfig{Rates: map[string]int64{"IOV": 2}, PerGas: true})
+	res, err = d.Check(ctx, db.CacheWrap(), tx, handler)
+	if err != nil {
+		t.Fatalf("cannot check: %s", err)
+	}
+	if want := int64(400 * 2 / 100); res.Priority != want {
+		t.Fatalf("want priority %d, got %d", want, res.Priority)
+	}
+}
+
+func TestDynamicFeeDecoratorCheckMinFee(t *testing.T) {
+	payer := weave.NewCondition("sigs", "ed25519", []byte{1, 2, 3})
+	collector := weave.NewCondition("custom", "type", []byte{0xAB})
+
+	db := store.MemStore()
+	gconf.SetValue(db, GconfCollectorAddress, collector.Address())
+	gconf.SetValue(db, GconfMinimalFee, coin.Coin{})
+	wallet, err := WalletWith(payer.Address(), &coin.Coin{Whole: 1, Ticker: "IOV"})
+	if err != nil {
+		t.Fatalf("cannot create a wallet: %s", err)
+	}
+	ensureWallets(t, db, []orm.Object{wallet})
+
+	auth := &weavetest.Auth{Signer: payer}
+	fee := coin.NewCoin(0, 400, "IOV")
+	tx := &txMock{info: &FeeInfo{Fees: &fee}}
+	handler := &handlerMock{}
+	ctx := context.Background()
+
+	minFee := NewCheckMinFee()
+	d := NewDynamicFeeDecorator(auth, NewController(NewBucket())).WithCheckMinFee(minFee)
+	if _, err := d.Check(ctx, db.CacheWrap(), tx, handler); err != nil {
+		t.Fatalf("cannot check: %s", err)
+	}
+
+	if err := minFee.Set(`{"fractional": 500, "ticker": "IOV"}`); err != nil {
+		t.Fatalf("cannot set min fee: %s", err)
+	}
+	if _, err := d.Check(ctx, db.CacheWrap(), tx, handler); !errors.ErrInsufficientAmount.Is(err) {
+		t.Fatalf("want insufficient amount error, got %v", err)
+	}
+	// the node-local fee is not part of the consensus
+	if _, err := d.Deliver(ctx, db.CacheWrap(), tx, handler); err != nil {
+		t.Fatalf("cannot deliver: %s", err)
+	}
+}
+
 // ensureWallets persist state of given wallet objects in the database. If
 // a wallet already exist it is overwritten.
 func ensureWallets(t *testing.T, db weave.KVStore, wallets []orm.Object) {
@@ -236,6 +312,41 @@ func ensureWallets(t *testing.T, db weave.KVStore, wallets []orm.Object) {
 
 // assertCharged check that given account was charged according to the fee
 // configuration.
+func TestDynamicFeeDecoratorOutOfGas(t *testing.T) {
+	payer := weave.NewCondition("sigs", "ed25519", []byte{1, 2, 3})
+	collector := weave.NewCondition("custom", "type", []byte{0xAB})
+
+	db := store.MemStore()
+	gconf.SetValue(db, GconfCollectorAddress, collector.Address())
+	gconf.SetValue(db, GconfMinimalFee, coin.NewCoin(0, 23, "IOV"))
+	wallet, err := WalletWith(payer.Address(), &coin.Coin{Whole: 1, Ticker: "IOV"})
+	if err != nil {
+		t.Fatalf("cannot create a wallet: %s", err)
+	}
+	ensureWallets(t, db, []orm.Object{wallet})
+
+	auth := &weavetest.Auth{Signer: payer}
+	fee := coin.NewCoin(0, 400, "IOV")
+	tx := &txMock{info: &FeeInfo{Fees: &fee}}
+	d := NewDynamicFeeDecorator(auth, NewController(NewBucket()))
+
+	// the panic of the gas meter is passed on, to be handled by the
+	// GasLimiter, without writing anything the handler did
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("out of gas panic not passed on")
+			}
+		}()
+		_, _ = d.Deliver(context.Background(), db, tx, &outOfGasHandler{})
+	}()
+
+	if db.Has([]byte("written")) {
+		t.Fatal("handler write was not discarded")
+	}
+	assertCharged(t, db, NewController(NewBucket()), coin.Coin{})
+}
+
 func assertCharged(t *testing.T, db weave.KVStore, ctrl Controller, want coin.Coin) {
 	t.Helper()
 
@@ -299,3 +410,13 @@ func (m *handlerMock) Check(weave.Context, weave.KVStore, weave.Tx) (weave.Check
 func (m *handlerMock) Deliver(weave.Context, weave.KVStore, weave.Tx) (weave.DeliverResult, error) {
 	return m.deliverRes, m.deliverErr
 }
+
+// outOfGasHandler writes to the store and then runs out of gas
+type outOfGasHandler struct {
+	weavetest.Handler
+}
+
+func (*outOfGasHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	db.Set([]byte("written"), []byte("value"))
+	panic(errors.ErrOutOfGas.New("test"))
+}
@@ -0,0 +1,20 @@
+package cash
+
+import (
+	"fmt"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+)
+
+// EventTransfer is emitted whenever coins are sent between accounts
+const EventTransfer = "cash.transfer"
+
+// TransferEvent describes coins moved from one account to another,
+// the amount is formatted as "<whole>.<fractional> <ticker>"
+func TransferEvent(from, to weave.Address, amount coin.Coin) weave.Event {
+	return weave.NewEvent(EventTransfer).
+		With("from", from.String()).
+		With("to", to.String()).
+		With("amount", fmt.Sprintf("%d.%09d %s", amount.Whole, amount.Fractional, amount.Ticker))
+}
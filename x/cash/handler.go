@@ -98,5 +98,6 @@ func (h SendHandler) Deliver(ctx weave.Context, store weave.KVStore,
 		return res, err
 	}
 
+	weave.EmitEvents(ctx, TransferEvent(msg.Src, msg.Dest, *msg.Amount))
 	return res, nil
 }
@@ -1,6 +1,7 @@
 package cash
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -85,8 +86,19 @@ func TestSend(t *testing.T) {
 
 			_, err := h.Check(nil, kv, tx)
 			assert.True(t, tc.expectCheck(err), "%+v", err)
-			_, err = h.Deliver(nil, kv, tx)
+			events := weave.NewEventManager()
+			ctx := weave.WithEventManager(context.Background(), events)
+			_, err = h.Deliver(ctx, kv, tx)
 			assert.True(t, tc.expectDeliver(err), "%+v", err)
+
+			// only a successful transfer is reported
+			if err == nil {
+				msg := tc.msg.(*SendMsg)
+				want := TransferEvent(msg.Src, msg.Dest, *msg.Amount)
+				assert.Equal(t, []weave.Event{want}, events.Events())
+			} else {
+				assert.Empty(t, events.Events())
+			}
 		})
 	}
 }
@@ -43,3 +43,22 @@ func (Initializer) FromGenesis(opts weave.Options, kv weave.KVStore) error {
 	}
 	return nil
 }
+
+var _ weave.Exporter = Initializer{}
+
+// ToGenesis exports all wallets in the format read by FromGenesis
+func (Initializer) ToGenesis(kv weave.ReadOnlyKVStore) (weave.Options, error) {
+	objs, err := NewBucket().All(kv)
+	if err != nil {
+		return nil, err
+	}
+	accts := make([]GenesisAccount, 0, len(objs))
+	for _, obj := range objs {
+		accts = append(accts, GenesisAccount{
+			Address: obj.Key(),
+			Set:     Set{Coins: AsCoins(obj)},
+		})
+	}
+	opts := weave.Options{}
+	return opts, opts.WriteOptions(optKey, accts)
+}
@@ -72,6 +72,38 @@ func TestInitState(t *testing.T) {
 	}
 }
 
+func TestGenesisExport(t *testing.T) {
+	addr1 := weave.NewAddress([]byte("alice"))
+	addr2 := weave.NewAddress([]byte("bob"))
+	accts := []GenesisAccount{
+		{Address: addr1, Set: Set{Coins: mustCombineCoins(coin.NewCoin(100, 5, "ATM"))}},
+		{Address: addr2, Set: Set{Coins: mustCombineCoins(coin.NewCoin(50, 0, "ETH"), coin.NewCoin(1, 0, "FOO"))}},
+	}
+	bz, err := json.Marshal(accts)
+	require.NoError(t, err)
+
+	init := Initializer{}
+	kv := store.MemStore()
+	require.NoError(t, init.FromGenesis(weave.Options{"cash": bz}, kv))
+
+	opts, err := init.ToGenesis(kv)
+	require.NoError(t, err)
+
+	// the export can be loaded into a fresh store
+	kv2 := store.MemStore()
+	require.NoError(t, init.FromGenesis(opts, kv2))
+	for _, a := range accts {
+		acct, err := NewBucket().Get(kv2, a.Address)
+		require.NoError(t, err)
+		require.NotNil(t, acct)
+		assert.EqualValues(t, a.Set.Coins, AsCoins(acct))
+	}
+
+	again, err := init.ToGenesis(kv2)
+	require.NoError(t, err)
+	assert.Equal(t, opts, again)
+}
+
 // mustCombineCoins has one return value for tests...
 func mustCombineCoins(cs ...coin.Coin) coin.Coins {
 	s, err := coin.CombineCoins(cs...)
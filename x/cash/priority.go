@@ -0,0 +1,58 @@
+package cash
+
+import (
+	"math"
+
+	"github.com/iov-one/weave"
+	coin "github.com/iov-one/weave/coin"
+)
+
+// PriorityConfig defines how the fee decorators compute the priority of a
+// transaction in CheckTx, so that a mempool can prefer well paying ones.
+//
+// The priority is the paid fee, in fractional units multiplied by the rate
+// of its ticker, divided by the size of the transaction in bytes or by the
+// gas it consumed.
+type PriorityConfig struct {
+	// Rates normalise fees paid in different tickers. A fee in a ticker
+	// without a rate gives no priority. If nil, every ticker has rate 1.
+	Rates map[string]int64
+	// PerGas divides the fee by the gas consumed (see utils.GasLimiter)
+	// instead of the size of the transaction
+	PerGas bool
+}
+
+// Priority returns the priority of a transaction paying the given fee,
+// res is the result of checking it down the stack
+func (c PriorityConfig) Priority(ctx weave.Context, tx weave.Tx, fee coin.Coin, res weave.CheckResult) int64 {
+	rate := int64(1)
+	if c.Rates != nil {
+		rate = c.Rates[fee.Ticker]
+	}
+	paid := toPayment(fee)
+	if paid <= 0 || rate <= 0 {
+		return 0
+	}
+
+	var units int64
+	if c.PerGas {
+		units = res.GasAllocated
+		if meter, ok := weave.GetGasMeter(ctx); ok {
+			units += meter.GasConsumed()
+		}
+	} else {
+		raw, err := tx.Marshal()
+		if err != nil {
+			return 0
+		}
+		units = int64(len(raw))
+	}
+	if units < 1 {
+		units = 1
+	}
+
+	if paid > math.MaxInt64/rate {
+		return math.MaxInt64 / units
+	}
+	return paid * rate / units
+}
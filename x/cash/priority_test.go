@@ -0,0 +1,88 @@
+package cash
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/iov-one/weave"
+	coin "github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/store"
+)
+
+func TestPriority(t *testing.T) {
+	// 10 bytes long
+	tx := &sizedTx{size: 10}
+
+	meter := store.NewGasMeter(1000)
+	meter.ConsumeGas(150, "test")
+	gasCtx := weave.WithGasMeter(context.Background(), meter)
+
+	cases := map[string]struct {
+		conf PriorityConfig
+		ctx  weave.Context
+		fee  coin.Coin
+		res  weave.CheckResult
+		want int64
+	}{
+		"fee per byte": {
+			fee:  coin.NewCoin(0, 1000, "IOV"),
+			want: 100,
+		},
+		"whole units are counted as fractional": {
+			fee:  coin.NewCoin(1, 0, "IOV"),
+			want: coin.FracUnit / 10,
+		},
+		"rate of the ticker is applied": {
+			conf: PriorityConfig{Rates: map[string]int64{"IOV": 3, "ETH": 7}},
+			fee:  coin.NewCoin(0, 1000, "ETH"),
+			want: 700,
+		},
+		"ticker without rate has no priority": {
+			conf: PriorityConfig{Rates: map[string]int64{"IOV": 3}},
+			fee:  coin.NewCoin(0, 1000, "ETH"),
+			want: 0,
+		},
+		"no fee": {
+			want: 0,
+		},
+		"fee per gas": {
+			conf: PriorityConfig{PerGas: true},
+			ctx:  gasCtx,
+			fee:  coin.NewCoin(0, 1000, "IOV"),
+			res:  weave.CheckResult{GasAllocated: 50},
+			want: 5,
+		},
+		"fee per gas without meter": {
+			conf: PriorityConfig{PerGas: true},
+			ctx:  context.Background(),
+			fee:  coin.NewCoin(0, 1000, "IOV"),
+			res:  weave.CheckResult{GasAllocated: 50},
+			want: 20,
+		},
+		"overflow is capped": {
+			conf: PriorityConfig{Rates: map[string]int64{"IOV": math.MaxInt64}},
+			fee:  coin.NewCoin(0, 1000, "IOV"),
+			want: math.MaxInt64 / 10,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			got := tc.conf.Priority(tc.ctx, tx, tc.fee, tc.res)
+			if got != tc.want {
+				t.Fatalf("want %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+// sizedTx serializes to the given number of bytes
+type sizedTx struct {
+	weave.Tx
+	size int
+}
+
+func (tx *sizedTx) Marshal() ([]byte, error) {
+	return make([]byte, tx.size), nil
+}
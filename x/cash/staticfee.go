@@ -24,8 +24,10 @@ import (
 )
 
 type FeeDecorator struct {
-	auth x.Authenticator
-	ctrl CoinMover
+	auth     x.Authenticator
+	ctrl     CoinMover
+	priority *PriorityConfig
+	minFee   *CheckMinFee
 }
 
 const (
@@ -45,6 +47,20 @@ func NewFeeDecorator(auth x.Authenticator, ctrl CoinMover) FeeDecorator {
 	}
 }
 
+// WithPriority returns a FeeDecorator setting the priority
+// of checked transactions, computed from the fee they paid
+func (d FeeDecorator) WithPriority(c PriorityConfig) FeeDecorator {
+	d.priority = &c
+	return d
+}
+
+// WithCheckMinFee returns a FeeDecorator rejecting transactions
+// paying less than the node-local minimal fee on CheckTx
+func (d FeeDecorator) WithCheckMinFee(m *CheckMinFee) FeeDecorator {
+	d.minFee = m
+	return d
+}
+
 // Check verifies and deducts fees before calling down the stack
 func (d FeeDecorator) Check(ctx weave.Context, store weave.KVStore, tx weave.Tx,
 	next weave.Checker) (weave.CheckResult, error) {
@@ -54,6 +70,11 @@ func (d FeeDecorator) Check(ctx weave.Context, store weave.KVStore, tx weave.Tx,
 	if err != nil {
 		return res, err
 	}
+	if d.minFee != nil {
+		if err := d.minFee.Verify(finfo.GetFees()); err != nil {
+			return res, err
+		}
+	}
 
 	// if nothing returned, but no error, just move along
 	fee := finfo.GetFees()
@@ -76,6 +97,9 @@ func (d FeeDecorator) Check(ctx weave.Context, store weave.KVStore, tx weave.Tx,
 	paid := toPayment(*fee)
 	res, err = next.Check(ctx, store, tx)
 	res.GasPayment += paid
+	if err == nil && d.priority != nil {
+		res.Priority += d.priority.Priority(ctx, tx, *fee, res)
+	}
 	return res, err
 }
 
@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"context"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/x"
+)
+
+//------------------- Context --------
+// Add context information specific to this package
+
+type contextKey int // local to the cron module
+
+const (
+	contextKeyAuthority contextKey = iota
+)
+
+// withAuthority is a private method, as only this module
+// can execute a task on behalf of its authority
+func withAuthority(ctx weave.Context, authority weave.Condition) weave.Context {
+	return context.WithValue(ctx, contextKeyAuthority, authority)
+}
+
+// Authenticate implements x.Authenticator and provides
+// authentication of scheduled tasks by their authority.
+type Authenticate struct{}
+
+var _ x.Authenticator = Authenticate{}
+
+// GetConditions returns the authority of the currently executed task.
+// May be nil
+func (a Authenticate) GetConditions(ctx weave.Context) []weave.Condition {
+	// (val, ok) form to return nil instead of panic if unset
+	val, _ := ctx.Value(contextKeyAuthority).(weave.Condition)
+	if val == nil {
+		return nil
+	}
+	return []weave.Condition{val}
+}
+
+// HasAddress returns true if the given address is the
+// authority of the currently executed task.
+func (a Authenticate) HasAddress(ctx weave.Context, addr weave.Address) bool {
+	val, _ := ctx.Value(contextKeyAuthority).(weave.Condition)
+	return val != nil && val.Address().Equals(addr)
+}
@@ -0,0 +1,25 @@
+/*
+Package cron implements an on-chain scheduler for delayed execution
+of transactions.
+
+Other extensions can schedule a transaction to be executed at a given
+block height, on behalf of an authority condition (see Schedule). This
+package does not define a message to schedule tasks, and no extension
+calls Schedule yet, so tasks can only be created by the Go code of an
+extension handler. Such a handler must only pass an authority that the
+scheduling transaction is authorized for, otherwise anyone could act
+on behalf of it.
+
+The Ticker executes the due tasks at the beginning of a block, passing
+them through the application handler, and stores the outcome of every
+task under the same key as the task, so it can be queried afterwards.
+
+The number of tasks executed in one block is capped, the remaining ones
+are executed in the following blocks. Every task is metered and fails
+once it consumes more than its gas limit (see Ticker.WithLimits).
+
+Scheduled transactions are not signed and no fees are paid for them.
+They are authorized only by the condition given on scheduling, so the
+application must include Authenticate in its authentication chain.
+*/
+package cron
@@ -0,0 +1,164 @@
+package cron
+
+import (
+	"encoding/binary"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/orm"
+)
+
+const (
+	// TaskBucketName is where we store the scheduled tasks
+	TaskBucketName = "crontask"
+	// ResultBucketName is where we store the results of executed tasks
+	ResultBucketName = "cronres"
+	// SequenceName is an auto-increment ID counter for tasks
+	SequenceName = "id"
+
+	// indexRunAt is the name of the task index by execution height
+	indexRunAt = "runat"
+)
+
+var _ orm.CloneableData = (*Task)(nil)
+
+// Validate ensures the task is valid
+func (t *Task) Validate() error {
+	if t.RunAt <= 0 {
+		return errors.ErrInvalidInput.Newf("run at: %d", t.RunAt)
+	}
+	if err := weave.Condition(t.Authority).Validate(); err != nil {
+		return errors.Wrap(err, "authority")
+	}
+	if len(t.SerializedTx) == 0 {
+		return errors.ErrEmpty.New("serialized tx")
+	}
+	return nil
+}
+
+// Copy makes a new task with the same data
+func (t *Task) Copy() orm.CloneableData {
+	return &Task{
+		RunAt:        t.RunAt,
+		Authority:    t.Authority,
+		SerializedTx: t.SerializedTx,
+	}
+}
+
+var _ orm.CloneableData = (*TaskResult)(nil)
+
+// Validate ensures the task result is valid
+func (r *TaskResult) Validate() error {
+	if r.ExecHeight <= 0 {
+		return errors.ErrInvalidInput.Newf("exec height: %d", r.ExecHeight)
+	}
+	return nil
+}
+
+// Copy makes a new task result with the same data
+func (r *TaskResult) Copy() orm.CloneableData {
+	return &TaskResult{
+		ExecHeight: r.ExecHeight,
+		Successful: r.Successful,
+		Info:       r.Info,
+	}
+}
+
+// AsTask extracts a *Task value or nil from the object
+// Must be called on a TaskBucket result, will panic on bad type.
+func AsTask(obj orm.Object) *Task {
+	if obj == nil || obj.Value() == nil {
+		return nil
+	}
+	return obj.Value().(*Task)
+}
+
+// AsTaskResult extracts a *TaskResult value or nil from the object
+// Must be called on a ResultBucket result, will panic on bad type.
+func AsTaskResult(obj orm.Object) *TaskResult {
+	if obj == nil || obj.Value() == nil {
+		return nil
+	}
+	return obj.Value().(*TaskResult)
+}
+
+//--- TaskBucket - handles scheduled tasks
+
+// TaskBucket is a type-safe wrapper around orm.Bucket,
+// indexing tasks by the height they are executed at
+type TaskBucket struct {
+	orm.Bucket
+	idSeq orm.Sequence
+}
+
+// NewTaskBucket initializes a TaskBucket with default name
+func NewTaskBucket() TaskBucket {
+	bucket := orm.NewBucket(TaskBucketName,
+		orm.NewSimpleObj(nil, new(Task))).
+		WithIndex(indexRunAt, idxRunAt, false)
+
+	return TaskBucket{
+		Bucket: bucket,
+		idSeq:  bucket.Sequence(SequenceName),
+	}
+}
+
+// Create saves the task with a new sequential key
+func (b TaskBucket) Create(db weave.KVStore, task *Task) (orm.Object, error) {
+	obj := orm.NewSimpleObj(b.idSeq.NextVal(db), task)
+	return obj, b.Save(db, obj)
+}
+
+// Save enforces the proper type
+func (b TaskBucket) Save(db weave.KVStore, obj orm.Object) error {
+	if _, ok := obj.Value().(*Task); !ok {
+		return errors.WithType(errors.ErrInvalidModel, obj.Value())
+	}
+	return b.Bucket.Save(db, obj)
+}
+
+// Due returns up to limit tasks to be executed up to the given height,
+// ordered by height and creation. A limit of zero returns all of them.
+func (b TaskBucket) Due(db weave.ReadOnlyKVStore, height int64, limit int) ([]orm.Object, error) {
+	q := &weave.RangeQuery{End: heightKey(height), EndInclusive: true}
+	return b.GetIndexedRangeN(db, indexRunAt, q, limit)
+}
+
+func idxRunAt(obj orm.Object) ([]byte, error) {
+	task, ok := obj.Value().(*Task)
+	if !ok {
+		return nil, errors.ErrHuman.New("Can only take index of Task")
+	}
+	return heightKey(task.RunAt), nil
+}
+
+// heightKey encodes the height, so the keys sort in height order
+func heightKey(height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return bz
+}
+
+//--- ResultBucket - handles results of executed tasks
+
+// ResultBucket is a type-safe wrapper around orm.Bucket,
+// storing task results under the key of the task
+type ResultBucket struct {
+	orm.Bucket
+}
+
+// NewResultBucket initializes a ResultBucket with default name
+func NewResultBucket() ResultBucket {
+	return ResultBucket{
+		Bucket: orm.NewBucket(ResultBucketName,
+			orm.NewSimpleObj(nil, new(TaskResult))),
+	}
+}
+
+// Save enforces the proper type
+func (b ResultBucket) Save(db weave.KVStore, obj orm.Object) error {
+	if _, ok := obj.Value().(*TaskResult); !ok {
+		return errors.WithType(errors.ErrInvalidModel, obj.Value())
+	}
+	return b.Bucket.Save(db, obj)
+}
@@ -0,0 +1,175 @@
+package cron
+
+import (
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/orm"
+	"github.com/iov-one/weave/store"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// RegisterQuery will register tasks as "/crontasks"
+// and their results as "/cronresults"
+func RegisterQuery(qr weave.QueryRouter) {
+	NewTaskBucket().Register("crontasks", qr)
+	NewResultBucket().Register("cronresults", qr)
+}
+
+// Schedule stores the transaction to be executed at the given
+// height on behalf of the authority. It returns the key of the task,
+// which is also the key its result is stored under.
+//
+// Tasks can only be scheduled for future blocks. Schedule is meant to
+// be called by extension handlers, which must check that the current
+// transaction is authorized for the authority.
+func Schedule(ctx weave.Context, db weave.KVStore, runAt int64,
+	authority weave.Condition, tx weave.Tx) ([]byte, error) {
+
+	height, _ := weave.GetHeight(ctx)
+	if runAt <= height {
+		return nil, errors.ErrInvalidInput.Newf("run at %d, current height %d", runAt, height)
+	}
+	raw, err := tx.Marshal()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot serialize tx")
+	}
+	obj, err := NewTaskBucket().Create(db, &Task{
+		RunAt:        runAt,
+		Authority:    authority,
+		SerializedTx: raw,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return obj.Key(), nil
+}
+
+const (
+	// DefaultMaxTasks is the number of tasks executed in one block,
+	// unless configured otherwise with WithLimits
+	DefaultMaxTasks = 100
+	// DefaultTaskGasLimit is the gas a single task may consume,
+	// unless configured otherwise with WithLimits
+	DefaultTaskGasLimit = 1000000
+)
+
+// Ticker executes the due tasks at the beginning of a block
+type Ticker struct {
+	handler  weave.Handler
+	decoder  weave.TxDecoder
+	tasks    TaskBucket
+	results  ResultBucket
+	maxTasks int
+	gasLimit int64
+	config   store.GasConfig
+}
+
+var _ weave.Ticker = (*Ticker)(nil)
+
+// NewTicker creates a Ticker delivering the tasks to the handler.
+//
+// The handler is usually the application router, possibly with
+// decorators that do not depend on signatures or fees.
+// The decoder must understand the transactions that are scheduled.
+func NewTicker(h weave.Handler, decoder weave.TxDecoder) *Ticker {
+	return &Ticker{
+		handler:  h,
+		decoder:  decoder,
+		tasks:    NewTaskBucket(),
+		results:  NewResultBucket(),
+		maxTasks: DefaultMaxTasks,
+		gasLimit: DefaultTaskGasLimit,
+		config:   store.DefaultGasConfig(),
+	}
+}
+
+// WithLimits returns a Ticker executing at most maxTasks tasks
+// per block, each of them allowed to consume up to gasLimit gas
+func (t Ticker) WithLimits(maxTasks int, gasLimit int64) *Ticker {
+	t.maxTasks = maxTasks
+	t.gasLimit = gasLimit
+	return &t
+}
+
+// Tick executes the tasks scheduled up to the current height,
+// at most maxTasks of them. The remaining ones are executed in
+// the following blocks, so a backlog cannot stall a block.
+//
+// Every task is executed in isolation, a failing task does not
+// affect the state nor the other tasks. Its result is stored and
+// the task is removed. Only a failure to update the tasks aborts.
+func (t *Ticker) Tick(ctx weave.Context, db weave.KVStore) (weave.TickResult, error) {
+	var res weave.TickResult
+
+	height, ok := weave.GetHeight(ctx)
+	if !ok {
+		return res, errors.ErrHuman.New("missing block height")
+	}
+	cstore, ok := db.(weave.CacheableKVStore)
+	if !ok {
+		return res, errors.ErrHuman.New("tasks require a cacheable store")
+	}
+
+	tasks, err := t.tasks.Due(db, height, t.maxTasks)
+	if err != nil {
+		return res, errors.Wrap(err, "cannot load tasks")
+	}
+	for _, obj := range tasks {
+		result, diff := t.execute(ctx, cstore, AsTask(obj))
+		result.ExecHeight = height
+		if err := t.results.Save(db, orm.NewSimpleObj(obj.Key(), result)); err != nil {
+			return res, errors.Wrap(err, "cannot save result")
+		}
+		if err := t.tasks.Delete(db, obj.Key()); err != nil {
+			return res, errors.Wrap(err, "cannot delete task")
+		}
+		res.Diff = append(res.Diff, diff...)
+	}
+	return res, nil
+}
+
+// execute delivers the task in a cache, which is only written on success,
+// the same goes for the events emitted by the task. All store access of
+// the task is metered, it fails once it consumed more than the gas limit.
+func (t *Ticker) execute(ctx weave.Context, db weave.CacheableKVStore,
+	task *Task) (*TaskResult, []abci.ValidatorUpdate) {
+
+	cache := db.CacheWrap()
+	meter := store.NewGasMeter(t.gasLimit)
+	events := weave.NewEventManager()
+	tctx := weave.WithEventManager(weave.WithGasMeter(ctx, meter), events)
+	dres, err := t.deliver(tctx, store.NewGasStore(cache, meter, t.config), task)
+	if err != nil {
+		cache.Discard()
+		return &TaskResult{Info: err.Error()}, nil
+	}
+	cache.Write()
+	weave.EmitEvents(ctx, events.Events()...)
+	return &TaskResult{Successful: true, Info: dres.Log}, dres.Diff
+}
+
+// deliver passes the task to the handler, turning panics into errors
+func (t *Ticker) deliver(ctx weave.Context, db weave.KVStore,
+	task *Task) (res weave.DeliverResult, err error) {
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		// keep the error of the gas meter, so running out of gas
+		// can be told apart from a failing handler
+		if e, ok := r.(error); ok && errors.ErrOutOfGas.Is(e) {
+			err = e
+			return
+		}
+		err = errors.NormalizePanic(r)
+	}()
+
+	tx, err := t.decoder(task.SerializedTx)
+	if err != nil {
+		return res, errors.Wrap(err, "cannot decode tx")
+	}
+	ctx = withAuthority(ctx, weave.Condition(task.Authority))
+	return t.handler.Deliver(ctx, db, tx)
+}
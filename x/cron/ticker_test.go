@@ -0,0 +1,215 @@
+package cron
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeHandler writes the serialized message of the tx under the
+// key "done", if the authority of the task is the expected one.
+// Messages "fail" and "panic" do exactly that after writing.
+type writeHandler struct {
+	weavetest.Handler
+	authority weave.Condition
+}
+
+func (h *writeHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	var res weave.DeliverResult
+	if !(Authenticate{}).HasAddress(ctx, h.authority.Address()) {
+		return res, errors.ErrUnauthorized.New("authority")
+	}
+	msg, err := tx.GetMsg()
+	if err != nil {
+		return res, err
+	}
+	raw, _ := msg.Marshal()
+	db.Set([]byte("done"), raw)
+	weave.EmitEvents(ctx, weave.NewEvent("write"))
+	switch string(raw) {
+	case "fail":
+		return res, errors.ErrInvalidInput.New("fail")
+	case "panic":
+		panic("boom")
+	}
+	weave.EmitEvents(ctx, weave.NewEvent("test").With("payload", string(raw)))
+	res.Log = string(raw)
+	return res, nil
+}
+
+func decodeTx(raw []byte) (weave.Tx, error) {
+	return &weavetest.Tx{Msg: &weavetest.Msg{Serialized: raw}}, nil
+}
+
+func newTx(payload string) weave.Tx {
+	return &weavetest.Tx{Msg: &weavetest.Msg{Serialized: []byte(payload)}}
+}
+
+func TestSchedule(t *testing.T) {
+	authority := weavetest.NewCondition()
+	ctx := weave.WithHeight(context.Background(), 10)
+
+	cases := map[string]struct {
+		runAt   int64
+		auth    weave.Condition
+		wantErr *errors.Error
+	}{
+		"next block": {
+			runAt: 11,
+			auth:  authority,
+		},
+		"current block": {
+			runAt:   10,
+			auth:    authority,
+			wantErr: &errors.ErrInvalidInput,
+		},
+		"invalid authority": {
+			runAt:   12,
+			auth:    weave.Condition("foo"),
+			wantErr: &errors.ErrInvalidInput,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := store.MemStore()
+			key, err := Schedule(ctx, db, tc.runAt, tc.auth, newTx("hello"))
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, tc.wantErr.Is(err), "unexpected error: %s", err)
+				return
+			}
+			require.NoError(t, err)
+
+			obj, err := NewTaskBucket().Get(db, key)
+			require.NoError(t, err)
+			task := AsTask(obj)
+			require.NotNil(t, task)
+			assert.Equal(t, tc.runAt, task.RunAt)
+			assert.Equal(t, []byte("hello"), task.SerializedTx)
+		})
+	}
+}
+
+func TestTicker(t *testing.T) {
+	authority := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+	h := &writeHandler{authority: authority}
+	ticker := NewTicker(h, decodeTx)
+
+	db := store.MemStore()
+	ctx := weave.WithHeight(context.Background(), 1)
+	schedule := func(runAt int64, auth weave.Condition, payload string) []byte {
+		key, err := Schedule(ctx, db, runAt, auth, newTx(payload))
+		require.NoError(t, err)
+		return key
+	}
+	late := schedule(5, authority, "late")
+	first := schedule(3, authority, "first")
+	second := schedule(3, authority, "second")
+	failing := schedule(4, authority, "fail")
+	panicking := schedule(4, authority, "panic")
+	unauthorized := schedule(4, other, "other")
+
+	tick := func(height int64) []weave.Event {
+		events := weave.NewEventManager()
+		ctx := weave.WithEventManager(weave.WithHeight(context.Background(), height), events)
+		_, err := ticker.Tick(ctx, db)
+		require.NoError(t, err)
+		return events.Events()
+	}
+	assertResult := func(key []byte, want *TaskResult) {
+		obj, err := NewResultBucket().Get(db, key)
+		require.NoError(t, err)
+		assert.Equal(t, want, AsTaskResult(obj))
+	}
+
+	// nothing due yet
+	tick(2)
+	assert.Nil(t, db.Get([]byte("done")))
+	assertResult(first, nil)
+
+	// tasks at the same height run in the order they were scheduled
+	tick(3)
+	assert.Equal(t, []byte("second"), db.Get([]byte("done")))
+	assertResult(first, &TaskResult{ExecHeight: 3, Successful: true, Info: "first"})
+	assertResult(second, &TaskResult{ExecHeight: 3, Successful: true, Info: "second"})
+
+	// failing tasks do not modify the state nor emit events
+	assert.Empty(t, tick(4))
+	assert.Equal(t, []byte("second"), db.Get([]byte("done")))
+	for _, key := range [][]byte{failing, panicking, unauthorized} {
+		obj, err := NewResultBucket().Get(db, key)
+		require.NoError(t, err)
+		res := AsTaskResult(obj)
+		require.NotNil(t, res)
+		assert.Equal(t, int64(4), res.ExecHeight)
+		assert.False(t, res.Successful)
+		assert.NotEmpty(t, res.Info)
+	}
+
+	// missed heights are caught up with
+	events := tick(7)
+	assert.Equal(t, []weave.Event{
+		weave.NewEvent("write"),
+		weave.NewEvent("test").With("payload", "late"),
+	}, events)
+	assert.Equal(t, []byte("late"), db.Get([]byte("done")))
+	assertResult(late, &TaskResult{ExecHeight: 7, Successful: true, Info: "late"})
+
+	// every task is executed only once
+	objs, err := NewTaskBucket().Due(db, 100, 0)
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+}
+
+func TestTickerLimits(t *testing.T) {
+	authority := weavetest.NewCondition()
+	h := &writeHandler{authority: authority}
+
+	db := store.MemStore()
+	ctx := weave.WithHeight(context.Background(), 1)
+	schedule := func(payload string) []byte {
+		key, err := Schedule(ctx, db, 2, authority, newTx(payload))
+		require.NoError(t, err)
+		return key
+	}
+	first := schedule("first")
+	second := schedule("second")
+	third := schedule("third")
+
+	tick := func(ticker *Ticker, height int64) {
+		_, err := ticker.Tick(weave.WithHeight(context.Background(), height), db)
+		require.NoError(t, err)
+	}
+	result := func(key []byte) *TaskResult {
+		obj, err := NewResultBucket().Get(db, key)
+		require.NoError(t, err)
+		return AsTaskResult(obj)
+	}
+
+	// only two tasks per block, the third one waits for the next block
+	ticker := NewTicker(h, decodeTx).WithLimits(2, DefaultTaskGasLimit)
+	tick(ticker, 2)
+	assert.True(t, result(first).Successful)
+	assert.True(t, result(second).Successful)
+	assert.Nil(t, result(third))
+
+	// a task exceeding the gas limit fails without modifying the state
+	tick(NewTicker(h, decodeTx).WithLimits(2, 30), 3)
+	res := result(third)
+	require.NotNil(t, res)
+	assert.False(t, res.Successful)
+	assert.Contains(t, res.Info, "out of gas")
+	assert.Equal(t, []byte("second"), db.Get([]byte("done")))
+
+	objs, err := NewTaskBucket().Due(db, 100, 0)
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+}
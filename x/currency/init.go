@@ -4,6 +4,12 @@ import (
 	"github.com/iov-one/weave"
 )
 
+// genesisToken is the genesis format of a TokenInfo
+type genesisToken struct {
+	Ticker string `json:"ticker"`
+	Name   string `json:"name"`
+}
+
 // Initializer fulfils the Initializer interface to load data from the genesis
 // file
 type Initializer struct{}
@@ -13,10 +19,7 @@ var _ weave.Initializer = (*Initializer)(nil)
 // FromGenesis will parse initial account info from genesis and save it to the
 // database
 func (*Initializer) FromGenesis(opts weave.Options, db weave.KVStore) error {
-	var tokens []struct {
-		Ticker string `json:"ticker"`
-		Name   string `json:"name"`
-	}
+	var tokens []genesisToken
 	if err := opts.ReadOptions("currencies", &tokens); err != nil {
 		return err
 	}
@@ -30,3 +33,22 @@ func (*Initializer) FromGenesis(opts weave.Options, db weave.KVStore) error {
 	}
 	return nil
 }
+
+var _ weave.Exporter = (*Initializer)(nil)
+
+// ToGenesis exports all tokens in the format read by FromGenesis
+func (*Initializer) ToGenesis(db weave.ReadOnlyKVStore) (weave.Options, error) {
+	objs, err := NewTokenInfoBucket().All(db)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]genesisToken, 0, len(objs))
+	for _, obj := range objs {
+		tokens = append(tokens, genesisToken{
+			Ticker: string(obj.Key()),
+			Name:   obj.Value().(*TokenInfo).Name,
+		})
+	}
+	opts := weave.Options{}
+	return opts, opts.WriteOptions("currencies", tokens)
+}
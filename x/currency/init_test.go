@@ -2,6 +2,7 @@ package currency
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/iov-one/weave"
@@ -42,3 +43,40 @@ func TestGenesisKey(t *testing.T) {
 		t.Errorf("invalid token name: %q", info.Name)
 	}
 }
+
+func TestGenesisExport(t *testing.T) {
+	const genesis = `
+		{
+			"currencies": [
+				{"ticker": "DOGE", "name": "Doge Coin"},
+				{"ticker": "MCR", "name": "my currency"}
+			]
+		}
+	`
+
+	var opts weave.Options
+	if err := json.Unmarshal([]byte(genesis), &opts); err != nil {
+		t.Fatalf("cannot unmarshal genesis: %s", err)
+	}
+
+	var ini Initializer
+	db := store.MemStore()
+	if err := ini.FromGenesis(opts, db); err != nil {
+		t.Fatalf("cannot load genesis: %s", err)
+	}
+	exported, err := ini.ToGenesis(db)
+	if err != nil {
+		t.Fatalf("cannot export genesis: %s", err)
+	}
+
+	var want, got []genesisToken
+	if err := opts.ReadOptions("currencies", &want); err != nil {
+		t.Fatalf("cannot read genesis: %s", err)
+	}
+	if err := exported.ReadOptions("currencies", &got); err != nil {
+		t.Fatalf("cannot read exported genesis: %s", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
@@ -7,6 +7,17 @@ import (
 	"github.com/iov-one/weave/errors"
 )
 
+// genesisRevenue is the genesis format of a Revenue
+type genesisRevenue struct {
+	Admin      weave.Address      `json:"admin"`
+	Recipients []genesisRecipient `json:"recipients"`
+}
+
+type genesisRecipient struct {
+	Address weave.Address `json:"address"`
+	Weight  int32         `json:"weight"`
+}
+
 // Initializer fulfils the Initializer interface to load data from the genesis
 // file
 type Initializer struct{}
@@ -16,14 +27,7 @@ var _ weave.Initializer = (*Initializer)(nil)
 // FromGenesis will parse initial account info from genesis and save it to the
 // database
 func (*Initializer) FromGenesis(opts weave.Options, db weave.KVStore) error {
-	type recipient struct {
-		Address weave.Address `json:"address"`
-		Weight  int32         `json:"weight"`
-	}
-	var revenues []struct {
-		Admin      weave.Address `json:"admin"`
-		Recipients []recipient   `json:"recipients"`
-	}
+	var revenues []genesisRevenue
 	if err := opts.ReadOptions("distribution", &revenues); err != nil {
 		return errors.Wrap(err, "cannot load distribution")
 	}
@@ -50,3 +54,32 @@ func (*Initializer) FromGenesis(opts weave.Options, db weave.KVStore) error {
 	}
 	return nil
 }
+
+var _ weave.Exporter = (*Initializer)(nil)
+
+// ToGenesis exports all revenues in the format read by FromGenesis.
+// Revenues are never deleted and exported in the order of their IDs,
+// so FromGenesis assigns them the same IDs again.
+func (*Initializer) ToGenesis(db weave.ReadOnlyKVStore) (weave.Options, error) {
+	objs, err := NewRevenueBucket().All(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load revenues")
+	}
+	revenues := make([]genesisRevenue, 0, len(objs))
+	for _, obj := range objs {
+		rev := obj.Value().(*Revenue)
+		recipients := make([]genesisRecipient, 0, len(rev.Recipients))
+		for _, rc := range rev.Recipients {
+			recipients = append(recipients, genesisRecipient{
+				Address: rc.Address,
+				Weight:  rc.Weight,
+			})
+		}
+		revenues = append(revenues, genesisRevenue{
+			Admin:      rev.Admin,
+			Recipients: recipients,
+		})
+	}
+	opts := weave.Options{}
+	return opts, opts.WriteOptions("distribution", revenues)
+}
@@ -3,6 +3,7 @@ package distribution
 import (
 	"encoding/hex"
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/iov-one/weave"
@@ -64,3 +65,51 @@ func TestGenesisKey(t *testing.T) {
 		t.Fatalf("unexected address: %q", r.Address)
 	}
 }
+
+func TestGenesisExport(t *testing.T) {
+	const genesis = `
+		{
+			"distribution": [
+				{
+					"admin": "E94323317C46BDA2268FA3698BAF4F95B893E8C7",
+					"recipients": [
+						{"weight": 2, "address": "E94323317C46BDA2268FA3698BAF4F95B893E8C7"},
+						{"weight": 1, "address": "FE5526DE08337DFEF5CF45EF3ED8C577B854DE34"}
+					]
+				},
+				{
+					"admin": "FE5526DE08337DFEF5CF45EF3ED8C577B854DE34",
+					"recipients": [
+						{"weight": 5, "address": "E94323317C46BDA2268FA3698BAF4F95B893E8C7"}
+					]
+				}
+			]
+		}
+	`
+
+	var opts weave.Options
+	if err := json.Unmarshal([]byte(genesis), &opts); err != nil {
+		t.Fatalf("cannot unmarshal genesis: %s", err)
+	}
+
+	var ini Initializer
+	db := store.MemStore()
+	if err := ini.FromGenesis(opts, db); err != nil {
+		t.Fatalf("cannot load genesis: %s", err)
+	}
+	exported, err := ini.ToGenesis(db)
+	if err != nil {
+		t.Fatalf("cannot export genesis: %s", err)
+	}
+
+	var want, got []genesisRevenue
+	if err := opts.ReadOptions("distribution", &want); err != nil {
+		t.Fatalf("cannot read genesis: %s", err)
+	}
+	if err := exported.ReadOptions("distribution", &got); err != nil {
+		t.Fatalf("cannot read exported genesis: %s", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
@@ -6,8 +6,11 @@ package escrow
 import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import _ "github.com/gogo/protobuf/gogoproto"
 import coin "github.com/iov-one/weave/coin"
 
+import github_com_iov_one_weave "github.com/iov-one/weave"
+
 import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -30,9 +33,9 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 // an HTLC ;)
 type Escrow struct {
 	// Sender, Arbiter, Recipient are all weave.Permission
-	Sender    []byte `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
-	Arbiter   []byte `protobuf:"bytes,2,opt,name=arbiter,proto3" json:"arbiter,omitempty"`
-	Recipient []byte `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Sender    github_com_iov_one_weave.Address `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/iov-one/weave.Address" json:"sender,omitempty"`
+	Arbiter   []byte                           `protobuf:"bytes,2,opt,name=arbiter,proto3" json:"arbiter,omitempty"`
+	Recipient github_com_iov_one_weave.Address `protobuf:"bytes,3,opt,name=recipient,proto3,casttype=github.com/iov-one/weave.Address" json:"recipient,omitempty"`
 	// amount may contain multiple token types
 	Amount []*coin.Coin `protobuf:"bytes,4,rep,name=amount" json:"amount,omitempty"`
 	// if unreleased before timeout, will return to sender
@@ -48,7 +51,7 @@ func (m *Escrow) Reset()         { *m = Escrow{} }
 func (m *Escrow) String() string { return proto.CompactTextString(m) }
 func (*Escrow) ProtoMessage()    {}
 func (*Escrow) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_f219ed13388eed8a, []int{0}
+	return fileDescriptor_codec_9ed9b83447117278, []int{0}
 }
 func (m *Escrow) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -77,7 +80,7 @@ func (m *Escrow) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Escrow proto.InternalMessageInfo
 
-func (m *Escrow) GetSender() []byte {
+func (m *Escrow) GetSender() github_com_iov_one_weave.Address {
 	if m != nil {
 		return m.Sender
 	}
@@ -91,7 +94,7 @@ func (m *Escrow) GetArbiter() []byte {
 	return nil
 }
 
-func (m *Escrow) GetRecipient() []byte {
+func (m *Escrow) GetRecipient() github_com_iov_one_weave.Address {
 	if m != nil {
 		return m.Recipient
 	}
@@ -124,9 +127,9 @@ func (m *Escrow) GetMemo() string {
 // The rest must be defined
 type CreateEscrowMsg struct {
 	// Sender, Arbiter, Recipient are all weave.Permission
-	Src       []byte `protobuf:"bytes,1,opt,name=src,proto3" json:"src,omitempty"`
-	Arbiter   []byte `protobuf:"bytes,2,opt,name=arbiter,proto3" json:"arbiter,omitempty"`
-	Recipient []byte `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Src       github_com_iov_one_weave.Address `protobuf:"bytes,1,opt,name=src,proto3,casttype=github.com/iov-one/weave.Address" json:"src,omitempty"`
+	Arbiter   []byte                           `protobuf:"bytes,2,opt,name=arbiter,proto3" json:"arbiter,omitempty"`
+	Recipient github_com_iov_one_weave.Address `protobuf:"bytes,3,opt,name=recipient,proto3,casttype=github.com/iov-one/weave.Address" json:"recipient,omitempty"`
 	// amount may contain multiple token types
 	Amount []*coin.Coin `protobuf:"bytes,4,rep,name=amount" json:"amount,omitempty"`
 	// if unreleased before timeout, will return to sender
@@ -141,7 +144,7 @@ func (m *CreateEscrowMsg) Reset()         { *m = CreateEscrowMsg{} }
 func (m *CreateEscrowMsg) String() string { return proto.CompactTextString(m) }
 func (*CreateEscrowMsg) ProtoMessage()    {}
 func (*CreateEscrowMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_f219ed13388eed8a, []int{1}
+	return fileDescriptor_codec_9ed9b83447117278, []int{1}
 }
 func (m *CreateEscrowMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -170,7 +173,7 @@ func (m *CreateEscrowMsg) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CreateEscrowMsg proto.InternalMessageInfo
 
-func (m *CreateEscrowMsg) GetSrc() []byte {
+func (m *CreateEscrowMsg) GetSrc() github_com_iov_one_weave.Address {
 	if m != nil {
 		return m.Src
 	}
@@ -184,7 +187,7 @@ func (m *CreateEscrowMsg) GetArbiter() []byte {
 	return nil
 }
 
-func (m *CreateEscrowMsg) GetRecipient() []byte {
+func (m *CreateEscrowMsg) GetRecipient() github_com_iov_one_weave.Address {
 	if m != nil {
 		return m.Recipient
 	}
@@ -227,7 +230,7 @@ func (m *ReleaseEscrowMsg) Reset()         { *m = ReleaseEscrowMsg{} }
 func (m *ReleaseEscrowMsg) String() string { return proto.CompactTextString(m) }
 func (*ReleaseEscrowMsg) ProtoMessage()    {}
 func (*ReleaseEscrowMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_f219ed13388eed8a, []int{2}
+	return fileDescriptor_codec_9ed9b83447117278, []int{2}
 }
 func (m *ReleaseEscrowMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -282,7 +285,7 @@ func (m *ReturnEscrowMsg) Reset()         { *m = ReturnEscrowMsg{} }
 func (m *ReturnEscrowMsg) String() string { return proto.CompactTextString(m) }
 func (*ReturnEscrowMsg) ProtoMessage()    {}
 func (*ReturnEscrowMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_f219ed13388eed8a, []int{3}
+	return fileDescriptor_codec_9ed9b83447117278, []int{3}
 }
 func (m *ReturnEscrowMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -324,19 +327,19 @@ func (m *ReturnEscrowMsg) GetEscrowId() []byte {
 //
 // Represents delegating responsibility
 type UpdateEscrowPartiesMsg struct {
-	EscrowId             []byte   `protobuf:"bytes,1,opt,name=escrow_id,json=escrowId,proto3" json:"escrow_id,omitempty"`
-	Sender               []byte   `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
-	Arbiter              []byte   `protobuf:"bytes,3,opt,name=arbiter,proto3" json:"arbiter,omitempty"`
-	Recipient            []byte   `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	EscrowId             []byte                           `protobuf:"bytes,1,opt,name=escrow_id,json=escrowId,proto3" json:"escrow_id,omitempty"`
+	Sender               github_com_iov_one_weave.Address `protobuf:"bytes,2,opt,name=sender,proto3,casttype=github.com/iov-one/weave.Address" json:"sender,omitempty"`
+	Arbiter              []byte                           `protobuf:"bytes,3,opt,name=arbiter,proto3" json:"arbiter,omitempty"`
+	Recipient            github_com_iov_one_weave.Address `protobuf:"bytes,4,opt,name=recipient,proto3,casttype=github.com/iov-one/weave.Address" json:"recipient,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
 }
 
 func (m *UpdateEscrowPartiesMsg) Reset()         { *m = UpdateEscrowPartiesMsg{} }
 func (m *UpdateEscrowPartiesMsg) String() string { return proto.CompactTextString(m) }
 func (*UpdateEscrowPartiesMsg) ProtoMessage()    {}
 func (*UpdateEscrowPartiesMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_f219ed13388eed8a, []int{4}
+	return fileDescriptor_codec_9ed9b83447117278, []int{4}
 }
 func (m *UpdateEscrowPartiesMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -372,7 +375,7 @@ func (m *UpdateEscrowPartiesMsg) GetEscrowId() []byte {
 	return nil
 }
 
-func (m *UpdateEscrowPartiesMsg) GetSender() []byte {
+func (m *UpdateEscrowPartiesMsg) GetSender() github_com_iov_one_weave.Address {
 	if m != nil {
 		return m.Sender
 	}
@@ -386,7 +389,7 @@ func (m *UpdateEscrowPartiesMsg) GetArbiter() []byte {
 	return nil
 }
 
-func (m *UpdateEscrowPartiesMsg) GetRecipient() []byte {
+func (m *UpdateEscrowPartiesMsg) GetRecipient() github_com_iov_one_weave.Address {
 	if m != nil {
 		return m.Recipient
 	}
@@ -1668,29 +1671,32 @@ var (
 	ErrIntOverflowCodec   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("x/escrow/codec.proto", fileDescriptor_codec_f219ed13388eed8a) }
-
-var fileDescriptor_codec_f219ed13388eed8a = []byte{
-	// 331 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x92, 0xc1, 0x4a, 0xc3, 0x40,
-	0x10, 0x86, 0xdd, 0xa6, 0x46, 0x3b, 0x0a, 0x2d, 0x8b, 0x94, 0x45, 0x25, 0x84, 0x9c, 0xe2, 0xc1,
-	0x04, 0xf4, 0x0d, 0x2c, 0x1e, 0x3c, 0x08, 0x12, 0xf1, 0x2c, 0xe9, 0x66, 0xa8, 0x0b, 0x66, 0xa7,
-	0x6c, 0x36, 0xad, 0x2f, 0xe0, 0xdd, 0xe7, 0x10, 0x7c, 0x0f, 0x8f, 0x3e, 0x82, 0xd4, 0x17, 0x91,
-	0x26, 0xa9, 0x2d, 0x42, 0xd5, 0xa3, 0xb7, 0x99, 0xf9, 0x99, 0xd9, 0x8f, 0x7f, 0x7f, 0xd8, 0x7b,
-	0x88, 0xb1, 0x90, 0x86, 0xa6, 0xb1, 0xa4, 0x0c, 0x65, 0x34, 0x36, 0x64, 0x89, 0xbb, 0xf5, 0x6c,
-	0xff, 0x68, 0xa4, 0xec, 0x5d, 0x39, 0x8c, 0x24, 0xe5, 0xb1, 0xa2, 0xc9, 0x31, 0x69, 0x8c, 0xa7,
-	0x98, 0x4e, 0x30, 0x96, 0xa4, 0xf4, 0xea, 0x4a, 0xf0, 0xcc, 0xc0, 0x3d, 0xaf, 0xb6, 0x78, 0x1f,
-	0xdc, 0x02, 0x75, 0x86, 0x46, 0x30, 0x9f, 0x85, 0xbb, 0x49, 0xd3, 0x71, 0x01, 0x5b, 0xa9, 0x19,
-	0x2a, 0x8b, 0x46, 0xb4, 0x2a, 0x61, 0xd1, 0xf2, 0x43, 0xe8, 0x18, 0x94, 0x6a, 0xac, 0x50, 0x5b,
-	0xe1, 0x54, 0xda, 0x72, 0xc0, 0x03, 0x70, 0xd3, 0x9c, 0x4a, 0x6d, 0x45, 0xdb, 0x77, 0xc2, 0x9d,
-	0x13, 0x88, 0xe6, 0xaf, 0x47, 0x03, 0x52, 0x3a, 0x69, 0x94, 0xf9, 0x6d, 0xab, 0x72, 0xa4, 0xd2,
-	0x8a, 0x4d, 0x9f, 0x85, 0x4e, 0xb2, 0x68, 0x39, 0x87, 0x76, 0x8e, 0x39, 0x09, 0xd7, 0x67, 0x61,
-	0x27, 0xa9, 0xea, 0xe0, 0x85, 0x41, 0x77, 0x60, 0x30, 0xb5, 0x58, 0x23, 0x5f, 0x16, 0x23, 0xde,
-	0x03, 0xa7, 0x30, 0xb2, 0x41, 0x9e, 0x97, 0xff, 0x88, 0xf7, 0x1a, 0x7a, 0x09, 0xde, 0x63, 0x5a,
-	0xac, 0xf0, 0x1e, 0x40, 0xa7, 0xfe, 0xa5, 0x5b, 0x95, 0x35, 0xd4, 0xdb, 0xf5, 0xe0, 0x22, 0x5b,
-	0x41, 0x68, 0xad, 0x43, 0x08, 0x22, 0xe8, 0x26, 0x68, 0x4b, 0xa3, 0xff, 0x76, 0x33, 0x78, 0x64,
-	0xd0, 0xbf, 0x19, 0x67, 0x5f, 0xa6, 0x5d, 0xa5, 0xc6, 0x2a, 0x2c, 0x7e, 0x65, 0x59, 0xc6, 0xa1,
-	0xb5, 0x2e, 0x0e, 0xce, 0x0f, 0xf6, 0xb6, 0xbf, 0xd9, 0x7b, 0xd6, 0x7b, 0x9d, 0x79, 0xec, 0x6d,
-	0xe6, 0xb1, 0xf7, 0x99, 0xc7, 0x9e, 0x3e, 0xbc, 0x8d, 0xa1, 0x5b, 0x45, 0xf0, 0xf4, 0x33, 0x00,
-	0x00, 0xff, 0xff, 0xa5, 0xc0, 0x71, 0xa1, 0xcd, 0x02, 0x00, 0x00,
+func init() { proto.RegisterFile("x/escrow/codec.proto", fileDescriptor_codec_9ed9b83447117278) }
+
+var fileDescriptor_codec_9ed9b83447117278 = []byte{
+	// 372 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x53, 0x4d, 0x4b, 0xf3, 0x40,
+	0x10, 0x7e, 0xb7, 0xe9, 0x9b, 0xf7, 0xed, 0x2a, 0xb4, 0x2c, 0x22, 0xa1, 0x42, 0x0c, 0xc1, 0x43,
+	0x3c, 0x34, 0x01, 0x05, 0x4f, 0x5e, 0x6c, 0xf1, 0xe0, 0x41, 0x90, 0x15, 0xcf, 0x92, 0x8f, 0x31,
+	0x2e, 0x98, 0x6c, 0xd9, 0xdd, 0xb4, 0xfe, 0x0c, 0x7f, 0x96, 0x47, 0xfd, 0x03, 0x22, 0xf5, 0x3f,
+	0x08, 0x7a, 0x92, 0x6c, 0x5a, 0x9b, 0x4b, 0xd5, 0xea, 0xc9, 0xdb, 0x3c, 0x33, 0x79, 0x66, 0x9e,
+	0x27, 0x33, 0x8b, 0xd7, 0xae, 0x03, 0x90, 0xb1, 0xe0, 0xe3, 0x20, 0xe6, 0x09, 0xc4, 0xfe, 0x50,
+	0x70, 0xc5, 0x89, 0x59, 0xe5, 0xba, 0xbd, 0x94, 0xa9, 0xcb, 0x22, 0xf2, 0x63, 0x9e, 0x05, 0x29,
+	0x4f, 0x79, 0xa0, 0xcb, 0x51, 0x71, 0xa1, 0x91, 0x06, 0x3a, 0xaa, 0x68, 0xdd, 0xed, 0xda, 0xe7,
+	0x8c, 0x8f, 0x7a, 0x3c, 0x87, 0x60, 0x0c, 0xe1, 0x08, 0x82, 0x98, 0xb3, 0xbc, 0x3e, 0xc1, 0x7d,
+	0x46, 0xd8, 0x3c, 0xd4, 0x43, 0xc8, 0x3e, 0x36, 0x25, 0xe4, 0x09, 0x08, 0x0b, 0x39, 0xc8, 0x5b,
+	0xed, 0x6f, 0xbd, 0x3e, 0x6c, 0x3a, 0x8b, 0x3a, 0xf9, 0x07, 0x49, 0x22, 0x40, 0x4a, 0x3a, 0xe5,
+	0x10, 0x0b, 0xff, 0x0b, 0x45, 0xc4, 0x14, 0x08, 0xab, 0x51, 0xd2, 0xe9, 0x0c, 0x92, 0x3e, 0x6e,
+	0x09, 0x88, 0xd9, 0x90, 0x41, 0xae, 0x2c, 0x63, 0x89, 0xd6, 0x73, 0x1a, 0x71, 0xb1, 0x19, 0x66,
+	0xbc, 0xc8, 0x95, 0xd5, 0x74, 0x0c, 0x6f, 0x65, 0x07, 0xfb, 0xa5, 0x13, 0x7f, 0xc0, 0x59, 0x4e,
+	0xa7, 0x95, 0x52, 0x81, 0x62, 0x19, 0xf0, 0x42, 0x59, 0x7f, 0x1d, 0xe4, 0x19, 0x74, 0x06, 0x09,
+	0xc1, 0xcd, 0x0c, 0x32, 0x6e, 0x99, 0x0e, 0xf2, 0x5a, 0x54, 0xc7, 0xee, 0x0b, 0xc2, 0xed, 0x81,
+	0x80, 0x50, 0x41, 0x65, 0xff, 0x58, 0xa6, 0x64, 0x0f, 0x1b, 0x52, 0xc4, 0x4b, 0xd9, 0x2f, 0x09,
+	0xbf, 0xce, 0xfb, 0x29, 0xee, 0x50, 0xb8, 0x82, 0x50, 0xd6, 0xbc, 0x6f, 0xe0, 0x56, 0x75, 0x6c,
+	0xe7, 0x2c, 0xa9, 0xfe, 0x00, 0xfd, 0x5f, 0x25, 0x8e, 0x92, 0x9a, 0x84, 0xc6, 0x22, 0x09, 0xae,
+	0x8f, 0xdb, 0x14, 0x54, 0x21, 0xf2, 0xaf, 0xf5, 0x74, 0xef, 0x11, 0x5e, 0x3f, 0x1b, 0x26, 0xef,
+	0x0b, 0x38, 0x09, 0x85, 0x62, 0x20, 0x3f, 0xd5, 0x32, 0x3f, 0xd3, 0xc6, 0xcf, 0xce, 0xd4, 0xf8,
+	0x60, 0x55, 0xcd, 0x6f, 0xad, 0xaa, 0xdf, 0xb9, 0x9d, 0xd8, 0xe8, 0x6e, 0x62, 0xa3, 0xc7, 0x89,
+	0x8d, 0x6e, 0x9e, 0xec, 0x3f, 0x91, 0xa9, 0x9f, 0xd9, 0xee, 0xdb, 0x00, 0xfa, 0xaa, 0xd8, 0xa7,
+	0xe0, 0x03, 0x00, 0x00,
 }
@@ -458,7 +458,7 @@ func TestHandler(t *testing.T) {
 				perms: []weave.Condition{a},
 				msg: &UpdateEscrowPartiesMsg{
 					EscrowId: id(1),
-					Sender:   d,
+					Sender:   d.Address(),
 				},
 				height: Timeout + 100,
 			},
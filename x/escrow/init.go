@@ -0,0 +1,97 @@
+package escrow
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+)
+
+// genesisEscrow is the genesis format of an Escrow. The ID is part
+// of it, as released escrows leave gaps in the sequence and the
+// address holding the coins is derived from the ID.
+type genesisEscrow struct {
+	ID        hexBytes      `json:"id"`
+	Sender    weave.Address `json:"sender"`
+	Arbiter   hexBytes      `json:"arbiter"`
+	Recipient weave.Address `json:"recipient"`
+	Amount    []*coin.Coin  `json:"amount"`
+	Timeout   int64         `json:"timeout"`
+	Memo      string        `json:"memo"`
+}
+
+// hexBytes is serialized as hex in JSON, like weave.Address
+type hexBytes []byte
+
+func (h hexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(h))
+}
+
+func (h *hexBytes) UnmarshalJSON(raw []byte) error {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return err
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*h = b
+	return nil
+}
+
+// Initializer fulfils the Initializer interface to load data from the genesis
+// file
+type Initializer struct{}
+
+var _ weave.Initializer = (*Initializer)(nil)
+
+// FromGenesis will parse initial escrows from genesis and save them in the
+// database, keeping their IDs.
+func (*Initializer) FromGenesis(opts weave.Options, db weave.KVStore) error {
+	var escrows []genesisEscrow
+	if err := opts.ReadOptions("escrow", &escrows); err != nil {
+		return errors.Wrap(err, "cannot load escrows")
+	}
+
+	bucket := NewBucket()
+	for i, e := range escrows {
+		obj := NewEscrow(e.ID, e.Sender, e.Recipient, weave.Condition(e.Arbiter),
+			e.Amount, e.Timeout, e.Memo)
+		if err := bucket.idSeq.Reserve(db, e.ID); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("escrow #%d has an invalid id", i))
+		}
+		if err := bucket.Save(db, obj); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("cannot store #%d escrow", i))
+		}
+	}
+	return nil
+}
+
+var _ weave.Exporter = (*Initializer)(nil)
+
+// ToGenesis exports all escrows in the format read by FromGenesis
+func (*Initializer) ToGenesis(db weave.ReadOnlyKVStore) (weave.Options, error) {
+	objs, err := NewBucket().All(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load escrows")
+	}
+	escrows := make([]genesisEscrow, 0, len(objs))
+	for _, obj := range objs {
+		e := AsEscrow(obj)
+		escrows = append(escrows, genesisEscrow{
+			ID:        obj.Key(),
+			Sender:    e.Sender,
+			Arbiter:   e.Arbiter,
+			Recipient: e.Recipient,
+			Amount:    e.Amount,
+			Timeout:   e.Timeout,
+			Memo:      e.Memo,
+		})
+	}
+	opts := weave.Options{}
+	return opts, opts.WriteOptions("escrow", escrows)
+}
@@ -0,0 +1,73 @@
+package escrow
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/iov-one/weave"
+	coin "github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesisExport(t *testing.T) {
+	const genesis = `
+		{
+			"escrow": [
+				{
+					"id": "0000000000000001",
+					"sender": "E94323317C46BDA2268FA3698BAF4F95B893E8C7",
+					"arbiter": "736967732f656432353531392f01",
+					"recipient": "FE5526DE08337DFEF5CF45EF3ED8C577B854DE34",
+					"amount": [{"whole": 10, "ticker": "IOV"}],
+					"timeout": 1000,
+					"memo": "first"
+				},
+				{
+					"id": "0000000000000005",
+					"sender": "FE5526DE08337DFEF5CF45EF3ED8C577B854DE34",
+					"arbiter": "736967732f656432353531392f02",
+					"recipient": "E94323317C46BDA2268FA3698BAF4F95B893E8C7",
+					"amount": [{"whole": 1, "fractional": 5, "ticker": "ETH"}],
+					"timeout": 2000
+				}
+			]
+		}
+	`
+
+	var opts weave.Options
+	require.NoError(t, json.Unmarshal([]byte(genesis), &opts))
+
+	var ini Initializer
+	db := store.MemStore()
+	require.NoError(t, ini.FromGenesis(opts, db))
+
+	bucket := NewBucket()
+	obj, err := bucket.Get(db, seq(5))
+	require.NoError(t, err)
+	require.NotNil(t, obj)
+	esc := AsEscrow(obj)
+	assert.Equal(t, int64(2000), esc.Timeout)
+	assert.Equal(t, coin.NewCoin(1, 5, "ETH"), *esc.Amount[0])
+
+	// new escrows must not reuse the IDs from genesis
+	next := bucket.Build(db, &Escrow{})
+	assert.Equal(t, seq(6), next.Key())
+
+	exported, err := ini.ToGenesis(db)
+	require.NoError(t, err)
+
+	var want, got []genesisEscrow
+	require.NoError(t, opts.ReadOptions("escrow", &want))
+	require.NoError(t, exported.ReadOptions("escrow", &got))
+	assert.Equal(t, want, got)
+}
+
+// seq returns encoded sequence number as implemented in orm/sequence.go
+func seq(val int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(val))
+	return b
+}
@@ -0,0 +1,45 @@
+package escrow
+
+import (
+	"encoding/json"
+	"testing"
+
+	coin "github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/orm"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderJSON(t *testing.T) {
+	sender := weavetest.NewCondition().Address()
+	rcpt := weavetest.NewCondition().Address()
+	arbiter := weavetest.NewCondition()
+
+	db := store.MemStore()
+	bucket := NewBucket()
+	obj := bucket.Build(db, &Escrow{
+		Sender:    sender,
+		Arbiter:   arbiter,
+		Recipient: rcpt,
+		Amount:    []*coin.Coin{coin.NewCoinp(10, 0, "IOV")},
+		Timeout:   1000,
+	})
+	require.NoError(t, bucket.Save(db, obj))
+
+	r := orm.NewModelRegistry()
+	bucket.RegisterModel(r)
+	key := append([]byte(BucketName+":"), obj.Key()...)
+	raw, err := r.RenderJSON(key, db.Get(key))
+	require.NoError(t, err)
+
+	// addresses are rendered as hex, like in the genesis file
+	var got struct {
+		Sender    string `json:"sender"`
+		Recipient string `json:"recipient"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &got))
+	assert.Equal(t, sender.String(), got.Sender)
+	assert.Equal(t, rcpt.String(), got.Recipient)
+}
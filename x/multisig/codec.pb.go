@@ -8,6 +8,8 @@ import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
 
+import github_com_iov_one_weave "github.com/iov-one/weave"
+
 import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -23,7 +25,7 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type Contract struct {
 	// addresses to control it
-	Sigs [][]byte `protobuf:"bytes,1,rep,name=sigs" json:"sigs,omitempty"`
+	Sigs []github_com_iov_one_weave.Address `protobuf:"bytes,1,rep,name=sigs,casttype=github.com/iov-one/weave.Address" json:"sigs,omitempty"`
 	// threshold needed to sign to activate it
 	ActivationThreshold int64 `protobuf:"varint,2,opt,name=activation_threshold,json=activationThreshold,proto3" json:"activation_threshold,omitempty"`
 	// threshold needed to sign to change it
@@ -36,7 +38,7 @@ func (m *Contract) Reset()         { *m = Contract{} }
 func (m *Contract) String() string { return proto.CompactTextString(m) }
 func (*Contract) ProtoMessage()    {}
 func (*Contract) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_7e61cf6fb6bc9197, []int{0}
+	return fileDescriptor_codec_0fb16cbcb5dc12e3, []int{0}
 }
 func (m *Contract) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -65,7 +67,7 @@ func (m *Contract) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Contract proto.InternalMessageInfo
 
-func (m *Contract) GetSigs() [][]byte {
+func (m *Contract) GetSigs() []github_com_iov_one_weave.Address {
 	if m != nil {
 		return m.Sigs
 	}
@@ -88,7 +90,7 @@ func (m *Contract) GetAdminThreshold() int64 {
 
 type CreateContractMsg struct {
 	// addresses to control it
-	Sigs [][]byte `protobuf:"bytes,1,rep,name=sigs" json:"sigs,omitempty"`
+	Sigs []github_com_iov_one_weave.Address `protobuf:"bytes,1,rep,name=sigs,casttype=github.com/iov-one/weave.Address" json:"sigs,omitempty"`
 	// threshold needed to sign to activate it
 	ActivationThreshold int64 `protobuf:"varint,2,opt,name=activation_threshold,json=activationThreshold,proto3" json:"activation_threshold,omitempty"`
 	// threshold needed to sign to change it
@@ -101,7 +103,7 @@ func (m *CreateContractMsg) Reset()         { *m = CreateContractMsg{} }
 func (m *CreateContractMsg) String() string { return proto.CompactTextString(m) }
 func (*CreateContractMsg) ProtoMessage()    {}
 func (*CreateContractMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_7e61cf6fb6bc9197, []int{1}
+	return fileDescriptor_codec_0fb16cbcb5dc12e3, []int{1}
 }
 func (m *CreateContractMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -130,7 +132,7 @@ func (m *CreateContractMsg) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CreateContractMsg proto.InternalMessageInfo
 
-func (m *CreateContractMsg) GetSigs() [][]byte {
+func (m *CreateContractMsg) GetSigs() []github_com_iov_one_weave.Address {
 	if m != nil {
 		return m.Sigs
 	}
@@ -155,7 +157,7 @@ type UpdateContractMsg struct {
 	// contract id
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	// addresses to control it
-	Sigs [][]byte `protobuf:"bytes,2,rep,name=sigs" json:"sigs,omitempty"`
+	Sigs []github_com_iov_one_weave.Address `protobuf:"bytes,2,rep,name=sigs,casttype=github.com/iov-one/weave.Address" json:"sigs,omitempty"`
 	// threshold needed to sign to activate it
 	ActivationThreshold int64 `protobuf:"varint,3,opt,name=activation_threshold,json=activationThreshold,proto3" json:"activation_threshold,omitempty"`
 	// threshold needed to sign to change it
@@ -168,7 +170,7 @@ func (m *UpdateContractMsg) Reset()         { *m = UpdateContractMsg{} }
 func (m *UpdateContractMsg) String() string { return proto.CompactTextString(m) }
 func (*UpdateContractMsg) ProtoMessage()    {}
 func (*UpdateContractMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_7e61cf6fb6bc9197, []int{2}
+	return fileDescriptor_codec_0fb16cbcb5dc12e3, []int{2}
 }
 func (m *UpdateContractMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -204,7 +206,7 @@ func (m *UpdateContractMsg) GetId() []byte {
 	return nil
 }
 
-func (m *UpdateContractMsg) GetSigs() [][]byte {
+func (m *UpdateContractMsg) GetSigs() []github_com_iov_one_weave.Address {
 	if m != nil {
 		return m.Sigs
 	}
@@ -911,24 +913,26 @@ var (
 	ErrIntOverflowCodec   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("x/multisig/codec.proto", fileDescriptor_codec_7e61cf6fb6bc9197) }
+func init() { proto.RegisterFile("x/multisig/codec.proto", fileDescriptor_codec_0fb16cbcb5dc12e3) }
 
-var fileDescriptor_codec_7e61cf6fb6bc9197 = []byte{
-	// 247 bytes of a gzipped FileDescriptorProto
+var fileDescriptor_codec_0fb16cbcb5dc12e3 = []byte{
+	// 275 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0xab, 0xd0, 0xcf, 0x2d,
 	0xcd, 0x29, 0xc9, 0x2c, 0xce, 0x4c, 0xd7, 0x4f, 0xce, 0x4f, 0x49, 0x4d, 0xd6, 0x2b, 0x28, 0xca,
 	0x2f, 0xc9, 0x17, 0xe2, 0x80, 0x89, 0x4a, 0xe9, 0xa6, 0x67, 0x96, 0x64, 0x94, 0x26, 0xe9, 0x25,
 	0xe7, 0xe7, 0xea, 0xa7, 0xe7, 0xa7, 0xe7, 0xeb, 0x83, 0x15, 0x24, 0x95, 0xa6, 0x81, 0x79, 0x60,
-	0x0e, 0x98, 0x05, 0xd1, 0xa8, 0x54, 0xc5, 0xc5, 0xe1, 0x9c, 0x9f, 0x57, 0x52, 0x94, 0x98, 0x5c,
-	0x22, 0x24, 0xc4, 0xc5, 0x52, 0x9c, 0x99, 0x5e, 0x2c, 0xc1, 0xa8, 0xc0, 0xac, 0xc1, 0x13, 0x04,
-	0x66, 0x0b, 0x19, 0x72, 0x89, 0x24, 0x26, 0x97, 0x64, 0x96, 0x25, 0x96, 0x64, 0xe6, 0xe7, 0xc5,
-	0x97, 0x64, 0x14, 0xa5, 0x16, 0x67, 0xe4, 0xe7, 0xa4, 0x48, 0x30, 0x29, 0x30, 0x6a, 0x30, 0x07,
-	0x09, 0x23, 0xe4, 0x42, 0x60, 0x52, 0x42, 0xea, 0x5c, 0xfc, 0x89, 0x29, 0xb9, 0x99, 0xc8, 0xaa,
-	0x99, 0xc1, 0xaa, 0xf9, 0xc0, 0xc2, 0x70, 0x85, 0x4a, 0xcd, 0x8c, 0x5c, 0x82, 0xce, 0x45, 0xa9,
-	0x89, 0x25, 0xa9, 0x30, 0x27, 0xf8, 0x16, 0xa7, 0xd3, 0xdd, 0x15, 0x93, 0x19, 0xb9, 0x04, 0x43,
-	0x0b, 0x52, 0xd0, 0x5c, 0xc1, 0xc7, 0xc5, 0x94, 0x99, 0x22, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x13,
-	0xc4, 0x94, 0x99, 0x02, 0x77, 0x15, 0x13, 0x11, 0xae, 0x62, 0x26, 0xc9, 0x55, 0x2c, 0xd8, 0x5c,
-	0xe5, 0x24, 0x70, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0x4e,
-	0x78, 0x2c, 0xc7, 0x90, 0xc4, 0x06, 0x8e, 0x30, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x45,
-	0x6b, 0xdc, 0x85, 0x03, 0x02, 0x00, 0x00,
+	0x0e, 0x98, 0x05, 0xd1, 0xa8, 0xb4, 0x80, 0x91, 0x8b, 0xc3, 0x39, 0x3f, 0xaf, 0xa4, 0x28, 0x31,
+	0xb9, 0x44, 0xc8, 0x82, 0x8b, 0xa5, 0x38, 0x33, 0xbd, 0x58, 0x82, 0x51, 0x81, 0x59, 0x83, 0xc7,
+	0x49, 0xe5, 0xd7, 0x3d, 0x79, 0x05, 0x24, 0xd3, 0x32, 0xf3, 0xcb, 0x74, 0xf3, 0xf3, 0x52, 0xf5,
+	0xcb, 0x53, 0x13, 0xcb, 0x52, 0xf5, 0x1c, 0x53, 0x52, 0x8a, 0x52, 0x8b, 0x8b, 0x83, 0xc0, 0x3a,
+	0x84, 0x0c, 0xb9, 0x44, 0x12, 0x93, 0x4b, 0x32, 0xcb, 0x12, 0x4b, 0x32, 0xf3, 0xf3, 0xe2, 0x4b,
+	0x32, 0x8a, 0x52, 0x8b, 0x33, 0xf2, 0x73, 0x52, 0x24, 0x98, 0x14, 0x18, 0x35, 0x98, 0x83, 0x84,
+	0x11, 0x72, 0x21, 0x30, 0x29, 0x21, 0x75, 0x2e, 0xfe, 0xc4, 0x94, 0xdc, 0x4c, 0x64, 0xd5, 0xcc,
+	0x60, 0xd5, 0x7c, 0x60, 0x61, 0xb8, 0x42, 0xa5, 0x95, 0x8c, 0x5c, 0x82, 0xce, 0x45, 0xa9, 0x89,
+	0x25, 0xa9, 0x30, 0x87, 0xfa, 0x16, 0xa7, 0x0f, 0x52, 0xb7, 0xee, 0x64, 0xe4, 0x12, 0x0c, 0x2d,
+	0x48, 0x41, 0x73, 0x2b, 0x1f, 0x17, 0x53, 0x66, 0x8a, 0x04, 0xa3, 0x02, 0xa3, 0x06, 0x4f, 0x10,
+	0x53, 0x66, 0x0a, 0xdc, 0xed, 0x4c, 0x54, 0x73, 0x3b, 0x33, 0x49, 0x6e, 0x67, 0xc1, 0xe6, 0x76,
+	0x27, 0x81, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2,
+	0x63, 0x39, 0x86, 0x24, 0x36, 0x70, 0x1a, 0x31, 0x06, 0x0c, 0x00, 0xc5, 0xbf, 0xc1, 0x35, 0x76,
+	0x02, 0x00, 0x00,
 }
@@ -23,9 +23,9 @@ func newContextWithAuth(perms ...weave.Condition) (weave.Context, x.Authenticato
 }
 
 // newSigs creates an array with addresses from each condition
-func newSigs(perms ...weave.Condition) [][]byte {
+func newSigs(perms ...weave.Condition) []weave.Address {
 	// initial addresses controlling contract
-	var sigs [][]byte
+	var sigs []weave.Address
 	for _, p := range perms {
 		sigs = append(sigs, p.Address())
 	}
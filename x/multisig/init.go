@@ -2,6 +2,13 @@ package multisig
 
 import "github.com/iov-one/weave"
 
+// genesisContract is the genesis format of a Contract
+type genesisContract struct {
+	Sigs                []weave.Address `json:"sigs"`
+	ActivationThreshold int64           `json:"activation_threshold"`
+	AdminThreshold      int64           `json:"admin_threshold"`
+}
+
 // Initializer fulfils the Initializer interface to load data from the genesis
 // file
 type Initializer struct{}
@@ -11,23 +18,15 @@ var _ weave.Initializer = (*Initializer)(nil)
 // FromGenesis will parse initial account info from genesis and save it in the
 // database.
 func (*Initializer) FromGenesis(opts weave.Options, db weave.KVStore) error {
-	var contracts []struct {
-		Sigs                []weave.Address `json:"sigs"`
-		ActivationThreshold int64           `json:"activation_threshold"`
-		AdminThreshold      int64           `json:"admin_threshold"`
-	}
+	var contracts []genesisContract
 	if err := opts.ReadOptions("multisig", &contracts); err != nil {
 		return err
 	}
 
 	bucket := NewContractBucket()
 	for _, c := range contracts {
-		sigs := make([][]byte, 0, len(c.Sigs))
-		for _, s := range c.Sigs {
-			sigs = append(sigs, []byte(s))
-		}
 		contract := Contract{
-			Sigs:                sigs,
+			Sigs:                c.Sigs,
 			ActivationThreshold: c.ActivationThreshold,
 			AdminThreshold:      c.AdminThreshold,
 		}
@@ -38,3 +37,26 @@ func (*Initializer) FromGenesis(opts weave.Options, db weave.KVStore) error {
 	}
 	return nil
 }
+
+var _ weave.Exporter = (*Initializer)(nil)
+
+// ToGenesis exports all contracts in the format read by FromGenesis.
+// Contracts are never deleted and exported in the order of their IDs,
+// so FromGenesis assigns them the same IDs again.
+func (*Initializer) ToGenesis(db weave.ReadOnlyKVStore) (weave.Options, error) {
+	objs, err := NewContractBucket().All(db)
+	if err != nil {
+		return nil, err
+	}
+	contracts := make([]genesisContract, 0, len(objs))
+	for _, obj := range objs {
+		c := obj.Value().(*Contract)
+		contracts = append(contracts, genesisContract{
+			Sigs:                c.Sigs,
+			ActivationThreshold: c.ActivationThreshold,
+			AdminThreshold:      c.AdminThreshold,
+		})
+	}
+	opts := weave.Options{}
+	return opts, opts.WriteOptions("multisig", contracts)
+}
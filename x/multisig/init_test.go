@@ -59,7 +59,7 @@ func TestGenesisKey(t *testing.T) {
 	if want, got := int64(2), c.AdminThreshold; want != got {
 		t.Errorf("want admin threshold %d, got %d", want, got)
 	}
-	wantSigs := [][]byte{
+	wantSigs := []weave.Address{
 		fromHex(t, "e4c7e4c71a3b301a2521753ddd1d2c26fd6fe1bf"),
 		fromHex(t, "904bc35e341b428d4faa535022b553efbc443d49"),
 		fromHex(t, "91d66344d78599b66e1b504db958b1b07a8f5049"),
@@ -85,3 +85,61 @@ func fromHex(t *testing.T, s string) []byte {
 	}
 	return raw
 }
+
+func TestGenesisExport(t *testing.T) {
+	const genesis = `
+		{
+			"multisig": [
+				{
+					"sigs": [
+						"e4c7e4c71a3b301a2521753ddd1d2c26fd6fe1bf",
+						"904bc35e341b428d4faa535022b553efbc443d49"
+					],
+					"activation_threshold": 1,
+					"admin_threshold": 2
+				},
+				{
+					"sigs": ["91d66344d78599b66e1b504db958b1b07a8f5049"],
+					"activation_threshold": 1,
+					"admin_threshold": 1
+				}
+			]
+		}
+	`
+
+	var opts weave.Options
+	if err := json.Unmarshal([]byte(genesis), &opts); err != nil {
+		t.Fatalf("cannot unmarshal genesis: %s", err)
+	}
+
+	var ini Initializer
+	db := store.MemStore()
+	if err := ini.FromGenesis(opts, db); err != nil {
+		t.Fatalf("cannot load genesis: %s", err)
+	}
+	exported, err := ini.ToGenesis(db)
+	if err != nil {
+		t.Fatalf("cannot export genesis: %s", err)
+	}
+
+	var want, got []genesisContract
+	if err := opts.ReadOptions("multisig", &want); err != nil {
+		t.Fatalf("cannot read genesis: %s", err)
+	}
+	if err := exported.ReadOptions("multisig", &got); err != nil {
+		t.Fatalf("cannot read exported genesis: %s", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	// loading the export must assign the same IDs
+	db2 := store.MemStore()
+	if err := ini.FromGenesis(exported, db2); err != nil {
+		t.Fatalf("cannot load exported genesis: %s", err)
+	}
+	obj, err := NewContractBucket().Get(db2, seq(2))
+	if err != nil || obj == nil {
+		t.Fatalf("cannot fetch second contract: %v", err)
+	}
+}
@@ -8,6 +8,8 @@ import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
 
+import github_com_iov_one_weave "github.com/iov-one/weave"
+
 import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -27,7 +29,7 @@ type NonFungibleToken struct {
 	// ID is the address of this token.
 	ID []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	// Owner is the address of the token owner.
-	Owner []byte `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Owner github_com_iov_one_weave.Address `protobuf:"bytes,2,opt,name=owner,proto3,casttype=github.com/iov-one/weave.Address" json:"owner,omitempty"`
 	// Action approvals is a list of permissions. In order for operation to
 	// succeed, all action approvals validation must pass.
 	ActionApprovals      []ActionApprovals `protobuf:"bytes,3,rep,name=action_approvals,json=actionApprovals" json:"action_approvals"`
@@ -39,7 +41,7 @@ func (m *NonFungibleToken) Reset()         { *m = NonFungibleToken{} }
 func (m *NonFungibleToken) String() string { return proto.CompactTextString(m) }
 func (*NonFungibleToken) ProtoMessage()    {}
 func (*NonFungibleToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_fd78a077c59ce03c, []int{0}
+	return fileDescriptor_codec_d9afc19fb3c857ba, []int{0}
 }
 func (m *NonFungibleToken) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -75,7 +77,7 @@ func (m *NonFungibleToken) GetID() []byte {
 	return nil
 }
 
-func (m *NonFungibleToken) GetOwner() []byte {
+func (m *NonFungibleToken) GetOwner() github_com_iov_one_weave.Address {
 	if m != nil {
 		return m.Owner
 	}
@@ -102,7 +104,7 @@ func (m *ActionApprovals) Reset()         { *m = ActionApprovals{} }
 func (m *ActionApprovals) String() string { return proto.CompactTextString(m) }
 func (*ActionApprovals) ProtoMessage()    {}
 func (*ActionApprovals) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_fd78a077c59ce03c, []int{1}
+	return fileDescriptor_codec_d9afc19fb3c857ba, []int{1}
 }
 func (m *ActionApprovals) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -149,7 +151,7 @@ func (m *Approval) Reset()         { *m = Approval{} }
 func (m *Approval) String() string { return proto.CompactTextString(m) }
 func (*Approval) ProtoMessage()    {}
 func (*Approval) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_fd78a077c59ce03c, []int{2}
+	return fileDescriptor_codec_d9afc19fb3c857ba, []int{2}
 }
 func (m *Approval) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -213,7 +215,7 @@ func (m *ApprovalOptions) Reset()         { *m = ApprovalOptions{} }
 func (m *ApprovalOptions) String() string { return proto.CompactTextString(m) }
 func (*ApprovalOptions) ProtoMessage()    {}
 func (*ApprovalOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_fd78a077c59ce03c, []int{3}
+	return fileDescriptor_codec_d9afc19fb3c857ba, []int{3}
 }
 func (m *ApprovalOptions) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -277,7 +279,7 @@ func (m *AddApprovalMsg) Reset()         { *m = AddApprovalMsg{} }
 func (m *AddApprovalMsg) String() string { return proto.CompactTextString(m) }
 func (*AddApprovalMsg) ProtoMessage()    {}
 func (*AddApprovalMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_fd78a077c59ce03c, []int{4}
+	return fileDescriptor_codec_d9afc19fb3c857ba, []int{4}
 }
 func (m *AddApprovalMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -347,7 +349,7 @@ func (m *RemoveApprovalMsg) Reset()         { *m = RemoveApprovalMsg{} }
 func (m *RemoveApprovalMsg) String() string { return proto.CompactTextString(m) }
 func (*RemoveApprovalMsg) ProtoMessage()    {}
 func (*RemoveApprovalMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_fd78a077c59ce03c, []int{5}
+	return fileDescriptor_codec_d9afc19fb3c857ba, []int{5}
 }
 func (m *RemoveApprovalMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1723,36 +1725,37 @@ var (
 	ErrIntOverflowCodec   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("x/nft/codec.proto", fileDescriptor_codec_fd78a077c59ce03c) }
-
-var fileDescriptor_codec_fd78a077c59ce03c = []byte{
-	// 433 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x93, 0xcd, 0x8a, 0xd4, 0x40,
-	0x14, 0x85, 0xa7, 0x92, 0x9e, 0x9e, 0xe9, 0xeb, 0x38, 0x9d, 0x29, 0x06, 0x09, 0x22, 0xe9, 0x26,
-	0x0b, 0xe9, 0x85, 0x26, 0xf8, 0xf3, 0x02, 0x1d, 0x54, 0x74, 0xa1, 0x42, 0xe1, 0xca, 0x4d, 0x93,
-	0x9f, 0x4a, 0xba, 0x98, 0xa4, 0x2a, 0x24, 0x95, 0x51, 0xf0, 0x01, 0xdc, 0xfa, 0x24, 0x3e, 0xc7,
-	0x2c, 0x5d, 0xbb, 0x68, 0x24, 0xbe, 0x88, 0xa4, 0x2a, 0x31, 0x33, 0x0d, 0x8a, 0x2b, 0x77, 0xb9,
-	0xf7, 0x9c, 0x5b, 0x5f, 0xdd, 0x13, 0x0a, 0xce, 0x3e, 0xfa, 0x3c, 0x95, 0x7e, 0x2c, 0x12, 0x1a,
-	0x7b, 0x65, 0x25, 0xa4, 0xc0, 0x26, 0x4f, 0xe5, 0xdd, 0x87, 0x19, 0x93, 0xdb, 0x26, 0xf2, 0x62,
-	0x51, 0xf8, 0x99, 0xc8, 0x84, 0xaf, 0xb4, 0xa8, 0x49, 0x55, 0xa5, 0x0a, 0xf5, 0xa5, 0x67, 0xdc,
-	0xcf, 0x08, 0xac, 0x37, 0x82, 0xbf, 0x68, 0x78, 0xc6, 0xa2, 0x9c, 0xbe, 0x13, 0x17, 0x94, 0xe3,
-	0x3b, 0x60, 0xb0, 0xc4, 0x46, 0x4b, 0xb4, 0x3a, 0x09, 0xa6, 0xed, 0x6e, 0x61, 0xbc, 0x7a, 0x46,
-	0x0c, 0x96, 0xe0, 0x73, 0x38, 0x14, 0x1f, 0x38, 0xad, 0x6c, 0xa3, 0x93, 0x88, 0x2e, 0xf0, 0x73,
-	0xb0, 0xc2, 0x58, 0x32, 0xc1, 0x37, 0x61, 0x59, 0x56, 0xe2, 0x32, 0xcc, 0x6b, 0xdb, 0x5c, 0x9a,
-	0xab, 0x5b, 0x8f, 0xcf, 0x3d, 0x9e, 0x4a, 0x6f, 0xad, 0xc4, 0xf5, 0xa0, 0x05, 0x93, 0xab, 0xdd,
-	0xe2, 0x80, 0xcc, 0xc3, 0x9b, 0x6d, 0x37, 0x87, 0xf9, 0x9e, 0x13, 0xdf, 0x87, 0xa9, 0x76, 0xa9,
-	0xbb, 0xcc, 0x82, 0xd3, 0x6e, 0xf2, 0xfb, 0x6e, 0x31, 0xd5, 0x46, 0xd2, 0xab, 0xf8, 0x11, 0xcc,
-	0x46, 0xb4, 0xa1, 0xd0, 0xb7, 0x35, 0xba, 0xef, 0xf6, 0xcc, 0xd1, 0xe5, 0xbe, 0x87, 0xe3, 0x41,
-	0xc4, 0x36, 0x1c, 0x85, 0x49, 0x52, 0xd1, 0xba, 0xd6, 0x3b, 0x93, 0xa1, 0xc4, 0x4f, 0xe1, 0x48,
-	0x94, 0x1d, 0xa2, 0x56, 0x2b, 0xff, 0xde, 0xa8, 0x9f, 0x7c, 0xab, 0xb5, 0xfe, 0xf4, 0xc1, 0xea,
-	0xd6, 0x30, 0xdf, 0x73, 0xe0, 0x07, 0x80, 0x1b, 0x2e, 0x59, 0xbe, 0x89, 0x72, 0x11, 0x5f, 0x6c,
-	0xb6, 0x94, 0x65, 0x5b, 0xa9, 0x68, 0x26, 0xb1, 0x94, 0x12, 0x74, 0xc2, 0x4b, 0xd5, 0xef, 0x72,
-	0x8e, 0x45, 0xc3, 0xa5, 0x82, 0x9a, 0x44, 0x17, 0xf8, 0x1e, 0xcc, 0x58, 0x51, 0x34, 0x32, 0x8c,
-	0x72, 0x6a, 0x9b, 0x4b, 0xb4, 0x3a, 0x26, 0x63, 0xc3, 0xfd, 0x8a, 0xe0, 0x74, 0x9d, 0x24, 0x03,
-	0xf8, 0x75, 0x9d, 0xfd, 0xf1, 0x37, 0x5e, 0xdb, 0xd7, 0xb8, 0xb9, 0xef, 0x18, 0xb8, 0xf9, 0xd7,
-	0xc0, 0xaf, 0xe5, 0x32, 0xf9, 0xe7, 0x5c, 0xf0, 0x09, 0x20, 0x69, 0x1f, 0x76, 0x07, 0x13, 0x24,
-	0xdd, 0x4f, 0x70, 0x46, 0x68, 0x21, 0x2e, 0xe9, 0xff, 0xb9, 0xb2, 0x82, 0x4f, 0x7a, 0x78, 0x60,
-	0x5d, 0xb5, 0x0e, 0xfa, 0xd6, 0x3a, 0xe8, 0x47, 0xeb, 0xa0, 0x2f, 0x3f, 0x9d, 0x83, 0x68, 0xaa,
-	0xde, 0xc3, 0x93, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x20, 0x8b, 0x9d, 0x52, 0x58, 0x03, 0x00,
-	0x00,
+func init() { proto.RegisterFile("x/nft/codec.proto", fileDescriptor_codec_d9afc19fb3c857ba) }
+
+var fileDescriptor_codec_d9afc19fb3c857ba = []byte{
+	// 453 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0xbb, 0x76, 0x92, 0x36, 0x43, 0x69, 0xd2, 0x55, 0x85, 0x2c, 0x84, 0x92, 0xc8, 0x42,
+	0x28, 0x07, 0x6a, 0x8b, 0x3f, 0x27, 0x6e, 0xb1, 0x00, 0xc1, 0x01, 0x90, 0x56, 0x9c, 0xb8, 0x44,
+	0xfe, 0xb3, 0x71, 0x56, 0x75, 0x76, 0xa2, 0x78, 0x9d, 0x22, 0xf1, 0x12, 0xbc, 0x08, 0x3c, 0x47,
+	0x8f, 0x9c, 0x39, 0x44, 0x28, 0xbc, 0x05, 0x27, 0xe4, 0x5d, 0x1b, 0xa7, 0x91, 0x40, 0x9c, 0x7a,
+	0xf3, 0xcc, 0xf7, 0xcd, 0xfe, 0x76, 0x3e, 0x6b, 0xe1, 0xf4, 0xa3, 0x2f, 0x67, 0xca, 0x8f, 0x31,
+	0xe1, 0xb1, 0xb7, 0x5c, 0xa1, 0x42, 0x6a, 0xcb, 0x99, 0xba, 0x7b, 0x9e, 0x0a, 0x35, 0x2f, 0x22,
+	0x2f, 0xc6, 0x85, 0x9f, 0x62, 0x8a, 0xbe, 0xd6, 0xa2, 0x62, 0xa6, 0x2b, 0x5d, 0xe8, 0x2f, 0x33,
+	0xe3, 0x7e, 0x21, 0xd0, 0x7f, 0x8b, 0xf2, 0x65, 0x21, 0x53, 0x11, 0x65, 0xfc, 0x3d, 0x5e, 0x70,
+	0x49, 0xef, 0x80, 0x25, 0x12, 0x87, 0x8c, 0xc8, 0xf8, 0x38, 0xe8, 0x6c, 0x37, 0x43, 0xeb, 0xf5,
+	0x73, 0x66, 0x89, 0x84, 0x3e, 0x83, 0x36, 0x5e, 0x4a, 0xbe, 0x72, 0x2c, 0x2d, 0xdd, 0xff, 0xb5,
+	0x19, 0x8e, 0x76, 0x70, 0x02, 0xd7, 0xe7, 0x28, 0xb9, 0x7f, 0xc9, 0xc3, 0x35, 0xf7, 0x26, 0x49,
+	0xb2, 0xe2, 0x79, 0xce, 0xcc, 0x08, 0x7d, 0x01, 0xfd, 0x30, 0x56, 0x02, 0xe5, 0x34, 0x5c, 0x2e,
+	0x57, 0xb8, 0x0e, 0xb3, 0xdc, 0xb1, 0x47, 0xf6, 0xf8, 0xd6, 0xe3, 0x33, 0x4f, 0xce, 0x94, 0x37,
+	0xd1, 0xe2, 0xa4, 0xd6, 0x82, 0xd6, 0xd5, 0x66, 0x78, 0xc0, 0x7a, 0xe1, 0xf5, 0xb6, 0x9b, 0x41,
+	0x6f, 0xcf, 0x49, 0x1f, 0x40, 0xc7, 0xb8, 0xf4, 0x8d, 0xbb, 0xc1, 0x49, 0x39, 0xf9, 0x7d, 0x33,
+	0xec, 0x18, 0x23, 0xab, 0x54, 0xfa, 0x08, 0xba, 0x0d, 0xda, 0xd2, 0xe8, 0xdb, 0x06, 0x5d, 0x75,
+	0x2b, 0x66, 0xe3, 0x72, 0x3f, 0xc0, 0x51, 0x2d, 0x52, 0x07, 0x0e, 0x43, 0xb3, 0x92, 0x49, 0x86,
+	0xd5, 0x25, 0x7d, 0x0a, 0x87, 0xb8, 0x2c, 0x11, 0xb9, 0x0e, 0xe6, 0xcf, 0x46, 0xd5, 0xe4, 0x3b,
+	0xa3, 0x55, 0xa7, 0xd7, 0x56, 0x37, 0x87, 0xde, 0x9e, 0x83, 0x3e, 0x04, 0x5a, 0x48, 0x25, 0xb2,
+	0x69, 0x94, 0x61, 0x7c, 0x31, 0x9d, 0x73, 0x91, 0xce, 0x95, 0xa6, 0xd9, 0xac, 0xaf, 0x95, 0xa0,
+	0x14, 0x5e, 0xe9, 0x3e, 0x3d, 0x83, 0x76, 0x8c, 0x85, 0x54, 0x1a, 0x6a, 0x33, 0x53, 0xd0, 0x7b,
+	0xd0, 0x15, 0x8b, 0x45, 0xa1, 0xc2, 0x28, 0xe3, 0x8e, 0x3d, 0x22, 0xe3, 0x23, 0xd6, 0x34, 0xdc,
+	0xaf, 0x04, 0x4e, 0x26, 0x49, 0x52, 0x83, 0xdf, 0xe4, 0xe9, 0x5f, 0x7f, 0xf6, 0xce, 0xbe, 0xd6,
+	0xf5, 0x7d, 0x9b, 0xc0, 0xed, 0x7f, 0x06, 0xbe, 0x93, 0x4b, 0xeb, 0xbf, 0x73, 0xa1, 0xc7, 0x40,
+	0x94, 0xd3, 0x2e, 0x0f, 0x66, 0x44, 0xb9, 0x9f, 0xe0, 0x94, 0xf1, 0x05, 0xae, 0xf9, 0xcd, 0x5c,
+	0x59, 0xc3, 0x5b, 0x15, 0x3c, 0xe8, 0x5f, 0x6d, 0x07, 0xe4, 0xdb, 0x76, 0x40, 0x7e, 0x6c, 0x07,
+	0xe4, 0xf3, 0xcf, 0xc1, 0x41, 0xd4, 0xd1, 0xaf, 0xe6, 0xc9, 0xef, 0x01, 0x00, 0x3d, 0x35, 0x7e,
+	0xa1, 0x7e, 0x03, 0x00, 0x00,
 }
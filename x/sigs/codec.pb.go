@@ -37,7 +37,7 @@ func (m *UserData) Reset()         { *m = UserData{} }
 func (m *UserData) String() string { return proto.CompactTextString(m) }
 func (*UserData) ProtoMessage()    {}
 func (*UserData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_8a4a2ea66e77ba63, []int{0}
+	return fileDescriptor_codec_fe09bd9bce48d99f, []int{0}
 }
 func (m *UserData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -98,7 +98,7 @@ func (m *StdSignature) Reset()         { *m = StdSignature{} }
 func (m *StdSignature) String() string { return proto.CompactTextString(m) }
 func (*StdSignature) ProtoMessage()    {}
 func (*StdSignature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_8a4a2ea66e77ba63, []int{1}
+	return fileDescriptor_codec_fe09bd9bce48d99f, []int{1}
 }
 func (m *StdSignature) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -148,9 +148,69 @@ func (m *StdSignature) GetSignature() *crypto.Signature {
 	return nil
 }
 
+// ValidUntil limits until when a transaction can be executed.
+// A zero value of a field means there is no limit on it.
+type ValidUntil struct {
+	// Height is the last block height the transaction can be included in.
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// Time is the latest block time (in unix seconds) the transaction
+	// can be included at.
+	Time                 int64    `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidUntil) Reset()         { *m = ValidUntil{} }
+func (m *ValidUntil) String() string { return proto.CompactTextString(m) }
+func (*ValidUntil) ProtoMessage()    {}
+func (*ValidUntil) Descriptor() ([]byte, []int) {
+	return fileDescriptor_codec_fe09bd9bce48d99f, []int{2}
+}
+func (m *ValidUntil) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidUntil) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidUntil.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ValidUntil) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidUntil.Merge(dst, src)
+}
+func (m *ValidUntil) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidUntil) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidUntil.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidUntil proto.InternalMessageInfo
+
+func (m *ValidUntil) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ValidUntil) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*UserData)(nil), "sigs.UserData")
 	proto.RegisterType((*StdSignature)(nil), "sigs.StdSignature")
+	proto.RegisterType((*ValidUntil)(nil), "sigs.ValidUntil")
 }
 func (m *UserData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -228,6 +288,34 @@ func (m *StdSignature) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ValidUntil) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidUntil) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Height))
+	}
+	if m.Time != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Time))
+	}
+	return i, nil
+}
+
 func encodeVarintCodec(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -267,6 +355,18 @@ func (m *StdSignature) Size() (n int) {
 	return n
 }
 
+func (m *ValidUntil) Size() (n int) {
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovCodec(uint64(m.Height))
+	}
+	if m.Time != 0 {
+		n += 1 + sovCodec(uint64(m.Time))
+	}
+	return n
+}
+
 func sovCodec(x uint64) (n int) {
 	for {
 		n++
@@ -517,6 +617,94 @@ func (m *StdSignature) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ValidUntil) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidUntil: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidUntil: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipCodec(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -622,10 +810,10 @@ var (
 	ErrIntOverflowCodec   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("x/sigs/codec.proto", fileDescriptor_codec_8a4a2ea66e77ba63) }
+func init() { proto.RegisterFile("x/sigs/codec.proto", fileDescriptor_codec_fe09bd9bce48d99f) }
 
-var fileDescriptor_codec_8a4a2ea66e77ba63 = []byte{
-	// 224 bytes of a gzipped FileDescriptorProto
+var fileDescriptor_codec_fe09bd9bce48d99f = []byte{
+	// 260 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0xaa, 0xd0, 0x2f, 0xce,
 	0x4c, 0x2f, 0xd6, 0x4f, 0xce, 0x4f, 0x49, 0x4d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62,
 	0x01, 0x89, 0x48, 0xe9, 0xa4, 0x67, 0x96, 0x64, 0x94, 0x26, 0xe9, 0x25, 0xe7, 0xe7, 0xea, 0x67,
@@ -637,7 +825,10 @@ var fileDescriptor_codec_8a4a2ea66e77ba63 = []byte{
 	0x16, 0x96, 0xa6, 0xe6, 0x25, 0xa7, 0x4a, 0x30, 0x29, 0x30, 0x6a, 0x30, 0x07, 0xc1, 0xf9, 0x4a,
 	0x6d, 0x8c, 0x5c, 0x3c, 0xc1, 0x25, 0x29, 0xc1, 0x99, 0xe9, 0x79, 0x89, 0x25, 0xa5, 0x45, 0xa9,
 	0x28, 0x8a, 0x19, 0x51, 0x15, 0x23, 0xd9, 0xc9, 0x44, 0xc8, 0x4e, 0x7d, 0x2e, 0xce, 0x62, 0x98,
-	0x99, 0x12, 0x2c, 0xa8, 0xaa, 0xe1, 0x96, 0x05, 0x21, 0xd4, 0x38, 0x09, 0x9c, 0x78, 0x24, 0xc7,
-	0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x24, 0xb1, 0x81,
-	0x3d, 0x6d, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0xc4, 0xa8, 0xe4, 0x7c, 0x3e, 0x01, 0x00, 0x00,
+	0x99, 0x12, 0x2c, 0xa8, 0xaa, 0xe1, 0x96, 0x05, 0x21, 0xd4, 0x28, 0x59, 0x70, 0x71, 0x85, 0x25,
+	0xe6, 0x64, 0xa6, 0x84, 0xe6, 0x95, 0x64, 0xe6, 0x08, 0x89, 0x71, 0xb1, 0x65, 0xa4, 0x66, 0xa6,
+	0x67, 0x94, 0x40, 0xdd, 0x00, 0xe5, 0x09, 0x09, 0x71, 0xb1, 0x94, 0x64, 0xe6, 0xc2, 0xbc, 0x01,
+	0x66, 0x3b, 0x09, 0x9c, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c,
+	0x13, 0x1e, 0xcb, 0x31, 0x24, 0xb1, 0x81, 0x83, 0xcb, 0x18, 0x30, 0x00, 0xb0, 0xb5, 0xd5, 0x08,
+	0x78, 0x01, 0x00, 0x00,
 }
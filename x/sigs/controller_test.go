@@ -171,14 +171,41 @@ func TestVerifyTxSignatures(t *testing.T) {
 	}
 }
 
+func TestVerifyExpiringTxSignatures(t *testing.T) {
+	kv := store.MemStore()
+	priv := crypto.GenPrivKeyEd25519()
+	chainID := "expiring"
+
+	tx := NewStdTx([]byte("melting ice cream"))
+	tx.ValidUntil = &ValidUntil{Height: 10, Time: 1234567}
+	sig, err := SignTx(priv, tx, chainID, 0)
+	require.NoError(t, err)
+	tx.Signatures = []*StdSignature{sig}
+
+	// the expiration cannot be stripped or changed
+	tx.ValidUntil = nil
+	_, err = VerifyTxSignatures(kv, tx, chainID)
+	assert.True(t, errors.ErrUnauthorized.Is(err))
+	tx.ValidUntil = &ValidUntil{Height: 11, Time: 1234567}
+	_, err = VerifyTxSignatures(kv, tx, chainID)
+	assert.True(t, errors.ErrUnauthorized.Is(err))
+
+	tx.ValidUntil = &ValidUntil{Height: 10, Time: 1234567}
+	signers, err := VerifyTxSignatures(kv, tx, chainID)
+	require.NoError(t, err)
+	assert.Equal(t, []weave.Condition{priv.PublicKey().Condition()}, signers)
+}
+
 //----- mock objects for testing...
 
 type StdTx struct {
 	weave.Tx
 	Signatures []*StdSignature
+	ValidUntil *ValidUntil
 }
 
 var _ SignedTx = (*StdTx)(nil)
+var _ ExpiringTx = (*StdTx)(nil)
 var _ weave.Tx = (*StdTx)(nil)
 
 func NewStdTx(payload []byte) *StdTx {
@@ -193,6 +220,10 @@ func (tx StdTx) GetSignatures() []*StdSignature {
 	return tx.Signatures
 }
 
+func (tx StdTx) GetValidUntil() *ValidUntil {
+	return tx.ValidUntil
+}
+
 func (tx StdTx) GetSignBytes() ([]byte, error) {
 	// marshal self w/o sigs
 	s := tx.Signatures
@@ -207,5 +238,13 @@ func (tx StdTx) GetSignBytes() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the expiration is part of the signed data, like in a real tx
+	if !tx.ValidUntil.IsZero() {
+		exp, err := tx.ValidUntil.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		bz = append(bz, exp...)
+	}
 	return bz, nil
 }
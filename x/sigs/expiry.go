@@ -0,0 +1,59 @@
+package sigs
+
+import (
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+// ExpiryDecorator rejects transactions that passed their expiration,
+// as set by the ExpiringTx. Transactions without expiration are
+// passed along.
+type ExpiryDecorator struct{}
+
+var _ weave.Decorator = ExpiryDecorator{}
+
+// NewExpiryDecorator returns a decorator enforcing the tx expiration
+func NewExpiryDecorator() ExpiryDecorator {
+	return ExpiryDecorator{}
+}
+
+// Check verifies the tx did not expire before calling down the stack
+func (ExpiryDecorator) Check(ctx weave.Context, store weave.KVStore, tx weave.Tx,
+	next weave.Checker) (weave.CheckResult, error) {
+
+	if err := checkExpiry(ctx, tx); err != nil {
+		return weave.CheckResult{}, err
+	}
+	return next.Check(ctx, store, tx)
+}
+
+// Deliver verifies the tx did not expire before calling down the stack
+func (ExpiryDecorator) Deliver(ctx weave.Context, store weave.KVStore, tx weave.Tx,
+	next weave.Deliverer) (weave.DeliverResult, error) {
+
+	if err := checkExpiry(ctx, tx); err != nil {
+		return weave.DeliverResult{}, err
+	}
+	return next.Deliver(ctx, store, tx)
+}
+
+func checkExpiry(ctx weave.Context, tx weave.Tx) error {
+	etx, ok := tx.(ExpiringTx)
+	if !ok {
+		return nil
+	}
+	validUntil := etx.GetValidUntil()
+	if validUntil.IsZero() {
+		return nil
+	}
+	if err := validUntil.Validate(); err != nil {
+		return err
+	}
+	height, _ := weave.GetHeight(ctx)
+	header, _ := weave.GetHeader(ctx)
+	if validUntil.Expired(height, header.Time) {
+		return errors.ErrExpired.Newf("valid until height %d, time %d",
+			validUntil.Height, validUntil.Time)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package sigs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestExpiryDecorator(t *testing.T) {
+	now := time.Unix(1000000, 0)
+
+	cases := map[string]struct {
+		validUntil *ValidUntil
+		wantErr    *errors.Error
+	}{
+		"no expiration": {
+			validUntil: nil,
+		},
+		"zero expiration": {
+			validUntil: &ValidUntil{},
+		},
+		"current height": {
+			validUntil: &ValidUntil{Height: 10},
+		},
+		"past height": {
+			validUntil: &ValidUntil{Height: 9},
+			wantErr:    &errors.ErrExpired,
+		},
+		"current time": {
+			validUntil: &ValidUntil{Time: now.Unix()},
+		},
+		"past time": {
+			validUntil: &ValidUntil{Time: now.Unix() - 1},
+			wantErr:    &errors.ErrExpired,
+		},
+		"future height, past time": {
+			validUntil: &ValidUntil{Height: 20, Time: now.Unix() - 1},
+			wantErr:    &errors.ErrExpired,
+		},
+		"negative height": {
+			validUntil: &ValidUntil{Height: -1},
+			wantErr:    &errors.ErrInvalidInput,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			ctx := weave.WithHeader(context.Background(), abci.Header{Time: now})
+			ctx = weave.WithHeight(ctx, 10)
+			db := store.MemStore()
+			tx := NewStdTx([]byte("payload"))
+			tx.ValidUntil = tc.validUntil
+			d := NewExpiryDecorator()
+
+			_, cerr := d.Check(ctx, db, tx, &weavetest.Handler{})
+			_, derr := d.Deliver(ctx, db, tx, &weavetest.Handler{})
+			for _, err := range []error{cerr, derr} {
+				if tc.wantErr == nil {
+					assert.NoError(t, err)
+				} else {
+					assert.True(t, tc.wantErr.Is(err), "got %v", err)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package sigs
 
 import (
+	"time"
+
 	"github.com/iov-one/weave/errors"
 )
 
@@ -18,6 +20,15 @@ type SignedTx interface {
 	GetSignatures() []*StdSignature
 }
 
+// ExpiringTx represents a transaction that can only be executed
+// until a given block height or time, which is verified by the
+// ExpiryDecorator. The expiration must be part of GetSignBytes,
+// so it is covered by the signatures
+type ExpiringTx interface {
+	// GetValidUntil returns the expiration, nil if the tx never expires
+	GetValidUntil() *ValidUntil
+}
+
 // Validate ensures the StdSignature meets basic standards
 func (s *StdSignature) Validate() error {
 	seq := s.GetSequence()
@@ -33,3 +44,31 @@ func (s *StdSignature) Validate() error {
 
 	return nil
 }
+
+// IsZero returns true if there is no expiration set
+func (v *ValidUntil) IsZero() bool {
+	return v == nil || (v.Height == 0 && v.Time == 0)
+}
+
+// Validate ensures the ValidUntil has no negative limits
+func (v *ValidUntil) Validate() error {
+	if v.GetHeight() < 0 {
+		return errors.ErrInvalidInput.New("negative valid until height")
+	}
+	if v.GetTime() < 0 {
+		return errors.ErrInvalidInput.New("negative valid until time")
+	}
+	return nil
+}
+
+// Expired returns true if the transaction cannot be executed
+// anymore at the given block height and time
+func (v *ValidUntil) Expired(height int64, now time.Time) bool {
+	if v.GetHeight() > 0 && height > v.Height {
+		return true
+	}
+	if v.GetTime() > 0 && now.Unix() > v.Time {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,1030 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: x/slashing/codec.proto
+
+package slashing
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import _ "github.com/gogo/protobuf/gogoproto"
+import validators "github.com/iov-one/weave/x/validators"
+
+import github_com_iov_one_weave "github.com/iov-one/weave"
+
+import io "io"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// Config defines how misbehaving validators are punished.
+type Config struct {
+	// number of consecutive blocks a validator may miss to sign before it
+	// is punished for downtime, zero disables downtime tracking
+	MaxMissedBlocks int64 `protobuf:"varint,1,opt,name=max_missed_blocks,json=maxMissedBlocks,proto3" json:"max_missed_blocks,omitempty"`
+	// percentage of the power and of the bonded coins a validator loses
+	// for downtime, a validator left without power is jailed
+	DowntimePenalty int32 `protobuf:"varint,2,opt,name=downtime_penalty,json=downtimePenalty,proto3" json:"downtime_penalty,omitempty"`
+	// percentage of the bonded coins a validator loses for double signing,
+	// a double signing validator is always jailed
+	DoubleSignPenalty int32 `protobuf:"varint,3,opt,name=double_sign_penalty,json=doubleSignPenalty,proto3" json:"double_sign_penalty,omitempty"`
+	// address receiving the slashed coins, they are burned if empty
+	Destination          github_com_iov_one_weave.Address `protobuf:"bytes,4,opt,name=destination,proto3,casttype=github.com/iov-one/weave.Address" json:"destination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *Config) Reset()         { *m = Config{} }
+func (m *Config) String() string { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()    {}
+func (*Config) Descriptor() ([]byte, []int) {
+	return fileDescriptor_codec_9519225a0c64d806, []int{0}
+}
+func (m *Config) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Config) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Config.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Config) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Config.Merge(dst, src)
+}
+func (m *Config) XXX_Size() int {
+	return m.Size()
+}
+func (m *Config) XXX_DiscardUnknown() {
+	xxx_messageInfo_Config.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Config proto.InternalMessageInfo
+
+func (m *Config) GetMaxMissedBlocks() int64 {
+	if m != nil {
+		return m.MaxMissedBlocks
+	}
+	return 0
+}
+
+func (m *Config) GetDowntimePenalty() int32 {
+	if m != nil {
+		return m.DowntimePenalty
+	}
+	return 0
+}
+
+func (m *Config) GetDoubleSignPenalty() int32 {
+	if m != nil {
+		return m.DoubleSignPenalty
+	}
+	return 0
+}
+
+func (m *Config) GetDestination() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Destination
+	}
+	return nil
+}
+
+// Validator is the slashing state of a validator. Its power is kept in
+// the validator set of the application, see weave.GetValidators.
+// It is stored under the tendermint address of its public key.
+type Validator struct {
+	Pubkey validators.Pubkey `protobuf:"bytes,1,opt,name=pubkey" json:"pubkey"`
+	// address of the wallet holding the bonded coins,
+	// no coins are slashed if empty
+	Bond github_com_iov_one_weave.Address `protobuf:"bytes,3,opt,name=bond,proto3,casttype=github.com/iov-one/weave.Address" json:"bond,omitempty"`
+	// number of consecutive blocks the validator did not sign
+	MissedBlocks int64 `protobuf:"varint,4,opt,name=missed_blocks,json=missedBlocks,proto3" json:"missed_blocks,omitempty"`
+	// jailed validators are removed from the validator set
+	// and not tracked anymore
+	Jailed               bool     `protobuf:"varint,5,opt,name=jailed,proto3" json:"jailed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Validator) Reset()         { *m = Validator{} }
+func (m *Validator) String() string { return proto.CompactTextString(m) }
+func (*Validator) ProtoMessage()    {}
+func (*Validator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_codec_9519225a0c64d806, []int{1}
+}
+func (m *Validator) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Validator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Validator.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Validator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator.Merge(dst, src)
+}
+func (m *Validator) XXX_Size() int {
+	return m.Size()
+}
+func (m *Validator) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validator proto.InternalMessageInfo
+
+func (m *Validator) GetPubkey() validators.Pubkey {
+	if m != nil {
+		return m.Pubkey
+	}
+	return validators.Pubkey{}
+}
+
+func (m *Validator) GetBond() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Bond
+	}
+	return nil
+}
+
+func (m *Validator) GetMissedBlocks() int64 {
+	if m != nil {
+		return m.MissedBlocks
+	}
+	return 0
+}
+
+func (m *Validator) GetJailed() bool {
+	if m != nil {
+		return m.Jailed
+	}
+	return false
+}
+
+// Evidence is a handled double signing of a validator.
+// It is stored under the validator address and the height of the
+// infraction, so the same evidence is never punished twice.
+type Evidence struct {
+	// tendermint address of the validator
+	Validator []byte `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	// block height of the infraction
+	Height int64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// block time of the infraction, in seconds since unix epoch
+	Time int64 `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	// block height at which the evidence was handled
+	HandledAt            int64    `protobuf:"varint,4,opt,name=handled_at,json=handledAt,proto3" json:"handled_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Evidence) Reset()         { *m = Evidence{} }
+func (m *Evidence) String() string { return proto.CompactTextString(m) }
+func (*Evidence) ProtoMessage()    {}
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_codec_9519225a0c64d806, []int{2}
+}
+func (m *Evidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Evidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Evidence.Merge(dst, src)
+}
+func (m *Evidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Evidence proto.InternalMessageInfo
+
+func (m *Evidence) GetValidator() []byte {
+	if m != nil {
+		return m.Validator
+	}
+	return nil
+}
+
+func (m *Evidence) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Evidence) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Evidence) GetHandledAt() int64 {
+	if m != nil {
+		return m.HandledAt
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Config)(nil), "slashing.Config")
+	proto.RegisterType((*Validator)(nil), "slashing.Validator")
+	proto.RegisterType((*Evidence)(nil), "slashing.Evidence")
+}
+func (m *Config) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Config) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxMissedBlocks != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MaxMissedBlocks))
+	}
+	if m.DowntimePenalty != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.DowntimePenalty))
+	}
+	if m.DoubleSignPenalty != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.DoubleSignPenalty))
+	}
+	if len(m.Destination) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Destination)))
+		i += copy(dAtA[i:], m.Destination)
+	}
+	return i, nil
+}
+
+func (m *Validator) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Validator) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintCodec(dAtA, i, uint64(m.Pubkey.Size()))
+	n1, err := m.Pubkey.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n1
+	if len(m.Bond) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Bond)))
+		i += copy(dAtA[i:], m.Bond)
+	}
+	if m.MissedBlocks != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MissedBlocks))
+	}
+	if m.Jailed {
+		dAtA[i] = 0x28
+		i++
+		if m.Jailed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *Evidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Evidence) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Validator) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Validator)))
+		i += copy(dAtA[i:], m.Validator)
+	}
+	if m.Height != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Height))
+	}
+	if m.Time != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Time))
+	}
+	if m.HandledAt != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.HandledAt))
+	}
+	return i, nil
+}
+
+func encodeVarintCodec(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Config) Size() (n int) {
+	var l int
+	_ = l
+	if m.MaxMissedBlocks != 0 {
+		n += 1 + sovCodec(uint64(m.MaxMissedBlocks))
+	}
+	if m.DowntimePenalty != 0 {
+		n += 1 + sovCodec(uint64(m.DowntimePenalty))
+	}
+	if m.DoubleSignPenalty != 0 {
+		n += 1 + sovCodec(uint64(m.DoubleSignPenalty))
+	}
+	l = len(m.Destination)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *Validator) Size() (n int) {
+	var l int
+	_ = l
+	l = m.Pubkey.Size()
+	n += 1 + l + sovCodec(uint64(l))
+	l = len(m.Bond)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.MissedBlocks != 0 {
+		n += 1 + sovCodec(uint64(m.MissedBlocks))
+	}
+	if m.Jailed {
+		n += 2
+	}
+	return n
+}
+
+func (m *Evidence) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Validator)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovCodec(uint64(m.Height))
+	}
+	if m.Time != 0 {
+		n += 1 + sovCodec(uint64(m.Time))
+	}
+	if m.HandledAt != 0 {
+		n += 1 + sovCodec(uint64(m.HandledAt))
+	}
+	return n
+}
+
+func sovCodec(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozCodec(x uint64) (n int) {
+	return sovCodec(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Config) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Config: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Config: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMissedBlocks", wireType)
+			}
+			m.MaxMissedBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMissedBlocks |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DowntimePenalty", wireType)
+			}
+			m.DowntimePenalty = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DowntimePenalty |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DoubleSignPenalty", wireType)
+			}
+			m.DoubleSignPenalty = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DoubleSignPenalty |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Destination = append(m.Destination[:0], dAtA[iNdEx:postIndex]...)
+			if m.Destination == nil {
+				m.Destination = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Validator) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Validator: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Validator: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pubkey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Pubkey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bond", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bond = append(m.Bond[:0], dAtA[iNdEx:postIndex]...)
+			if m.Bond == nil {
+				m.Bond = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MissedBlocks", wireType)
+			}
+			m.MissedBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MissedBlocks |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Jailed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Jailed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Evidence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Evidence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Evidence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Validator = append(m.Validator[:0], dAtA[iNdEx:postIndex]...)
+			if m.Validator == nil {
+				m.Validator = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HandledAt", wireType)
+			}
+			m.HandledAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HandledAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipCodec(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthCodec
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowCodec
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipCodec(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthCodec = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowCodec   = fmt.Errorf("proto: integer overflow")
+)
+
+func init() { proto.RegisterFile("x/slashing/codec.proto", fileDescriptor_codec_9519225a0c64d806) }
+
+var fileDescriptor_codec_9519225a0c64d806 = []byte{
+	// 423 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xcd, 0x6e, 0xd3, 0x40,
+	0x14, 0x85, 0x3b, 0xd8, 0x8d, 0x92, 0xdb, 0xa0, 0xb6, 0x83, 0x54, 0x59, 0x15, 0xa4, 0x56, 0x60,
+	0x11, 0x90, 0x6a, 0xf3, 0xb3, 0x61, 0xdb, 0x20, 0xd8, 0x21, 0x55, 0x46, 0x62, 0x6b, 0x8d, 0x3d,
+	0xb7, 0xe3, 0xa1, 0xf6, 0x4c, 0x94, 0x99, 0xa4, 0xe9, 0x5b, 0xf0, 0x30, 0x3c, 0x44, 0x97, 0xec,
+	0xd8, 0x55, 0x28, 0xbc, 0x05, 0x2b, 0xd4, 0x1b, 0xa7, 0x09, 0x0b, 0x16, 0xec, 0xe6, 0x7c, 0x73,
+	0xae, 0x7d, 0xee, 0xd1, 0xc0, 0xd1, 0x22, 0x75, 0xb5, 0x70, 0x95, 0x36, 0x2a, 0x2d, 0xad, 0xc4,
+	0x32, 0x99, 0x4c, 0xad, 0xb7, 0xbc, 0xbb, 0xa6, 0xc7, 0xa7, 0x4a, 0xfb, 0x6a, 0x56, 0x24, 0xa5,
+	0x6d, 0x52, 0x65, 0x95, 0x4d, 0xc9, 0x50, 0xcc, 0x2e, 0x48, 0x91, 0xa0, 0xd3, 0x6a, 0xf0, 0xf8,
+	0xd5, 0x96, 0x5d, 0xdb, 0xf9, 0xa9, 0x35, 0x98, 0x5e, 0xa1, 0x98, 0x63, 0xba, 0x48, 0xe7, 0xa2,
+	0xd6, 0x52, 0x78, 0x3b, 0x75, 0xdb, 0xff, 0x1a, 0xfe, 0x60, 0xd0, 0x79, 0x67, 0xcd, 0x85, 0x56,
+	0xfc, 0x05, 0x1c, 0x36, 0x62, 0x91, 0x37, 0xda, 0x39, 0x94, 0x79, 0x51, 0xdb, 0xf2, 0xd2, 0x45,
+	0x2c, 0x66, 0xa3, 0x20, 0xdb, 0x6f, 0xc4, 0xe2, 0x23, 0xf1, 0x31, 0x61, 0xfe, 0x1c, 0x0e, 0xa4,
+	0xbd, 0x32, 0x5e, 0x37, 0x98, 0x4f, 0xd0, 0x88, 0xda, 0x5f, 0x47, 0x0f, 0x62, 0x36, 0xda, 0xcd,
+	0xf6, 0xd7, 0xfc, 0x7c, 0x85, 0x79, 0x02, 0x8f, 0xa4, 0x9d, 0x15, 0x35, 0xe6, 0x4e, 0x2b, 0x73,
+	0xef, 0x0e, 0xc8, 0x7d, 0xb8, 0xba, 0xfa, 0xa4, 0x95, 0x59, 0xfb, 0x3f, 0xc0, 0x9e, 0x44, 0xe7,
+	0xb5, 0x11, 0x5e, 0x5b, 0x13, 0x85, 0x31, 0x1b, 0xf5, 0xc7, 0xcf, 0x7e, 0xdf, 0x9e, 0xc4, 0xff,
+	0xda, 0x2e, 0x39, 0x93, 0x72, 0x8a, 0xce, 0x65, 0xdb, 0x83, 0xc3, 0x6f, 0x0c, 0x7a, 0x9f, 0xd7,
+	0x4b, 0xf3, 0x97, 0xd0, 0x99, 0xcc, 0x8a, 0x4b, 0xbc, 0xa6, 0x8d, 0xf6, 0x5e, 0xf3, 0x64, 0x53,
+	0x48, 0x72, 0x4e, 0x37, 0xe3, 0xf0, 0xe6, 0xf6, 0x64, 0x27, 0x6b, 0x7d, 0xfc, 0x2d, 0x84, 0x85,
+	0x35, 0x32, 0x0a, 0xfe, 0x23, 0x00, 0x4d, 0xf0, 0xa7, 0xf0, 0xf0, 0xef, 0x12, 0x43, 0x2a, 0xb1,
+	0xdf, 0x6c, 0x37, 0x78, 0x04, 0x9d, 0x2f, 0x42, 0xd7, 0x28, 0xa3, 0xdd, 0x98, 0x8d, 0xba, 0x59,
+	0xab, 0x86, 0x0e, 0xba, 0xef, 0xe7, 0x5a, 0xa2, 0x29, 0x91, 0x3f, 0x86, 0xde, 0x7d, 0x4a, 0xca,
+	0xdd, 0xcf, 0x36, 0xe0, 0xee, 0x0b, 0x15, 0x6a, 0x55, 0x79, 0x6a, 0x3e, 0xc8, 0x5a, 0xc5, 0x39,
+	0x84, 0x77, 0xfd, 0x53, 0xf0, 0x20, 0xa3, 0x33, 0x7f, 0x02, 0x50, 0x09, 0x23, 0x6b, 0x94, 0xb9,
+	0xf0, 0x6d, 0x9e, 0x5e, 0x4b, 0xce, 0xfc, 0xf8, 0xe0, 0x66, 0x39, 0x60, 0xdf, 0x97, 0x03, 0xf6,
+	0x73, 0x39, 0x60, 0x5f, 0x7f, 0x0d, 0x76, 0x8a, 0x0e, 0x3d, 0x8f, 0x37, 0x7f, 0x06, 0x00, 0xf5,
+	0x70, 0x49, 0x3e, 0xa4, 0x02, 0x00, 0x00,
+}
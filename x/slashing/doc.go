@@ -0,0 +1,32 @@
+/*
+Package slashing punishes validators that misbehave in consensus.
+
+At the beginning of every block tendermint reports the validators that
+signed the last block and the evidence of byzantine behaviour. The
+Ticker records every double signing and jails the validator, so it
+loses all its power. It also counts the consecutive blocks a validator
+did not sign; once the configured limit is reached, the validator loses
+a percentage of its power, or is jailed if no power is left.
+
+In both cases a percentage of the coins in the bond wallet of the
+validator is burned, or moved to the configured destination.
+
+The power of the validators is read from the validator set of the
+application (see weave.GetValidators), only its members are punished.
+Jailed validators are removed from the set again if they are added back.
+
+The configuration and the wallets holding the bonds of the validators
+are set in the genesis file:
+
+	"slashing": {
+		"config": {
+			"max_missed_blocks": 100,
+			"downtime_penalty": 10,
+			"double_sign_penalty": 50
+		},
+		"validators": [
+			{"pubkey": {"type": "ed25519", "data": "<base64>"}, "bond": "<hex address>"}
+		]
+	}
+*/
+package slashing
@@ -0,0 +1,99 @@
+package slashing
+
+import (
+	"fmt"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/x/validators"
+)
+
+const optKey = "slashing"
+
+// genesis is the genesis format of the slashing extension
+type genesis struct {
+	Config     *Config            `json:"config"`
+	Validators []genesisValidator `json:"validators"`
+}
+
+type genesisValidator struct {
+	Pubkey validators.Pubkey `json:"pubkey"`
+	Bond   weave.Address     `json:"bond,omitempty"`
+	Jailed bool              `json:"jailed,omitempty"`
+}
+
+// Initializer fulfils the Initializer interface to load data from the genesis
+// file
+type Initializer struct{}
+
+var _ weave.Initializer = (*Initializer)(nil)
+
+// FromGenesis stores the configuration and the bonds of the validators.
+// Nothing is tracked if no configuration is given.
+func (*Initializer) FromGenesis(opts weave.Options, db weave.KVStore) error {
+	var gen genesis
+	if err := opts.ReadOptions(optKey, &gen); err != nil {
+		return errors.Wrap(err, "cannot load slashing")
+	}
+	if gen.Config == nil {
+		if len(gen.Validators) != 0 {
+			return errors.ErrInvalidInput.New("validators without config")
+		}
+		return nil
+	}
+	if err := gen.Config.Validate(); err != nil {
+		return errors.Wrap(err, "config")
+	}
+	if err := NewConfigBucket().Store(db, gen.Config); err != nil {
+		return errors.Wrap(err, "cannot store config")
+	}
+
+	bucket := NewValidatorBucket()
+	for i, gv := range gen.Validators {
+		v := Validator{
+			Pubkey: gv.Pubkey,
+			Bond:   gv.Bond,
+			Jailed: gv.Jailed,
+		}
+		if err := v.Validate(); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("validator #%d is invalid", i))
+		}
+		if err := bucket.Track(db, &v); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("cannot store #%d validator", i))
+		}
+	}
+	return nil
+}
+
+var _ weave.Exporter = (*Initializer)(nil)
+
+// ToGenesis exports the configuration and the slashing state of the
+// validators in the format read by FromGenesis. Missed blocks are not
+// exported.
+func (*Initializer) ToGenesis(db weave.ReadOnlyKVStore) (weave.Options, error) {
+	opts := weave.Options{}
+	conf, err := NewConfigBucket().Load(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load config")
+	}
+	if conf == nil {
+		return opts, nil
+	}
+	objs, err := NewValidatorBucket().All(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load validators")
+	}
+	gen := genesis{
+		Config:     conf,
+		Validators: make([]genesisValidator, 0, len(objs)),
+	}
+	for _, obj := range objs {
+		v := obj.Value().(*Validator)
+		gen.Validators = append(gen.Validators, genesisValidator{
+			Pubkey: v.Pubkey,
+			Bond:   v.Bond,
+			Jailed: v.Jailed,
+		})
+	}
+	return opts, opts.WriteOptions(optKey, gen)
+}
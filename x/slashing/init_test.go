@@ -0,0 +1,76 @@
+package slashing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesis(t *testing.T) {
+	const genesis = `
+		{
+			"slashing": {
+				"config": {
+					"max_missed_blocks": 100,
+					"downtime_penalty": 10,
+					"double_sign_penalty": 50,
+					"destination": "E94323317C46BDA2268FA3698BAF4F95B893E8C7"
+				},
+				"validators": [
+					{
+						"pubkey": {"type": "ed25519", "data": "HWSDu0rrtsNyRlWb6Ka9MMOjeXWRwNAMOhx2Grok2CM="},
+						"bond": "FE5526DE08337DFEF5CF45EF3ED8C577B854DE34"
+					}
+				]
+			}
+		}
+	`
+	var opts weave.Options
+	require.NoError(t, json.Unmarshal([]byte(genesis), &opts))
+
+	db := store.MemStore()
+	var ini Initializer
+	require.NoError(t, ini.FromGenesis(opts, db))
+
+	conf, err := NewConfigBucket().Load(db)
+	require.NoError(t, err)
+	require.NotNil(t, conf)
+	assert.Equal(t, int64(100), conf.MaxMissedBlocks)
+	assert.Equal(t, int32(50), conf.DoubleSignPenalty)
+	assert.Equal(t, "E94323317C46BDA2268FA3698BAF4F95B893E8C7", conf.Destination.String())
+
+	objs, err := NewValidatorBucket().All(db)
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	val := objs[0].Value().(*Validator)
+	assert.Equal(t, "FE5526DE08337DFEF5CF45EF3ED8C577B854DE34", val.Bond.String())
+	assert.Equal(t, val.Address(), objs[0].Key())
+
+	exported, err := ini.ToGenesis(db)
+	require.NoError(t, err)
+	db2 := store.MemStore()
+	require.NoError(t, ini.FromGenesis(exported, db2))
+	objs2, err := NewValidatorBucket().All(db2)
+	require.NoError(t, err)
+	assert.Equal(t, objs, objs2)
+}
+
+func TestGenesisErrors(t *testing.T) {
+	cases := map[string]string{
+		"validators without config": `{"slashing": {"validators": [{"pubkey": {"type": "ed25519", "data": "HWSDu0rrtsNyRlWb6Ka9MMOjeXWRwNAMOhx2Grok2CM="}}]}}`,
+		"invalid penalty":           `{"slashing": {"config": {"downtime_penalty": 101}}}`,
+		"invalid pubkey":            `{"slashing": {"config": {}, "validators": [{"pubkey": {"type": "ed25519", "data": "AA=="}}]}}`,
+	}
+	for name, genesis := range cases {
+		t.Run(name, func(t *testing.T) {
+			var opts weave.Options
+			require.NoError(t, json.Unmarshal([]byte(genesis), &opts))
+			var ini Initializer
+			assert.Error(t, ini.FromGenesis(opts, store.MemStore()))
+		})
+	}
+}
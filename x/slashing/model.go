@@ -0,0 +1,254 @@
+package slashing
+
+import (
+	"encoding/binary"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/orm"
+	"github.com/iov-one/weave/x/validators"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+const (
+	// ConfigBucketName is where we store the slashing configuration
+	ConfigBucketName = "slashconf"
+	// ValidatorBucketName is where we store the slashing state of validators
+	ValidatorBucketName = "slashval"
+	// EvidenceBucketName is where we store the handled evidence
+	EvidenceBucketName = "slashev"
+
+	// configKey is the only key in the config bucket
+	configKey = "config"
+)
+
+var _ orm.CloneableData = (*Config)(nil)
+
+// Validate ensures the configuration is valid
+func (c *Config) Validate() error {
+	if c.MaxMissedBlocks < 0 {
+		return errors.ErrInvalidInput.Newf("max missed blocks: %d", c.MaxMissedBlocks)
+	}
+	if c.DowntimePenalty < 0 || c.DowntimePenalty > 100 {
+		return errors.ErrInvalidInput.Newf("downtime penalty: %d", c.DowntimePenalty)
+	}
+	if c.DoubleSignPenalty < 0 || c.DoubleSignPenalty > 100 {
+		return errors.ErrInvalidInput.Newf("double sign penalty: %d", c.DoubleSignPenalty)
+	}
+	if len(c.Destination) != 0 {
+		if err := c.Destination.Validate(); err != nil {
+			return errors.Wrap(err, "destination")
+		}
+	}
+	return nil
+}
+
+// Copy makes a new config with the same data
+func (c *Config) Copy() orm.CloneableData {
+	return &Config{
+		MaxMissedBlocks:   c.MaxMissedBlocks,
+		DowntimePenalty:   c.DowntimePenalty,
+		DoubleSignPenalty: c.DoubleSignPenalty,
+		Destination:       copyAddr(c.Destination),
+	}
+}
+
+var _ orm.CloneableData = (*Validator)(nil)
+
+// Validate ensures the validator is valid
+func (v *Validator) Validate() error {
+	update := validators.ValidatorUpdate{Pubkey: v.Pubkey}
+	if err := update.Validate(); err != nil {
+		return err
+	}
+	if len(v.Bond) != 0 {
+		if err := v.Bond.Validate(); err != nil {
+			return errors.Wrap(err, "bond")
+		}
+	}
+	if v.MissedBlocks < 0 {
+		return errors.ErrInvalidModel.Newf("missed blocks: %d", v.MissedBlocks)
+	}
+	return nil
+}
+
+// Copy makes a new validator with the same data
+func (v *Validator) Copy() orm.CloneableData {
+	return &Validator{
+		Pubkey: validators.Pubkey{
+			Type: v.Pubkey.Type,
+			Data: append([]byte(nil), v.Pubkey.Data...),
+		},
+		Bond:         copyAddr(v.Bond),
+		MissedBlocks: v.MissedBlocks,
+		Jailed:       v.Jailed,
+	}
+}
+
+// Address returns the tendermint address of the validator,
+// the key it is stored under
+func (v *Validator) Address() []byte {
+	return PubkeyAddress(v.Pubkey)
+}
+
+// PubkeyAddress returns the tendermint address of an ed25519 public key,
+// as used to identify validators in votes and evidence
+func PubkeyAddress(pk validators.Pubkey) []byte {
+	var key ed25519.PubKeyEd25519
+	copy(key[:], pk.Data)
+	return key.Address()
+}
+
+var _ orm.CloneableData = (*Evidence)(nil)
+
+// Validate ensures the evidence is valid
+func (e *Evidence) Validate() error {
+	if len(e.Validator) == 0 {
+		return errors.ErrEmpty.New("validator")
+	}
+	if e.Height <= 0 {
+		return errors.ErrInvalidModel.Newf("height: %d", e.Height)
+	}
+	if e.HandledAt <= 0 {
+		return errors.ErrInvalidModel.Newf("handled at: %d", e.HandledAt)
+	}
+	return nil
+}
+
+// Copy makes a new evidence with the same data
+func (e *Evidence) Copy() orm.CloneableData {
+	return &Evidence{
+		Validator: append([]byte(nil), e.Validator...),
+		Height:    e.Height,
+		Time:      e.Time,
+		HandledAt: e.HandledAt,
+	}
+}
+
+//--- ConfigBucket - holds the configuration
+
+// ConfigBucket is a type-safe wrapper around orm.Bucket,
+// holding a single configuration
+type ConfigBucket struct {
+	orm.Bucket
+}
+
+// NewConfigBucket initializes a ConfigBucket with default name
+func NewConfigBucket() ConfigBucket {
+	return ConfigBucket{
+		Bucket: orm.NewBucket(ConfigBucketName,
+			orm.NewSimpleObj(nil, new(Config))),
+	}
+}
+
+// Load returns the stored configuration, or nil if none is stored
+func (b ConfigBucket) Load(db weave.ReadOnlyKVStore) (*Config, error) {
+	obj, err := b.Get(db, []byte(configKey))
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil || obj.Value() == nil {
+		return nil, nil
+	}
+	return obj.Value().(*Config), nil
+}
+
+// Store saves the configuration
+func (b ConfigBucket) Store(db weave.KVStore, conf *Config) error {
+	return b.Save(db, orm.NewSimpleObj([]byte(configKey), conf))
+}
+
+//--- ValidatorBucket - handles the slashing state of validators
+
+// ValidatorBucket is a type-safe wrapper around orm.Bucket,
+// storing validators under their tendermint address
+type ValidatorBucket struct {
+	orm.Bucket
+}
+
+// NewValidatorBucket initializes a ValidatorBucket with default name
+func NewValidatorBucket() ValidatorBucket {
+	return ValidatorBucket{
+		Bucket: orm.NewBucket(ValidatorBucketName,
+			orm.NewSimpleObj(nil, new(Validator))),
+	}
+}
+
+// Track saves the validator under its address
+func (b ValidatorBucket) Track(db weave.KVStore, v *Validator) error {
+	return b.Save(db, orm.NewSimpleObj(v.Address(), v))
+}
+
+// GetValidator returns the validator with the given tendermint
+// address, or nil if it has no slashing state
+func (b ValidatorBucket) GetValidator(db weave.ReadOnlyKVStore, address []byte) (*Validator, error) {
+	obj, err := b.Get(db, address)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil || obj.Value() == nil {
+		return nil, nil
+	}
+	return obj.Value().(*Validator), nil
+}
+
+// Save enforces the proper type
+func (b ValidatorBucket) Save(db weave.KVStore, obj orm.Object) error {
+	if _, ok := obj.Value().(*Validator); !ok {
+		return errors.WithType(errors.ErrInvalidModel, obj.Value())
+	}
+	return b.Bucket.Save(db, obj)
+}
+
+//--- EvidenceBucket - handles recorded double signing
+
+// EvidenceBucket is a type-safe wrapper around orm.Bucket,
+// storing evidence under the validator address and height
+type EvidenceBucket struct {
+	orm.Bucket
+}
+
+// NewEvidenceBucket initializes an EvidenceBucket with default name
+func NewEvidenceBucket() EvidenceBucket {
+	return EvidenceBucket{
+		Bucket: orm.NewBucket(EvidenceBucketName,
+			orm.NewSimpleObj(nil, new(Evidence))),
+	}
+}
+
+// Record saves the evidence, unless it was recorded before.
+// It returns false if the evidence is already known.
+func (b EvidenceBucket) Record(db weave.KVStore, e *Evidence) (bool, error) {
+	key := evidenceKey(e.Validator, e.Height)
+	if obj, err := b.Get(db, key); err != nil {
+		return false, err
+	} else if obj != nil {
+		return false, nil
+	}
+	return true, b.Save(db, orm.NewSimpleObj(key, e))
+}
+
+// Save enforces the proper type
+func (b EvidenceBucket) Save(db weave.KVStore, obj orm.Object) error {
+	if _, ok := obj.Value().(*Evidence); !ok {
+		return errors.WithType(errors.ErrInvalidModel, obj.Value())
+	}
+	return b.Bucket.Save(db, obj)
+}
+
+func copyAddr(a weave.Address) weave.Address {
+	if a == nil {
+		return nil
+	}
+	cpy := make(weave.Address, len(a))
+	copy(cpy, a)
+	return cpy
+}
+
+// evidenceKey sorts the evidence by validator and height
+func evidenceKey(address []byte, height int64) []byte {
+	key := make([]byte, len(address)+8)
+	copy(key, address)
+	binary.BigEndian.PutUint64(key[len(address):], uint64(height))
+	return key
+}
@@ -0,0 +1,288 @@
+package slashing
+
+import (
+	"fmt"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/x/cash"
+	"github.com/iov-one/weave/x/validators"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+const (
+	// EventJailed is emitted when a validator loses all its power
+	EventJailed = "slashing.jailed"
+	// EventSlashed is emitted when a validator loses some of its power
+	// or bonded coins, but stays in the validator set
+	EventSlashed = "slashing.slashed"
+
+	// evidenceDuplicateVote is the only type of evidence tendermint reports
+	evidenceDuplicateVote = "duplicate/vote"
+)
+
+// RegisterQuery will register the slashing state of validators as
+// "/slashvalidators" and the handled evidence as "/slashevidence"
+func RegisterQuery(qr weave.QueryRouter) {
+	NewValidatorBucket().Register("slashvalidators", qr)
+	NewEvidenceBucket().Register("slashevidence", qr)
+}
+
+// Ticker punishes misbehaving validators at the beginning of a block.
+//
+// Double signing validators reported by tendermint are jailed. Validators
+// that did not sign the configured number of consecutive blocks lose
+// a part of their power. In both cases a part of their bonded coins is
+// burned or moved to the configured destination.
+type Ticker struct {
+	control    cash.Controller
+	config     ConfigBucket
+	validators ValidatorBucket
+	evidence   EvidenceBucket
+}
+
+var _ weave.Ticker = (*Ticker)(nil)
+
+// NewTicker creates a Ticker slashing bonded coins with the controller
+func NewTicker(control cash.Controller) *Ticker {
+	return &Ticker{
+		control:    control,
+		config:     NewConfigBucket(),
+		validators: NewValidatorBucket(),
+		evidence:   NewEvidenceBucket(),
+	}
+}
+
+// Tick handles the evidence and the votes of the last commit as passed
+// to BeginBlock. Nothing is done until a configuration is stored.
+//
+// The power of the validators is read from the validator set of the
+// application, see weave.GetValidators. Only members of the set are
+// punished, the returned diff updates their power. Jailed validators
+// that were added to the set again are removed from it.
+func (t *Ticker) Tick(ctx weave.Context, db weave.KVStore) (weave.TickResult, error) {
+	var res weave.TickResult
+
+	conf, err := t.config.Load(db)
+	if err != nil {
+		return res, errors.Wrap(err, "cannot load config")
+	}
+	if conf == nil {
+		return res, nil
+	}
+	height, ok := weave.GetHeight(ctx)
+	if !ok {
+		return res, errors.ErrHuman.New("missing block height")
+	}
+	set, err := validatorSet(db)
+	if err != nil {
+		return res, err
+	}
+
+	for _, m := range set.members {
+		v, err := t.validators.GetValidator(db, m.address)
+		if err != nil {
+			return res, errors.Wrap(err, "cannot load validator")
+		}
+		if v != nil && v.Jailed {
+			res.Diff = append(res.Diff, abci.ValidatorUpdate{PubKey: m.PubKey, Power: 0})
+		}
+	}
+
+	evidence, _ := weave.GetEvidence(ctx)
+	for _, ev := range evidence {
+		diff, err := t.doubleSign(ctx, db, conf, set, ev, height)
+		if err != nil {
+			return res, errors.Wrap(err, "double sign")
+		}
+		res.Diff = append(res.Diff, diff...)
+	}
+
+	if conf.MaxMissedBlocks == 0 {
+		return res, nil
+	}
+	info, _ := weave.GetCommitInfo(ctx)
+	for _, vote := range info.Votes {
+		diff, err := t.trackVote(ctx, db, conf, set, vote)
+		if err != nil {
+			return res, errors.Wrap(err, "downtime")
+		}
+		res.Diff = append(res.Diff, diff...)
+	}
+	return res, nil
+}
+
+// member is a validator of the set with its tendermint address
+type member struct {
+	abci.ValidatorUpdate
+	address []byte
+}
+
+// memberSet holds the members of the validator set in its order,
+// indexed by their tendermint address
+type memberSet struct {
+	members   []member
+	byAddress map[string]member
+}
+
+func (s memberSet) get(address []byte) (member, bool) {
+	m, ok := s.byAddress[string(address)]
+	return m, ok
+}
+
+// validatorSet loads the validator set of the application
+func validatorSet(db weave.ReadOnlyKVStore) (memberSet, error) {
+	vals, err := weave.GetValidators(db)
+	if err != nil {
+		return memberSet{}, errors.Wrap(err, "cannot load validator set")
+	}
+	set := memberSet{
+		members:   make([]member, 0, len(vals)),
+		byAddress: make(map[string]member, len(vals)),
+	}
+	for _, v := range vals {
+		address := PubkeyAddress(validators.FromABCI(v).Pubkey)
+		m := member{ValidatorUpdate: v, address: address}
+		set.members = append(set.members, m)
+		set.byAddress[string(address)] = m
+	}
+	return set, nil
+}
+
+// load returns the slashing state of the member,
+// creating it if there is none yet
+func (t *Ticker) load(db weave.KVStore, m member) (*Validator, error) {
+	v, err := t.validators.GetValidator(db, m.address)
+	if err != nil || v != nil {
+		return v, err
+	}
+	return &Validator{Pubkey: validators.FromABCI(m.ValidatorUpdate).Pubkey}, nil
+}
+
+// doubleSign records the evidence and jails the validator,
+// evidence that was handled before is ignored
+func (t *Ticker) doubleSign(ctx weave.Context, db weave.KVStore, conf *Config,
+	set memberSet, ev abci.Evidence, height int64) ([]abci.ValidatorUpdate, error) {
+
+	if ev.Type != evidenceDuplicateVote {
+		weave.GetLogger(ctx).Info("Unknown evidence", "type", ev.Type)
+		return nil, nil
+	}
+	m, ok := set.get(ev.Validator.Address)
+	if !ok {
+		return nil, nil
+	}
+	v, err := t.load(db, m)
+	if err != nil || v.Jailed {
+		return nil, err
+	}
+	fresh, err := t.evidence.Record(db, &Evidence{
+		Validator: ev.Validator.Address,
+		Height:    ev.Height,
+		Time:      ev.Time.Unix(),
+		HandledAt: height,
+	})
+	if err != nil || !fresh {
+		return nil, err
+	}
+	if err := t.slash(ctx, db, conf, v, conf.DoubleSignPenalty); err != nil {
+		return nil, err
+	}
+	return t.setPower(ctx, db, v, 0)
+}
+
+// trackVote counts the consecutive blocks a validator did not sign and
+// reduces its power once it missed too many
+func (t *Ticker) trackVote(ctx weave.Context, db weave.KVStore, conf *Config,
+	set memberSet, vote abci.VoteInfo) ([]abci.ValidatorUpdate, error) {
+
+	m, ok := set.get(vote.Validator.Address)
+	if !ok {
+		return nil, nil
+	}
+	v, err := t.load(db, m)
+	if err != nil || v.Jailed {
+		return nil, err
+	}
+	if vote.SignedLastBlock {
+		if v.MissedBlocks == 0 {
+			return nil, nil
+		}
+		v.MissedBlocks = 0
+		return nil, t.validators.Track(db, v)
+	}
+
+	v.MissedBlocks++
+	if v.MissedBlocks < conf.MaxMissedBlocks {
+		return nil, t.validators.Track(db, v)
+	}
+	v.MissedBlocks = 0
+	if err := t.slash(ctx, db, conf, v, conf.DowntimePenalty); err != nil {
+		return nil, err
+	}
+	return t.setPower(ctx, db, v, m.Power-m.Power*int64(conf.DowntimePenalty)/100)
+}
+
+// setPower jails the validator if no power is left, and returns
+// the diff to apply to the validator set
+func (t *Ticker) setPower(ctx weave.Context, db weave.KVStore,
+	v *Validator, power int64) ([]abci.ValidatorUpdate, error) {
+
+	event := EventSlashed
+	if power <= 0 {
+		power = 0
+		v.Jailed = true
+		event = EventJailed
+	}
+	if err := t.validators.Track(db, v); err != nil {
+		return nil, err
+	}
+	weave.EmitEvents(ctx, weave.NewEvent(event).
+		With("validator", fmt.Sprintf("%X", v.Address())).
+		With("power", fmt.Sprintf("%d", power)))
+	return []abci.ValidatorUpdate{{PubKey: v.Pubkey.AsABCI(), Power: power}}, nil
+}
+
+// slash takes the percentage of every coin bonded by the validator,
+// burning it or moving it to the configured destination
+func (t *Ticker) slash(ctx weave.Context, db weave.KVStore, conf *Config,
+	v *Validator, percent int32) error {
+
+	if len(v.Bond) == 0 || percent == 0 {
+		return nil
+	}
+	balance, err := t.control.Balance(db, v.Bond)
+	if errors.ErrNotFound.Is(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, c := range balance {
+		amount, err := percentOf(*c, percent)
+		if err != nil {
+			return err
+		}
+		if amount.IsZero() {
+			continue
+		}
+		if len(conf.Destination) == 0 {
+			err = t.control.IssueCoins(db, v.Bond, amount.Negative())
+		} else {
+			err = t.control.MoveCoins(db, v.Bond, conf.Destination, amount)
+		}
+		if err != nil {
+			return errors.Wrap(err, "cannot slash bond")
+		}
+	}
+	return nil
+}
+
+// percentOf returns the percentage of the coin, rounded down
+func percentOf(c coin.Coin, percent int32) (coin.Coin, error) {
+	piece, _, err := c.Divide(100)
+	if err != nil {
+		return coin.Coin{}, err
+	}
+	return piece.Multiply(int64(percent))
+}
@@ -0,0 +1,222 @@
+package slashing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/x/cash"
+	"github.com/iov-one/weave/x/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+// newValidator adds a validator to the validator set,
+// with its bond if given
+func newValidator(t *testing.T, db weave.KVStore, power int64, bond weave.Address) *Validator {
+	t.Helper()
+	pk := ed25519.GenPrivKey().PubKey().(ed25519.PubKeyEd25519)
+	v := &Validator{
+		Pubkey: validators.Pubkey{Type: "ed25519", Data: pk[:]},
+		Bond:   bond,
+	}
+	if bond != nil {
+		require.NoError(t, NewValidatorBucket().Track(db, v))
+	}
+	require.NoError(t, weave.UpdateValidators(db, []abci.ValidatorUpdate{{PubKey: v.Pubkey.AsABCI(), Power: power}}))
+	return v
+}
+
+// tick runs the ticker and applies the diff to the validator set, as done
+// at the end of the block
+func tick(t *testing.T, ticker *Ticker, ctx weave.Context, db weave.KVStore) weave.TickResult {
+	t.Helper()
+	res, err := ticker.Tick(ctx, db)
+	require.NoError(t, err)
+	require.NoError(t, weave.UpdateValidators(db, res.Diff))
+	return res
+}
+
+// power returns the power of the validator in the validator set
+func power(t *testing.T, db weave.KVStore, v *Validator) int64 {
+	t.Helper()
+	vals, err := weave.GetValidators(db)
+	require.NoError(t, err)
+	for _, val := range vals {
+		if bytes.Equal(val.PubKey.Data, v.Pubkey.Data) {
+			return val.Power
+		}
+	}
+	return 0
+}
+
+// blockContext returns the context of a block with the given commit votes and evidence
+func blockContext(height int64, votes []abci.VoteInfo, evidence ...abci.Evidence) weave.Context {
+	ctx := weave.WithHeight(context.Background(), height)
+	ctx = weave.WithCommitInfo(ctx, abci.LastCommitInfo{Votes: votes})
+	return weave.WithEvidence(ctx, evidence)
+}
+
+func vote(v *Validator, signed bool) abci.VoteInfo {
+	return abci.VoteInfo{
+		Validator:       abci.Validator{Address: v.Address()},
+		SignedLastBlock: signed,
+	}
+}
+
+func doubleSign(v *Validator, height int64) abci.Evidence {
+	return abci.Evidence{
+		Type:      evidenceDuplicateVote,
+		Validator: abci.Validator{Address: v.Address()},
+		Height:    height,
+		Time:      time.Unix(1000, 0),
+	}
+}
+
+func TestTickerDoubleSign(t *testing.T) {
+	db := store.MemStore()
+	ctrl := cash.NewController(cash.NewBucket())
+	bond := weavetest.NewCondition().Address()
+	require.NoError(t, ctrl.IssueCoins(db, bond, coin.NewCoin(10, 0, "IOV")))
+
+	require.NoError(t, NewConfigBucket().Store(db, &Config{DoubleSignPenalty: 50}))
+	val := newValidator(t, db, 7, bond)
+	other := newValidator(t, db, 3, nil)
+	vals := NewValidatorBucket()
+
+	ticker := NewTicker(ctrl)
+	events := weave.NewEventManager()
+	ctx := weave.WithEventManager(blockContext(5, nil, doubleSign(val, 4)), events)
+	res := tick(t, ticker, ctx, db)
+	assert.Equal(t, []abci.ValidatorUpdate{{PubKey: val.Pubkey.AsABCI(), Power: 0}}, res.Diff)
+	require.Len(t, events.Events(), 1)
+	assert.Equal(t, EventJailed, events.Events()[0].Type)
+	assert.Equal(t, int64(0), power(t, db, val))
+	assert.Equal(t, int64(3), power(t, db, other))
+
+	got, err := vals.GetValidator(db, val.Address())
+	require.NoError(t, err)
+	assert.True(t, got.Jailed)
+
+	// half of the bond is burned
+	balance, err := ctrl.Balance(db, bond)
+	require.NoError(t, err)
+	assert.Equal(t, coin.Coins{coin.NewCoinp(5, 0, "IOV")}, balance)
+
+	ev, err := NewEvidenceBucket().Get(db, evidenceKey(val.Address(), 4))
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.Equal(t, int64(5), ev.Value().(*Evidence).HandledAt)
+
+	// the same evidence is not punished twice, unknown evidence is ignored
+	unknown := doubleSign(other, 4)
+	unknown.Type = "light/attack"
+	res = tick(t, ticker, blockContext(6, nil, doubleSign(val, 4), unknown), db)
+	assert.Empty(t, res.Diff)
+	balance, err = ctrl.Balance(db, bond)
+	require.NoError(t, err)
+	assert.Equal(t, coin.Coins{coin.NewCoinp(5, 0, "IOV")}, balance)
+
+	// double signing of a validator without bond only jails it
+	res = tick(t, ticker, blockContext(7, nil, doubleSign(other, 6)), db)
+	assert.Equal(t, []abci.ValidatorUpdate{{PubKey: other.Pubkey.AsABCI(), Power: 0}}, res.Diff)
+	got, err = vals.GetValidator(db, other.Address())
+	require.NoError(t, err)
+	assert.True(t, got.Jailed)
+
+	// a jailed validator added to the set again is removed
+	require.NoError(t, weave.UpdateValidators(db, []abci.ValidatorUpdate{{PubKey: val.Pubkey.AsABCI(), Power: 5}}))
+	res = tick(t, ticker, blockContext(8, nil), db)
+	assert.Equal(t, []abci.ValidatorUpdate{{PubKey: val.Pubkey.AsABCI(), Power: 0}}, res.Diff)
+	assert.Equal(t, int64(0), power(t, db, val))
+}
+
+func TestTickerDowntime(t *testing.T) {
+	db := store.MemStore()
+	ctrl := cash.NewController(cash.NewBucket())
+	bond := weavetest.NewCondition().Address()
+	dest := weavetest.NewCondition().Address()
+	require.NoError(t, ctrl.IssueCoins(db, bond, coin.NewCoin(10, 0, "IOV")))
+
+	require.NoError(t, NewConfigBucket().Store(db, &Config{
+		MaxMissedBlocks: 2,
+		DowntimePenalty: 40,
+		Destination:     dest,
+	}))
+	val := newValidator(t, db, 10, bond)
+	vals := NewValidatorBucket()
+	// not in the validator set
+	unknown := &Validator{Pubkey: validators.Pubkey{Type: "ed25519", Data: make([]byte, 32)}}
+
+	ticker := NewTicker(ctrl)
+	cases := []struct {
+		signed  bool
+		missed  int64
+		power   int64
+		diff    bool
+		balance coin.Coins
+	}{
+		{signed: false, missed: 1, power: 10},
+		// signing resets the counter
+		{signed: true, missed: 0, power: 10},
+		{signed: false, missed: 1, power: 10},
+		{signed: false, missed: 0, power: 6, diff: true, balance: coin.Coins{coin.NewCoinp(4, 0, "IOV")}},
+		{signed: false, missed: 1, power: 6, balance: coin.Coins{coin.NewCoinp(4, 0, "IOV")}},
+		{signed: false, missed: 0, power: 4, diff: true, balance: coin.Coins{coin.NewCoinp(6, 400000000, "IOV")}},
+	}
+	for i, tc := range cases {
+		votes := []abci.VoteInfo{vote(val, tc.signed), vote(unknown, false)}
+		res := tick(t, ticker, blockContext(int64(i+2), votes), db)
+
+		got, err := vals.GetValidator(db, val.Address())
+		require.NoError(t, err)
+		assert.Equal(t, tc.missed, got.MissedBlocks, "case %d", i)
+		assert.Equal(t, tc.power, power(t, db, val), "case %d", i)
+		assert.False(t, got.Jailed, "case %d", i)
+		if tc.diff {
+			assert.Equal(t, []abci.ValidatorUpdate{{PubKey: val.Pubkey.AsABCI(), Power: tc.power}}, res.Diff, "case %d", i)
+		} else {
+			assert.Empty(t, res.Diff, "case %d", i)
+		}
+		if tc.balance != nil {
+			balance, err := ctrl.Balance(db, dest)
+			require.NoError(t, err)
+			assert.Equal(t, tc.balance, balance, "case %d", i)
+		}
+	}
+	got, err := vals.GetValidator(db, unknown.Address())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestTickerDowntimeWithoutBond(t *testing.T) {
+	db := store.MemStore()
+	require.NoError(t, NewConfigBucket().Store(db, &Config{MaxMissedBlocks: 1, DowntimePenalty: 100}))
+	val := newValidator(t, db, 10, nil)
+
+	ticker := NewTicker(cash.NewController(cash.NewBucket()))
+	res := tick(t, ticker, blockContext(2, []abci.VoteInfo{vote(val, false)}), db)
+	assert.Equal(t, []abci.ValidatorUpdate{{PubKey: val.Pubkey.AsABCI(), Power: 0}}, res.Diff)
+
+	got, err := NewValidatorBucket().GetValidator(db, val.Address())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, got.Jailed)
+}
+
+func TestTickerWithoutConfig(t *testing.T) {
+	db := store.MemStore()
+	val := newValidator(t, db, 10, nil)
+
+	ticker := NewTicker(cash.NewController(cash.NewBucket()))
+	res := tick(t, ticker, blockContext(3, []abci.VoteInfo{vote(val, false)}, doubleSign(val, 2)), db)
+	assert.Empty(t, res.Diff)
+	assert.Equal(t, int64(10), power(t, db, val))
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+)
+
+// GasLimiter is a decorator that meters all store access of its children
+// and aborts the transaction once it consumed more than the limit.
+//
+// The meter is put in the context, so handlers can consume gas for
+// other work. On CheckTx the gas allocated by the handlers is consumed
+// as well, and the total is reported as GasAllocated (GasWanted).
+// On DeliverTx the consumed gas is reported as GasUsed.
+type GasLimiter struct {
+	limit  int64
+	config store.GasConfig
+}
+
+var _ weave.Decorator = GasLimiter{}
+
+// NewGasLimiter creates a GasLimiter decorator allowing every
+// transaction to consume up to limit gas
+func NewGasLimiter(limit int64) GasLimiter {
+	return GasLimiter{
+		limit:  limit,
+		config: store.DefaultGasConfig(),
+	}
+}
+
+// WithConfig returns a GasLimiter charging the given costs
+func (g GasLimiter) WithConfig(config store.GasConfig) GasLimiter {
+	g.config = config
+	return g
+}
+
+// Check meters the child and ensures the gas it allocated
+// together with the consumed gas fits into the limit
+func (g GasLimiter) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx,
+	next weave.Checker) (res weave.CheckResult, err error) {
+
+	meter := store.NewGasMeter(g.limit)
+	defer recoverOutOfGas(&err)
+
+	ctx = weave.WithGasMeter(ctx, meter)
+	res, err = next.Check(ctx, store.NewGasStore(db, meter, g.config), tx)
+	if err != nil {
+		return res, err
+	}
+
+	meter.ConsumeGas(res.GasAllocated, "allocated")
+	res.GasAllocated = meter.GasConsumed()
+	res.GasUsed = meter.GasConsumed()
+	return res, nil
+}
+
+// Deliver meters the child and reports the consumed gas
+func (g GasLimiter) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx,
+	next weave.Deliverer) (res weave.DeliverResult, err error) {
+
+	meter := store.NewGasMeter(g.limit)
+	defer recoverOutOfGas(&err)
+
+	ctx = weave.WithGasMeter(ctx, meter)
+	res, err = next.Deliver(ctx, store.NewGasStore(db, meter, g.config), tx)
+	if err != nil {
+		return res, err
+	}
+
+	res.GasAllocated = g.limit
+	res.GasUsed += meter.GasConsumed()
+	return res, nil
+}
+
+// recoverOutOfGas turns a panic of the gas meter into an error,
+// any other panic is passed on
+func recoverOutOfGas(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if e, ok := r.(error); ok && errors.ErrOutOfGas.Is(e) {
+		*err = e
+		return
+	}
+	panic(r)
+}
@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+)
+
+func TestGasLimiter(t *testing.T) {
+	config := store.GasConfig{ReadCostFlat: 10, WriteCostFlat: 100}
+
+	cases := map[string]struct {
+		limit           int64
+		reads           int
+		allocated       int64
+		checkOutOfGas   bool
+		deliverOutOfGas bool
+		wantCheck       int64
+		wantDelivery    int64
+	}{
+		"within limit": {
+			limit:        1000,
+			reads:        3,
+			allocated:    50,
+			wantCheck:    3*10 + 100 + 50,
+			wantDelivery: 3*10 + 100,
+		},
+		"too many reads": {
+			limit:           100,
+			reads:           20,
+			checkOutOfGas:   true,
+			deliverOutOfGas: true,
+		},
+		"allocation over the limit": {
+			limit:         200,
+			reads:         1,
+			allocated:     100,
+			checkOutOfGas: true,
+			wantDelivery:  10 + 100,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := &gasHandler{reads: tc.reads, allocated: tc.allocated}
+			g := NewGasLimiter(tc.limit).WithConfig(config)
+			ctx := context.Background()
+
+			cres, err := g.Check(ctx, store.MemStore(), nil, h)
+			if tc.checkOutOfGas {
+				assert.True(t, errors.ErrOutOfGas.Is(err), "%+v", err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantCheck, cres.GasAllocated)
+				assert.Equal(t, tc.wantCheck, cres.ToABCI().GasWanted)
+			}
+
+			dres, err := g.Deliver(ctx, store.MemStore(), nil, h)
+			if tc.deliverOutOfGas {
+				assert.True(t, errors.ErrOutOfGas.Is(err), "%+v", err)
+			} else {
+				assert.NoError(t, err)
+				res := dres.ToABCI()
+				assert.Equal(t, tc.wantDelivery, res.GasUsed)
+				assert.Equal(t, tc.limit, res.GasWanted)
+			}
+		})
+	}
+}
+
+func TestGasLimiterPassesOtherPanics(t *testing.T) {
+	g := NewGasLimiter(1000)
+	assert.Panics(t, func() { g.Check(context.Background(), store.MemStore(), nil, panicHandler{}) })
+}
+
+func TestGasLimiterRollback(t *testing.T) {
+	g := NewGasLimiter(1000)
+	h := &spendHandler{write: []byte("written"), spend: 2000}
+
+	// without a savepoint, the write before running out of gas stays
+	db := store.MemStore()
+	_, err := g.Deliver(context.Background(), db, nil, h)
+	assert.True(t, errors.ErrOutOfGas.Is(err), "%+v", err)
+	assert.True(t, db.Has(h.write))
+
+	// the savepoint inside the GasLimiter discards it
+	db = store.MemStore()
+	sp := decorated{d: NewSavepoint().OnDeliver(), h: h}
+	_, err = g.Deliver(context.Background(), db, nil, sp)
+	assert.True(t, errors.ErrOutOfGas.Is(err), "%+v", err)
+	assert.False(t, db.Has(h.write))
+}
+
+// spendHandler writes a key and then consumes the given gas
+type spendHandler struct {
+	write []byte
+	spend int64
+}
+
+var _ weave.Handler = (*spendHandler)(nil)
+
+func (h *spendHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.CheckResult, error) {
+	return weave.CheckResult{}, nil
+}
+
+func (h *spendHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	db.Set(h.write, []byte("value"))
+	meter, _ := weave.GetGasMeter(ctx)
+	meter.ConsumeGas(h.spend, "spend")
+	return weave.DeliverResult{}, nil
+}
+
+// decorated calls the handler through the decorator
+type decorated struct {
+	d weave.Decorator
+	h weave.Handler
+}
+
+func (x decorated) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.CheckResult, error) {
+	return x.d.Check(ctx, db, tx, x.h)
+}
+
+func (x decorated) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	return x.d.Deliver(ctx, db, tx, x.h)
+}
+
+// gasHandler reads a few keys and writes one, it allocates
+// the given gas on check
+type gasHandler struct {
+	reads     int
+	allocated int64
+}
+
+var _ weave.Handler = (*gasHandler)(nil)
+
+func (h *gasHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.CheckResult, error) {
+	h.work(ctx, db)
+	return weave.NewCheck(h.allocated, ""), nil
+}
+
+func (h *gasHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (weave.DeliverResult, error) {
+	h.work(ctx, db)
+	return weave.DeliverResult{}, nil
+}
+
+func (h *gasHandler) work(ctx weave.Context, db weave.KVStore) {
+	if _, ok := weave.GetGasMeter(ctx); !ok {
+		panic("no gas meter in context")
+	}
+	for i := 0; i < h.reads; i++ {
+		db.Get([]byte{byte(i)})
+	}
+	db.Set([]byte("key"), nil)
+}
@@ -33,7 +33,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_4437bc3c3d7f77ca, []int{0}
+	return fileDescriptor_codec_165ffd9f373733db, []int{0}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -87,7 +87,7 @@ func (m *Pubkey) Reset()         { *m = Pubkey{} }
 func (m *Pubkey) String() string { return proto.CompactTextString(m) }
 func (*Pubkey) ProtoMessage()    {}
 func (*Pubkey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_4437bc3c3d7f77ca, []int{1}
+	return fileDescriptor_codec_165ffd9f373733db, []int{1}
 }
 func (m *Pubkey) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -141,7 +141,7 @@ func (m *SetValidatorsMsg) Reset()         { *m = SetValidatorsMsg{} }
 func (m *SetValidatorsMsg) String() string { return proto.CompactTextString(m) }
 func (*SetValidatorsMsg) ProtoMessage()    {}
 func (*SetValidatorsMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_4437bc3c3d7f77ca, []int{2}
+	return fileDescriptor_codec_165ffd9f373733db, []int{2}
 }
 func (m *SetValidatorsMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -188,7 +188,7 @@ func (m *Accounts) Reset()         { *m = Accounts{} }
 func (m *Accounts) String() string { return proto.CompactTextString(m) }
 func (*Accounts) ProtoMessage()    {}
 func (*Accounts) Descriptor() ([]byte, []int) {
-	return fileDescriptor_codec_4437bc3c3d7f77ca, []int{3}
+	return fileDescriptor_codec_165ffd9f373733db, []int{3}
 }
 func (m *Accounts) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -224,11 +224,175 @@ func (m *Accounts) GetAddresses() [][]byte {
 	return nil
 }
 
+// SetConsensusParamsMsg changes the consensus params of the chain.
+// Sections that are not set keep their current value.
+type SetConsensusParamsMsg struct {
+	BlockSize            *BlockSizeParams `protobuf:"bytes,1,opt,name=block_size,json=blockSize" json:"block_size,omitempty"`
+	Evidence             *EvidenceParams  `protobuf:"bytes,2,opt,name=evidence" json:"evidence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *SetConsensusParamsMsg) Reset()         { *m = SetConsensusParamsMsg{} }
+func (m *SetConsensusParamsMsg) String() string { return proto.CompactTextString(m) }
+func (*SetConsensusParamsMsg) ProtoMessage()    {}
+func (*SetConsensusParamsMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_codec_165ffd9f373733db, []int{4}
+}
+func (m *SetConsensusParamsMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetConsensusParamsMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetConsensusParamsMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SetConsensusParamsMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetConsensusParamsMsg.Merge(dst, src)
+}
+func (m *SetConsensusParamsMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetConsensusParamsMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetConsensusParamsMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetConsensusParamsMsg proto.InternalMessageInfo
+
+func (m *SetConsensusParamsMsg) GetBlockSize() *BlockSizeParams {
+	if m != nil {
+		return m.BlockSize
+	}
+	return nil
+}
+
+func (m *SetConsensusParamsMsg) GetEvidence() *EvidenceParams {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+// BlockSizeParams limits the size of a block
+type BlockSizeParams struct {
+	// Max block size, in bytes
+	MaxBytes int64 `protobuf:"varint,1,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// Max gas of all transactions in a block, -1 means no limit
+	MaxGas               int64    `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockSizeParams) Reset()         { *m = BlockSizeParams{} }
+func (m *BlockSizeParams) String() string { return proto.CompactTextString(m) }
+func (*BlockSizeParams) ProtoMessage()    {}
+func (*BlockSizeParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_codec_165ffd9f373733db, []int{5}
+}
+func (m *BlockSizeParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockSizeParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockSizeParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *BlockSizeParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSizeParams.Merge(dst, src)
+}
+func (m *BlockSizeParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockSizeParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSizeParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSizeParams proto.InternalMessageInfo
+
+func (m *BlockSizeParams) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *BlockSizeParams) GetMaxGas() int64 {
+	if m != nil {
+		return m.MaxGas
+	}
+	return 0
+}
+
+// EvidenceParams limits the age of an evidence
+type EvidenceParams struct {
+	// Max age of an evidence, in blocks
+	MaxAge               int64    `protobuf:"varint,1,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvidenceParams) Reset()         { *m = EvidenceParams{} }
+func (m *EvidenceParams) String() string { return proto.CompactTextString(m) }
+func (*EvidenceParams) ProtoMessage()    {}
+func (*EvidenceParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_codec_165ffd9f373733db, []int{6}
+}
+func (m *EvidenceParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EvidenceParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EvidenceParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *EvidenceParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvidenceParams.Merge(dst, src)
+}
+func (m *EvidenceParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *EvidenceParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvidenceParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvidenceParams proto.InternalMessageInfo
+
+func (m *EvidenceParams) GetMaxAge() int64 {
+	if m != nil {
+		return m.MaxAge
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ValidatorUpdate)(nil), "validators.ValidatorUpdate")
 	proto.RegisterType((*Pubkey)(nil), "validators.Pubkey")
 	proto.RegisterType((*SetValidatorsMsg)(nil), "validators.SetValidatorsMsg")
 	proto.RegisterType((*Accounts)(nil), "validators.Accounts")
+	proto.RegisterType((*SetConsensusParamsMsg)(nil), "validators.SetConsensusParamsMsg")
+	proto.RegisterType((*BlockSizeParams)(nil), "validators.BlockSizeParams")
+	proto.RegisterType((*EvidenceParams)(nil), "validators.EvidenceParams")
 }
 func (m *ValidatorUpdate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -347,6 +511,95 @@ func (m *Accounts) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *SetConsensusParamsMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetConsensusParamsMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.BlockSize != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.BlockSize.Size()))
+		n2, err := m.BlockSize.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.Evidence != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Evidence.Size()))
+		n3, err := m.Evidence.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	return i, nil
+}
+
+func (m *BlockSizeParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockSizeParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxBytes != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MaxBytes))
+	}
+	if m.MaxGas != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MaxGas))
+	}
+	return i, nil
+}
+
+func (m *EvidenceParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EvidenceParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxAge != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MaxAge))
+	}
+	return i, nil
+}
+
 func encodeVarintCodec(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -405,6 +658,41 @@ func (m *Accounts) Size() (n int) {
 	return n
 }
 
+func (m *SetConsensusParamsMsg) Size() (n int) {
+	var l int
+	_ = l
+	if m.BlockSize != nil {
+		l = m.BlockSize.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Evidence != nil {
+		l = m.Evidence.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *BlockSizeParams) Size() (n int) {
+	var l int
+	_ = l
+	if m.MaxBytes != 0 {
+		n += 1 + sovCodec(uint64(m.MaxBytes))
+	}
+	if m.MaxGas != 0 {
+		n += 1 + sovCodec(uint64(m.MaxGas))
+	}
+	return n
+}
+
+func (m *EvidenceParams) Size() (n int) {
+	var l int
+	_ = l
+	if m.MaxAge != 0 {
+		n += 1 + sovCodec(uint64(m.MaxAge))
+	}
+	return n
+}
+
 func sovCodec(x uint64) (n int) {
 	for {
 		n++
@@ -787,6 +1075,279 @@ func (m *Accounts) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SetConsensusParamsMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetConsensusParamsMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetConsensusParamsMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockSize", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockSize == nil {
+				m.BlockSize = &BlockSizeParams{}
+			}
+			if err := m.BlockSize.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Evidence == nil {
+				m.Evidence = &EvidenceParams{}
+			}
+			if err := m.Evidence.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BlockSizeParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockSizeParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockSizeParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+			}
+			m.MaxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGas", wireType)
+			}
+			m.MaxGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGas |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EvidenceParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EvidenceParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EvidenceParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAge", wireType)
+			}
+			m.MaxAge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAge |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipCodec(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -892,26 +1453,34 @@ var (
 	ErrIntOverflowCodec   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("x/validators/codec.proto", fileDescriptor_codec_4437bc3c3d7f77ca) }
-
-var fileDescriptor_codec_4437bc3c3d7f77ca = []byte{
-	// 277 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xa8, 0xd0, 0x2f, 0x4b,
-	0xcc, 0xc9, 0x4c, 0x49, 0x2c, 0xc9, 0x2f, 0x2a, 0xd6, 0x4f, 0xce, 0x4f, 0x49, 0x4d, 0xd6, 0x2b,
-	0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x42, 0x88, 0x4b, 0xe9, 0xa6, 0x67, 0x96, 0x64, 0x94, 0x26,
-	0xe9, 0x25, 0xe7, 0xe7, 0xea, 0xa7, 0xe7, 0xa7, 0xe7, 0xeb, 0x83, 0x95, 0x24, 0x95, 0xa6, 0x81,
-	0x79, 0x60, 0x0e, 0x98, 0x05, 0xd1, 0xaa, 0x14, 0xc9, 0xc5, 0x1f, 0x06, 0xd3, 0x1c, 0x5a, 0x90,
-	0x92, 0x58, 0x92, 0x2a, 0x64, 0xc0, 0xc5, 0x56, 0x50, 0x9a, 0x94, 0x9d, 0x5a, 0x29, 0xc1, 0xa8,
-	0xc0, 0xa8, 0xc1, 0x6d, 0x24, 0xa4, 0x87, 0x30, 0x5e, 0x2f, 0x00, 0x2c, 0xe3, 0xc4, 0x72, 0xe2,
-	0x9e, 0x3c, 0x43, 0x10, 0x54, 0x9d, 0x90, 0x08, 0x17, 0x6b, 0x41, 0x7e, 0x79, 0x6a, 0x91, 0x04,
-	0x93, 0x02, 0xa3, 0x06, 0x73, 0x10, 0x84, 0xa3, 0x64, 0xc0, 0xc5, 0x06, 0x51, 0x2d, 0x24, 0xc4,
-	0xc5, 0x52, 0x52, 0x59, 0x90, 0x0a, 0x36, 0x8f, 0x33, 0x08, 0xcc, 0x06, 0x89, 0xa5, 0x24, 0x96,
-	0x24, 0x82, 0xb5, 0xf0, 0x04, 0x81, 0xd9, 0x4a, 0x31, 0x5c, 0x02, 0xc1, 0xa9, 0x25, 0x70, 0xf7,
-	0x14, 0xfb, 0x16, 0xa7, 0x0b, 0x79, 0x70, 0x09, 0xc2, 0xad, 0x8f, 0x2f, 0x05, 0xbb, 0xb0, 0x58,
-	0x82, 0x51, 0x81, 0x59, 0x83, 0xdb, 0x48, 0x1a, 0xd9, 0x61, 0x68, 0xbe, 0x08, 0x12, 0x28, 0x43,
-	0x15, 0x28, 0x56, 0xd2, 0xe0, 0xe2, 0x70, 0x4c, 0x4e, 0xce, 0x2f, 0xcd, 0x2b, 0x29, 0x16, 0x92,
-	0xe1, 0xe2, 0x4c, 0x4c, 0x49, 0x29, 0x4a, 0x2d, 0x2e, 0x86, 0x9a, 0xc6, 0x13, 0x84, 0x10, 0x70,
-	0x12, 0x38, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x27, 0x3c,
-	0x96, 0x63, 0x48, 0x62, 0x03, 0x87, 0x96, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0xc9, 0x7e, 0x7f,
-	0xf4, 0x84, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("x/validators/codec.proto", fileDescriptor_codec_165ffd9f373733db) }
+
+var fileDescriptor_codec_165ffd9f373733db = []byte{
+	// 408 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x52, 0x4d, 0x8f, 0x93, 0x50,
+	0x14, 0x9d, 0xe7, 0x8c, 0x58, 0x6e, 0x27, 0x4e, 0x7d, 0xd1, 0x48, 0x66, 0x0c, 0x36, 0xac, 0x70,
+	0x21, 0x4c, 0x6a, 0xe2, 0xc2, 0xdd, 0x60, 0x4c, 0xdd, 0x98, 0x34, 0x34, 0x9a, 0x98, 0x98, 0x34,
+	0x0f, 0xb8, 0x22, 0x69, 0xe1, 0x11, 0xde, 0xa3, 0xd2, 0xfe, 0x05, 0x37, 0xfe, 0xac, 0x2e, 0xfd,
+	0x05, 0xc6, 0xd4, 0x3f, 0x32, 0xe1, 0x41, 0xe9, 0xc7, 0xee, 0xde, 0x73, 0xcf, 0x39, 0xef, 0xdc,
+	0x0b, 0x60, 0x54, 0xee, 0x92, 0x2d, 0x92, 0x88, 0x49, 0x5e, 0x08, 0x37, 0xe4, 0x11, 0x86, 0x4e,
+	0x5e, 0x70, 0xc9, 0x29, 0xec, 0xf1, 0xeb, 0xd7, 0x71, 0x22, 0x7f, 0x94, 0x81, 0x13, 0xf2, 0xd4,
+	0x8d, 0x79, 0xcc, 0x5d, 0x45, 0x09, 0xca, 0xef, 0xaa, 0x53, 0x8d, 0xaa, 0x1a, 0xa9, 0xf5, 0x15,
+	0xae, 0xbe, 0xec, 0xc4, 0x9f, 0xf3, 0x88, 0x49, 0xa4, 0xb7, 0xa0, 0xe5, 0x65, 0x30, 0xc7, 0x95,
+	0x41, 0x86, 0xc4, 0xee, 0x8f, 0xa8, 0xb3, 0xb7, 0x77, 0x26, 0x6a, 0xe2, 0x5d, 0x6c, 0xfe, 0xbe,
+	0x3c, 0xf3, 0x5b, 0x1e, 0x7d, 0x0a, 0x0f, 0x73, 0xfe, 0x13, 0x0b, 0xe3, 0xc1, 0x90, 0xd8, 0xe7,
+	0x7e, 0xd3, 0x58, 0xb7, 0xa0, 0x35, 0x6c, 0x4a, 0xe1, 0x42, 0xae, 0x72, 0x54, 0x7e, 0xba, 0xaf,
+	0xea, 0x1a, 0x8b, 0x98, 0x64, 0x4a, 0x72, 0xe9, 0xab, 0xda, 0xfa, 0x06, 0x83, 0x29, 0xca, 0x2e,
+	0x8f, 0xf8, 0x24, 0x62, 0xfa, 0x11, 0x9e, 0x74, 0xcf, 0xcf, 0x4a, 0x95, 0x50, 0x18, 0x64, 0x78,
+	0x6e, 0xf7, 0x47, 0x37, 0x87, 0xc1, 0x4e, 0xb6, 0xf0, 0x07, 0xcb, 0x63, 0x40, 0x58, 0x36, 0xf4,
+	0xee, 0xc2, 0x90, 0x97, 0x99, 0x14, 0xf4, 0x05, 0xe8, 0x2c, 0x8a, 0x0a, 0x14, 0xa2, 0x75, 0xbb,
+	0xf4, 0xf7, 0x80, 0xf5, 0x8b, 0xc0, 0xb3, 0x29, 0xca, 0xf7, 0x3c, 0x13, 0x98, 0x89, 0x52, 0x4c,
+	0x58, 0xc1, 0x52, 0x95, 0xe6, 0x1d, 0x40, 0xb0, 0xe0, 0xe1, 0x7c, 0x26, 0x92, 0x35, 0xb6, 0xf7,
+	0x39, 0x8a, 0xe1, 0xd5, 0xd3, 0x69, 0xb2, 0xc6, 0x46, 0xe3, 0xeb, 0xc1, 0x0e, 0xa0, 0x6f, 0xa1,
+	0x87, 0xcb, 0x24, 0xc2, 0x2c, 0x44, 0xb5, 0x75, 0x7f, 0x74, 0x7d, 0xa8, 0xfc, 0xd0, 0xce, 0x5a,
+	0x61, 0xc7, 0xb5, 0xc6, 0x70, 0x75, 0xe2, 0x4a, 0x6f, 0x40, 0x4f, 0x59, 0x35, 0x0b, 0x56, 0xcd,
+	0x31, 0xea, 0xa3, 0xf7, 0x52, 0x56, 0x79, 0x75, 0x4f, 0x9f, 0xc3, 0xa3, 0x7a, 0x18, 0x33, 0xd1,
+	0x7e, 0x0f, 0x2d, 0x65, 0xd5, 0x98, 0x09, 0xeb, 0x15, 0x3c, 0x3e, 0x7e, 0x64, 0x47, 0x65, 0x31,
+	0x1a, 0xa4, 0xa3, 0xde, 0xc5, 0xe8, 0x0d, 0x36, 0x5b, 0x93, 0xfc, 0xd9, 0x9a, 0xe4, 0xdf, 0xd6,
+	0x24, 0xbf, 0xff, 0x9b, 0x67, 0x81, 0xa6, 0xfe, 0x97, 0x37, 0xf7, 0x01, 0x00, 0x00, 0xff, 0xff,
+	0x12, 0xe4, 0xaa, 0x81, 0x86, 0x02, 0x00, 0x00,
 }
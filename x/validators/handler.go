@@ -1,8 +1,11 @@
 package validators
 
 import (
+	"bytes"
+
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/orm"
 	"github.com/iov-one/weave/x"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -21,11 +24,45 @@ func RegisterRoutes(r weave.Registry, auth x.Authenticator,
 	control Controller) {
 
 	r.Handle(pathUpdate, NewUpdateHandler(auth, control, authCheckAddress))
+	r.Handle(pathConsensusParams, NewConsensusParamsHandler(auth))
 }
 
 // RegisterQuery will register this bucket as "/validators"
+// and the current validator set as "/validatorset"
 func RegisterQuery(qr weave.QueryRouter) {
 	NewBucket().Register("validators", qr)
+	qr.Register("/validatorset", validatorSetQuery{})
+}
+
+// validatorSetQuery returns the validator set stored by the app,
+// all validators for no data, or the one with the given public key
+type validatorSetQuery struct{}
+
+var _ weave.QueryHandler = validatorSetQuery{}
+
+// Query returns every validator as a ValidatorUpdate under
+// the data of its public key
+func (validatorSetQuery) Query(db weave.ReadOnlyKVStore, mod string, data []byte) ([]weave.Model, error) {
+	if mod != weave.KeyQueryMod {
+		return nil, errors.ErrInvalidInput.Newf("unsupported mod: %s", mod)
+	}
+	vals, err := weave.GetValidators(db)
+	if err != nil {
+		return nil, err
+	}
+	var res []weave.Model
+	for _, v := range vals {
+		if len(data) != 0 && !bytes.Equal(data, v.PubKey.Data) {
+			continue
+		}
+		update := FromABCI(v)
+		raw, err := update.Marshal()
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot marshal validator")
+		}
+		res = append(res, weave.Pair(v.PubKey.Data, raw))
+	}
+	return res, nil
 }
 
 // UpdateHandler will handle sending coins
@@ -84,3 +121,64 @@ func (h UpdateHandler) validate(ctx weave.Context, store weave.KVStore, tx weave
 
 	return h.control.CanUpdateValidators(store, h.authCheckAddress(h.auth, ctx), msg.AsABCI())
 }
+
+// ConsensusParamsHandler changes the consensus params of the chain,
+// if authorized by one of the accounts allowed to update validators
+type ConsensusParamsHandler struct {
+	auth   x.Authenticator
+	bucket orm.Bucket
+}
+
+var _ weave.Handler = ConsensusParamsHandler{}
+
+// NewConsensusParamsHandler creates a handler for SetConsensusParamsMsg
+func NewConsensusParamsHandler(auth x.Authenticator) ConsensusParamsHandler {
+	return ConsensusParamsHandler{
+		auth:   auth,
+		bucket: NewBucket(),
+	}
+}
+
+// Check verifies all the preconditions
+func (h ConsensusParamsHandler) Check(ctx weave.Context, store weave.KVStore,
+	tx weave.Tx) (weave.CheckResult, error) {
+	var res weave.CheckResult
+	_, err := h.validate(ctx, store, tx)
+	return res, err
+}
+
+// Deliver returns the consensus params update, it is applied
+// at the end of the block
+func (h ConsensusParamsHandler) Deliver(ctx weave.Context, store weave.KVStore,
+	tx weave.Tx) (weave.DeliverResult, error) {
+	var res weave.DeliverResult
+	params, err := h.validate(ctx, store, tx)
+	if err != nil {
+		return res, err
+	}
+	res.ConsensusParams = params
+	return res, nil
+}
+
+func (h ConsensusParamsHandler) validate(ctx weave.Context, store weave.KVStore, tx weave.Tx) (*abci.ConsensusParams, error) {
+	rmsg, err := tx.GetMsg()
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := rmsg.(*SetConsensusParamsMsg)
+	if !ok {
+		return nil, errors.WithType(errors.ErrInvalidMsg, rmsg)
+	}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+
+	accts, err := GetAccounts(h.bucket, store)
+	if err != nil {
+		return nil, err
+	}
+	if !HasPermission(AsWeaveAccounts(accts), authCheckAddress(h.auth, ctx)) {
+		return nil, errors.ErrUnauthorized.New("consensus params")
+	}
+	return msg.AsABCI(), nil
+}
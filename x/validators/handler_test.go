@@ -10,6 +10,9 @@ import (
 	"github.com/iov-one/weave/weavetest"
 	"github.com/iov-one/weave/x/cash"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 )
 
@@ -76,3 +79,120 @@ func TestHandler(t *testing.T) {
 	})
 
 }
+
+func TestValidatorSetQuery(t *testing.T) {
+	pk1 := ed25519.GenPrivKey().PubKey().(ed25519.PubKeyEd25519)
+	pk2 := ed25519.GenPrivKey().PubKey().(ed25519.PubKeyEd25519)
+	set := []abci.ValidatorUpdate{
+		{PubKey: abci.PubKey{Type: "ed25519", Data: pk1[:]}, Power: 10},
+		{PubKey: abci.PubKey{Type: "ed25519", Data: pk2[:]}, Power: 5},
+	}
+	db := store.MemStore()
+	require.NoError(t, weave.UpdateValidators(db, set))
+
+	qr := weave.NewQueryRouter()
+	RegisterQuery(qr)
+	h := qr.Handler("/validatorset")
+	require.NotNil(t, h)
+
+	all, err := h.Query(db, weave.KeyQueryMod, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	one, err := h.Query(db, weave.KeyQueryMod, pk2[:])
+	require.NoError(t, err)
+	require.Len(t, one, 1)
+	var got ValidatorUpdate
+	require.NoError(t, got.Unmarshal(one[0].Value))
+	assert.Equal(t, FromABCI(set[1]), got)
+
+	_, err = h.Query(db, weave.PrefixQueryMod, nil)
+	assert.True(t, errors.ErrInvalidInput.Is(err))
+}
+
+func TestConsensusParamsHandler(t *testing.T) {
+	allowed := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	accts := WeaveAccounts{[]weave.Address{allowed.Address()}}
+	accountsJson, err := json.Marshal(accts)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		signer  weave.Condition
+		msg     weave.Msg
+		want    *abci.ConsensusParams
+		wantErr *errors.Error
+	}{
+		"block size": {
+			signer: allowed,
+			msg: &SetConsensusParamsMsg{
+				BlockSize: &BlockSizeParams{MaxBytes: 1000, MaxGas: -1},
+			},
+			want: &abci.ConsensusParams{
+				BlockSize: &abci.BlockSizeParams{MaxBytes: 1000, MaxGas: -1},
+			},
+		},
+		"all sections": {
+			signer: allowed,
+			msg: &SetConsensusParamsMsg{
+				BlockSize: &BlockSizeParams{MaxBytes: 1000, MaxGas: 500},
+				Evidence:  &EvidenceParams{MaxAge: 100},
+			},
+			want: &abci.ConsensusParams{
+				BlockSize: &abci.BlockSizeParams{MaxBytes: 1000, MaxGas: 500},
+				Evidence:  &abci.EvidenceParams{MaxAge: 100},
+			},
+		},
+		"not allowed": {
+			signer: other,
+			msg: &SetConsensusParamsMsg{
+				Evidence: &EvidenceParams{MaxAge: 100},
+			},
+			wantErr: &errors.ErrUnauthorized,
+		},
+		"no sections": {
+			signer:  allowed,
+			msg:     &SetConsensusParamsMsg{},
+			wantErr: &errors.ErrEmpty,
+		},
+		"invalid evidence age": {
+			signer: allowed,
+			msg: &SetConsensusParamsMsg{
+				Evidence: &EvidenceParams{MaxAge: 0},
+			},
+			wantErr: &errors.ErrInvalidInput,
+		},
+		"invalid message": {
+			signer:  allowed,
+			msg:     &cash.SendMsg{},
+			wantErr: &errors.ErrInvalidMsg,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := store.MemStore()
+			err := Initializer{}.FromGenesis(weave.Options{optKey: accountsJson}, db)
+			require.NoError(t, err)
+
+			h := NewConsensusParamsHandler(&weavetest.Auth{Signer: tc.signer})
+			tx := &weavetest.Tx{Msg: tc.msg}
+
+			_, err = h.Check(nil, db, tx)
+			if tc.wantErr != nil {
+				require.True(t, tc.wantErr.Is(err), "check: %+v", err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			res, err := h.Deliver(nil, db, tx)
+			if tc.wantErr != nil {
+				require.True(t, tc.wantErr.Is(err), "deliver: %+v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, res.ConsensusParams)
+		})
+	}
+}
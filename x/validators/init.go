@@ -36,3 +36,19 @@ func (Initializer) FromGenesis(opts weave.Options, kv weave.KVStore) error {
 
 	return nil
 }
+
+var _ weave.Exporter = Initializer{}
+
+// ToGenesis exports the accounts allowed to update validators
+func (Initializer) ToGenesis(db weave.ReadOnlyKVStore) (weave.Options, error) {
+	obj, err := NewBucket().Get(db, []byte(Key))
+	if err != nil {
+		return nil, err
+	}
+	opts := weave.Options{}
+	if obj == nil {
+		return opts, nil
+	}
+	accounts := AsWeaveAccounts(obj.Value().(*Accounts))
+	return opts, opts.WriteOptions(optKey, accounts)
+}
@@ -59,5 +59,17 @@ func TestInitState(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(accounts, ShouldResemble, AsAccounts(accts2))
 		})
+
+		Convey("Export returns the loaded contents", func() {
+			err := init.FromGenesis(weave.Options{optKey: accountsJson2}, kv)
+			So(err, ShouldBeNil)
+
+			opts, err := init.ToGenesis(kv)
+			So(err, ShouldBeNil)
+			var exported WeaveAccounts
+			err = opts.ReadOptions(optKey, &exported)
+			So(err, ShouldBeNil)
+			So(exported, ShouldResemble, accts2)
+		})
 	})
 }
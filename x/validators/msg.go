@@ -36,6 +36,14 @@ func (m ValidatorUpdate) AsABCI() abci.ValidatorUpdate {
 	}
 }
 
+// FromABCI converts a validator update as used by tendermint
+func FromABCI(v abci.ValidatorUpdate) ValidatorUpdate {
+	return ValidatorUpdate{
+		Pubkey: Pubkey{Type: v.PubKey.Type, Data: v.PubKey.Data},
+		Power:  v.Power,
+	}
+}
+
 func (m Pubkey) AsABCI() abci.PubKey {
 	return abci.PubKey{
 		Data: m.Data,
@@ -63,3 +71,38 @@ func (m *SetValidatorsMsg) AsABCI() []abci.ValidatorUpdate {
 
 	return validators
 }
+
+// Ensure we implement the Msg interface
+var _ weave.Msg = (*SetConsensusParamsMsg)(nil)
+
+const pathConsensusParams = "validators/consensus_params"
+
+// Path returns the routing path for this message
+func (*SetConsensusParamsMsg) Path() string {
+	return pathConsensusParams
+}
+
+func (m *SetConsensusParamsMsg) Validate() error {
+	if m.BlockSize == nil && m.Evidence == nil {
+		return errors.ErrEmpty.New("consensus params")
+	}
+	return weave.ValidateConsensusParams(m.AsABCI())
+}
+
+// AsABCI returns the consensus params update as used by tendermint,
+// with only the sections set in the message
+func (m *SetConsensusParamsMsg) AsABCI() *abci.ConsensusParams {
+	var params abci.ConsensusParams
+	if m.BlockSize != nil {
+		params.BlockSize = &abci.BlockSizeParams{
+			MaxBytes: m.BlockSize.MaxBytes,
+			MaxGas:   m.BlockSize.MaxGas,
+		}
+	}
+	if m.Evidence != nil {
+		params.Evidence = &abci.EvidenceParams{
+			MaxAge: m.Evidence.MaxAge,
+		}
+	}
+	return &params
+}